@@ -109,15 +109,21 @@ const (
 	RestorePaths     = "RESTORE_PATHS"
 	RestoreSnapshots = "RESTORE_SNAPSHOTS"
 
-	RetentionKeepLast    = "RETENTION_KEEP_LAST"
-	RetentionKeepHourly  = "RETENTION_KEEP_HOURLY"
-	RetentionKeepDaily   = "RETENTION_KEEP_DAILY"
-	RetentionKeepWeekly  = "RETENTION_KEEP_WEEKLY"
-	RetentionKeepMonthly = "RETENTION_KEEP_MONTHLY"
-	RetentionKeepYearly  = "RETENTION_KEEP_YEARLY"
-	RetentionKeepTags    = "RETENTION_KEEP_TAGS"
-	RetentionPrune       = "RETENTION_PRUNE"
-	RetentionDryRun      = "RETENTION_DRY_RUN"
+	RetentionKeepLast          = "RETENTION_KEEP_LAST"
+	RetentionKeepHourly        = "RETENTION_KEEP_HOURLY"
+	RetentionKeepDaily         = "RETENTION_KEEP_DAILY"
+	RetentionKeepWeekly        = "RETENTION_KEEP_WEEKLY"
+	RetentionKeepMonthly       = "RETENTION_KEEP_MONTHLY"
+	RetentionKeepYearly        = "RETENTION_KEEP_YEARLY"
+	RetentionKeepTags          = "RETENTION_KEEP_TAGS"
+	RetentionKeepWithin        = "RETENTION_KEEP_WITHIN"
+	RetentionKeepWithinHourly  = "RETENTION_KEEP_WITHIN_HOURLY"
+	RetentionKeepWithinDaily   = "RETENTION_KEEP_WITHIN_DAILY"
+	RetentionKeepWithinWeekly  = "RETENTION_KEEP_WITHIN_WEEKLY"
+	RetentionKeepWithinMonthly = "RETENTION_KEEP_WITHIN_MONTHLY"
+	RetentionKeepWithinYearly  = "RETENTION_KEEP_WITHIN_YEARLY"
+	RetentionPrune             = "RETENTION_PRUNE"
+	RetentionDryRun            = "RETENTION_DRY_RUN"
 
 	// default true
 	// false when TmpDir.DisableCaching is true in backupConfig/restoreSession
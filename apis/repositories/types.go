@@ -31,14 +31,34 @@ type Snapshot struct {
 }
 
 type SnapshotStatus struct {
-	Tree       string
-	Paths      []string
-	Hostname   string
-	Username   string
-	UID        int
-	Gid        int
-	Tags       []string
-	Repository string
+	Tree           string
+	Paths          []string
+	Hostname       string
+	Username       string
+	UID            int
+	Gid            int
+	Tags           []string
+	Repository     string
+	Time           *metav1.Time
+	Parent         string
+	TotalSize      string
+	BackupSession  string
+	ProgramVersion string
+	Summary        *SnapshotSummary
+}
+
+type SnapshotSummary struct {
+	BackupStart         *metav1.Time
+	BackupEnd           *metav1.Time
+	FilesNew            int64
+	FilesChanged        int64
+	FilesUnmodified     int64
+	DirsNew             int64
+	DirsChanged         int64
+	DirsUnmodified      int64
+	TotalFilesProcessed int64
+	DataAdded           string
+	TotalBytesProcessed string
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -48,3 +68,25 @@ type SnapshotList struct {
 	metav1.ListMeta
 	Items []Snapshot
 }
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type SnapshotFiles struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+	Path  string
+	Items []SnapshotFile
+}
+
+type SnapshotFileType string
+
+type SnapshotFile struct {
+	Name    string
+	Path    string
+	Type    SnapshotFileType
+	Size    uint64
+	Mode    string
+	UID     int
+	Gid     int
+	ModTime *metav1.Time
+}
@@ -443,8 +443,11 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kmodules.xyz/prober/api/v1.HTTPPostAction":                                        schema_kmodulesxyz_prober_api_v1_HTTPPostAction(ref),
 		"kmodules.xyz/prober/api/v1.Handler":                                               schema_kmodulesxyz_prober_api_v1_Handler(ref),
 		"stash.appscode.dev/apimachinery/apis/repositories/v1alpha1.Snapshot":              schema_apimachinery_apis_repositories_v1alpha1_Snapshot(ref),
+		"stash.appscode.dev/apimachinery/apis/repositories/v1alpha1.SnapshotFile":          schema_apimachinery_apis_repositories_v1alpha1_SnapshotFile(ref),
+		"stash.appscode.dev/apimachinery/apis/repositories/v1alpha1.SnapshotFiles":         schema_apimachinery_apis_repositories_v1alpha1_SnapshotFiles(ref),
 		"stash.appscode.dev/apimachinery/apis/repositories/v1alpha1.SnapshotList":          schema_apimachinery_apis_repositories_v1alpha1_SnapshotList(ref),
 		"stash.appscode.dev/apimachinery/apis/repositories/v1alpha1.SnapshotStatus":        schema_apimachinery_apis_repositories_v1alpha1_SnapshotStatus(ref),
+		"stash.appscode.dev/apimachinery/apis/repositories/v1alpha1.SnapshotSummary":       schema_apimachinery_apis_repositories_v1alpha1_SnapshotSummary(ref),
 	}
 }
 
@@ -23302,6 +23305,137 @@ func schema_apimachinery_apis_repositories_v1alpha1_Snapshot(ref common.Referenc
 	}
 }
 
+func schema_apimachinery_apis_repositories_v1alpha1_SnapshotFile(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the file",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the absolute path of the file inside the snapshot",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type indicates whether this is a file, directory or symlink",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"size": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Size is the size of the file in bytes",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"mode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mode is the permission of the file",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"uid": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int32",
+						},
+					},
+					"gid": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int32",
+						},
+					},
+					"modTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ModTime indicates when the file was last modified",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"name", "path", "type", "uid", "gid"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_apimachinery_apis_repositories_v1alpha1_SnapshotFiles(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SnapshotFiles lists the content of a directory of a Snapshot. It is served by the \"files\" subresource of the Snapshot.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the directory of the snapshot whose content has been listed",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Items lists the files and directories of the directory",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("stash.appscode.dev/apimachinery/apis/repositories/v1alpha1.SnapshotFile"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"path"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "stash.appscode.dev/apimachinery/apis/repositories/v1alpha1.SnapshotFile"},
+	}
+}
+
 func schema_apimachinery_apis_repositories_v1alpha1_SnapshotList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -23426,9 +23560,148 @@ func schema_apimachinery_apis_repositories_v1alpha1_SnapshotStatus(ref common.Re
 							Format:  "",
 						},
 					},
+					"time": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Time indicates when the snapshot was taken",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"parent": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Parent is the ID of the snapshot this snapshot was created from",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"totalSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TotalSize indicates the size of the data of this snapshot",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"backupSession": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BackupSession is the name of the BackupSession that produced this snapshot",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"programVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProgramVersion is the version of restic that created this snapshot",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"summary": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Summary shows the statistics of the backup that created this snapshot",
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/repositories/v1alpha1.SnapshotSummary"),
+						},
+					},
 				},
 				Required: []string{"tree", "paths", "hostname", "username", "uid", "gid", "repository"},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "stash.appscode.dev/apimachinery/apis/repositories/v1alpha1.SnapshotSummary"},
+	}
+}
+
+func schema_apimachinery_apis_repositories_v1alpha1_SnapshotSummary(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"backupStart": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BackupStart indicates when the backup started",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"backupEnd": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BackupEnd indicates when the backup ended",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"filesNew": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FilesNew shows the number of files that have been created since the parent snapshot",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"filesChanged": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FilesChanged shows the number of files that have been modified since the parent snapshot",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"filesUnmodified": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FilesUnmodified shows the number of files that have not been changed since the parent snapshot",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"dirsNew": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DirsNew shows the number of directories that have been created since the parent snapshot",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"dirsChanged": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DirsChanged shows the number of directories that have been modified since the parent snapshot",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"dirsUnmodified": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DirsUnmodified shows the number of directories that have not been changed since the parent snapshot",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"totalFilesProcessed": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TotalFilesProcessed shows the total number of files that have been processed by the backup",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"dataAdded": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DataAdded indicates the size of the data that has been added to the repository by the backup",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"totalBytesProcessed": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TotalBytesProcessed indicates the size of the data that has been processed by the backup",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"filesNew", "filesChanged", "filesUnmodified", "dirsNew", "dirsChanged", "dirsUnmodified", "totalFilesProcessed"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
@@ -47,6 +47,7 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Snapshot{},
 		&SnapshotList{},
+		&SnapshotFiles{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
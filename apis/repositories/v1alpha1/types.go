@@ -24,6 +24,10 @@ const (
 	ResourceKindSnapshot     = "Snapshot"
 	ResourcePluralSnapshot   = "snapshots"
 	ResourceSingularSnapshot = "snapshot"
+
+	ResourceKindSnapshotFiles = "SnapshotFiles"
+	// SubresourceSnapshotFiles is the name of the subresource of a Snapshot that lists its files
+	SubresourceSnapshotFiles = "files"
 )
 
 // +genclient
@@ -33,6 +37,7 @@ const (
 // +kubebuilder:printcolumn:name="Repository",type="string",JSONPath=".status.repository"
 // +kubebuilder:printcolumn:name="Hostname",type="string",JSONPath=".status.hostname"
 // +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".uid"
+// +kubebuilder:printcolumn:name="Size",type="string",JSONPath=".status.totalSize"
 type Snapshot struct {
 	metav1.TypeMeta   `json:",inline,omitempty"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -48,6 +53,53 @@ type SnapshotStatus struct {
 	Gid        int32    `json:"gid"`
 	Tags       []string `json:"tags,omitempty"`
 	Repository string   `json:"repository"`
+	// Time indicates when the snapshot was taken
+	// +optional
+	Time *metav1.Time `json:"time,omitempty"`
+	// Parent is the ID of the snapshot this snapshot was created from
+	// +optional
+	Parent string `json:"parent,omitempty"`
+	// TotalSize indicates the size of the data of this snapshot
+	// +optional
+	TotalSize string `json:"totalSize,omitempty"`
+	// BackupSession is the name of the BackupSession that produced this snapshot
+	// +optional
+	BackupSession string `json:"backupSession,omitempty"`
+	// ProgramVersion is the version of restic that created this snapshot
+	// +optional
+	ProgramVersion string `json:"programVersion,omitempty"`
+	// Summary shows the statistics of the backup that created this snapshot
+	// +optional
+	Summary *SnapshotSummary `json:"summary,omitempty"`
+}
+
+type SnapshotSummary struct {
+	// BackupStart indicates when the backup started
+	// +optional
+	BackupStart *metav1.Time `json:"backupStart,omitempty"`
+	// BackupEnd indicates when the backup ended
+	// +optional
+	BackupEnd *metav1.Time `json:"backupEnd,omitempty"`
+	// FilesNew shows the number of files that have been created since the parent snapshot
+	FilesNew int64 `json:"filesNew"`
+	// FilesChanged shows the number of files that have been modified since the parent snapshot
+	FilesChanged int64 `json:"filesChanged"`
+	// FilesUnmodified shows the number of files that have not been changed since the parent snapshot
+	FilesUnmodified int64 `json:"filesUnmodified"`
+	// DirsNew shows the number of directories that have been created since the parent snapshot
+	DirsNew int64 `json:"dirsNew"`
+	// DirsChanged shows the number of directories that have been modified since the parent snapshot
+	DirsChanged int64 `json:"dirsChanged"`
+	// DirsUnmodified shows the number of directories that have not been changed since the parent snapshot
+	DirsUnmodified int64 `json:"dirsUnmodified"`
+	// TotalFilesProcessed shows the total number of files that have been processed by the backup
+	TotalFilesProcessed int64 `json:"totalFilesProcessed"`
+	// DataAdded indicates the size of the data that has been added to the repository by the backup
+	// +optional
+	DataAdded string `json:"dataAdded,omitempty"`
+	// TotalBytesProcessed indicates the size of the data that has been processed by the backup
+	// +optional
+	TotalBytesProcessed string `json:"totalBytesProcessed,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -57,3 +109,45 @@ type SnapshotList struct {
 	metav1.ListMeta `json:"metadata,omitempty"`
 	Items           []Snapshot `json:"items"`
 }
+
+// SnapshotFiles lists the content of a directory of a Snapshot.
+// It is served by the "files" subresource of the Snapshot.
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type SnapshotFiles struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Path is the directory of the snapshot whose content has been listed
+	Path string `json:"path"`
+	// Items lists the files and directories of the directory
+	// +optional
+	Items []SnapshotFile `json:"items,omitempty"`
+}
+
+type SnapshotFileType string
+
+const (
+	SnapshotFileTypeFile    SnapshotFileType = "file"
+	SnapshotFileTypeDir     SnapshotFileType = "dir"
+	SnapshotFileTypeSymlink SnapshotFileType = "symlink"
+)
+
+type SnapshotFile struct {
+	// Name is the name of the file
+	Name string `json:"name"`
+	// Path is the absolute path of the file inside the snapshot
+	Path string `json:"path"`
+	// Type indicates whether this is a file, directory or symlink
+	Type SnapshotFileType `json:"type"`
+	// Size is the size of the file in bytes
+	// +optional
+	Size uint64 `json:"size,omitempty"`
+	// Mode is the permission of the file
+	// +optional
+	Mode string `json:"mode,omitempty"`
+	UID  int32  `json:"uid"`
+	Gid  int32  `json:"gid"`
+	// ModTime indicates when the file was last modified
+	// +optional
+	ModTime *metav1.Time `json:"modTime,omitempty"`
+}
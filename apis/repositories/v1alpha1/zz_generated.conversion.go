@@ -26,6 +26,7 @@ import (
 
 	repositories "stash.appscode.dev/apimachinery/apis/repositories"
 
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -47,6 +48,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*SnapshotFile)(nil), (*repositories.SnapshotFile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SnapshotFile_To_repositories_SnapshotFile(a.(*SnapshotFile), b.(*repositories.SnapshotFile), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*repositories.SnapshotFile)(nil), (*SnapshotFile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_repositories_SnapshotFile_To_v1alpha1_SnapshotFile(a.(*repositories.SnapshotFile), b.(*SnapshotFile), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SnapshotFiles)(nil), (*repositories.SnapshotFiles)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SnapshotFiles_To_repositories_SnapshotFiles(a.(*SnapshotFiles), b.(*repositories.SnapshotFiles), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*repositories.SnapshotFiles)(nil), (*SnapshotFiles)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_repositories_SnapshotFiles_To_v1alpha1_SnapshotFiles(a.(*repositories.SnapshotFiles), b.(*SnapshotFiles), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*SnapshotList)(nil), (*repositories.SnapshotList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_SnapshotList_To_repositories_SnapshotList(a.(*SnapshotList), b.(*repositories.SnapshotList), scope)
 	}); err != nil {
@@ -67,6 +88,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*SnapshotSummary)(nil), (*repositories.SnapshotSummary)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SnapshotSummary_To_repositories_SnapshotSummary(a.(*SnapshotSummary), b.(*repositories.SnapshotSummary), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*repositories.SnapshotSummary)(nil), (*SnapshotSummary)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_repositories_SnapshotSummary_To_v1alpha1_SnapshotSummary(a.(*repositories.SnapshotSummary), b.(*SnapshotSummary), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -96,6 +127,84 @@ func Convert_repositories_Snapshot_To_v1alpha1_Snapshot(in *repositories.Snapsho
 	return autoConvert_repositories_Snapshot_To_v1alpha1_Snapshot(in, out, s)
 }
 
+func autoConvert_v1alpha1_SnapshotFile_To_repositories_SnapshotFile(in *SnapshotFile, out *repositories.SnapshotFile, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Path = in.Path
+	out.Type = repositories.SnapshotFileType(in.Type)
+	out.Size = in.Size
+	out.Mode = in.Mode
+	out.UID = int(in.UID)
+	out.Gid = int(in.Gid)
+	out.ModTime = (*v1.Time)(unsafe.Pointer(in.ModTime))
+	return nil
+}
+
+// Convert_v1alpha1_SnapshotFile_To_repositories_SnapshotFile is an autogenerated conversion function.
+func Convert_v1alpha1_SnapshotFile_To_repositories_SnapshotFile(in *SnapshotFile, out *repositories.SnapshotFile, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SnapshotFile_To_repositories_SnapshotFile(in, out, s)
+}
+
+func autoConvert_repositories_SnapshotFile_To_v1alpha1_SnapshotFile(in *repositories.SnapshotFile, out *SnapshotFile, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Path = in.Path
+	out.Type = SnapshotFileType(in.Type)
+	out.Size = in.Size
+	out.Mode = in.Mode
+	out.UID = int32(in.UID)
+	out.Gid = int32(in.Gid)
+	out.ModTime = (*v1.Time)(unsafe.Pointer(in.ModTime))
+	return nil
+}
+
+// Convert_repositories_SnapshotFile_To_v1alpha1_SnapshotFile is an autogenerated conversion function.
+func Convert_repositories_SnapshotFile_To_v1alpha1_SnapshotFile(in *repositories.SnapshotFile, out *SnapshotFile, s conversion.Scope) error {
+	return autoConvert_repositories_SnapshotFile_To_v1alpha1_SnapshotFile(in, out, s)
+}
+
+func autoConvert_v1alpha1_SnapshotFiles_To_repositories_SnapshotFiles(in *SnapshotFiles, out *repositories.SnapshotFiles, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.Path = in.Path
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]repositories.SnapshotFile, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha1_SnapshotFile_To_repositories_SnapshotFile(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha1_SnapshotFiles_To_repositories_SnapshotFiles is an autogenerated conversion function.
+func Convert_v1alpha1_SnapshotFiles_To_repositories_SnapshotFiles(in *SnapshotFiles, out *repositories.SnapshotFiles, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SnapshotFiles_To_repositories_SnapshotFiles(in, out, s)
+}
+
+func autoConvert_repositories_SnapshotFiles_To_v1alpha1_SnapshotFiles(in *repositories.SnapshotFiles, out *SnapshotFiles, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.Path = in.Path
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SnapshotFile, len(*in))
+		for i := range *in {
+			if err := Convert_repositories_SnapshotFile_To_v1alpha1_SnapshotFile(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_repositories_SnapshotFiles_To_v1alpha1_SnapshotFiles is an autogenerated conversion function.
+func Convert_repositories_SnapshotFiles_To_v1alpha1_SnapshotFiles(in *repositories.SnapshotFiles, out *SnapshotFiles, s conversion.Scope) error {
+	return autoConvert_repositories_SnapshotFiles_To_v1alpha1_SnapshotFiles(in, out, s)
+}
+
 func autoConvert_v1alpha1_SnapshotList_To_repositories_SnapshotList(in *SnapshotList, out *repositories.SnapshotList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
@@ -147,6 +256,12 @@ func autoConvert_v1alpha1_SnapshotStatus_To_repositories_SnapshotStatus(in *Snap
 	out.Gid = int(in.Gid)
 	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
 	out.Repository = in.Repository
+	out.Time = (*v1.Time)(unsafe.Pointer(in.Time))
+	out.Parent = in.Parent
+	out.TotalSize = in.TotalSize
+	out.BackupSession = in.BackupSession
+	out.ProgramVersion = in.ProgramVersion
+	out.Summary = (*repositories.SnapshotSummary)(unsafe.Pointer(in.Summary))
 	return nil
 }
 
@@ -164,6 +279,12 @@ func autoConvert_repositories_SnapshotStatus_To_v1alpha1_SnapshotStatus(in *repo
 	out.Gid = int32(in.Gid)
 	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
 	out.Repository = in.Repository
+	out.Time = (*v1.Time)(unsafe.Pointer(in.Time))
+	out.Parent = in.Parent
+	out.TotalSize = in.TotalSize
+	out.BackupSession = in.BackupSession
+	out.ProgramVersion = in.ProgramVersion
+	out.Summary = (*SnapshotSummary)(unsafe.Pointer(in.Summary))
 	return nil
 }
 
@@ -171,3 +292,43 @@ func autoConvert_repositories_SnapshotStatus_To_v1alpha1_SnapshotStatus(in *repo
 func Convert_repositories_SnapshotStatus_To_v1alpha1_SnapshotStatus(in *repositories.SnapshotStatus, out *SnapshotStatus, s conversion.Scope) error {
 	return autoConvert_repositories_SnapshotStatus_To_v1alpha1_SnapshotStatus(in, out, s)
 }
+
+func autoConvert_v1alpha1_SnapshotSummary_To_repositories_SnapshotSummary(in *SnapshotSummary, out *repositories.SnapshotSummary, s conversion.Scope) error {
+	out.BackupStart = (*v1.Time)(unsafe.Pointer(in.BackupStart))
+	out.BackupEnd = (*v1.Time)(unsafe.Pointer(in.BackupEnd))
+	out.FilesNew = in.FilesNew
+	out.FilesChanged = in.FilesChanged
+	out.FilesUnmodified = in.FilesUnmodified
+	out.DirsNew = in.DirsNew
+	out.DirsChanged = in.DirsChanged
+	out.DirsUnmodified = in.DirsUnmodified
+	out.TotalFilesProcessed = in.TotalFilesProcessed
+	out.DataAdded = in.DataAdded
+	out.TotalBytesProcessed = in.TotalBytesProcessed
+	return nil
+}
+
+// Convert_v1alpha1_SnapshotSummary_To_repositories_SnapshotSummary is an autogenerated conversion function.
+func Convert_v1alpha1_SnapshotSummary_To_repositories_SnapshotSummary(in *SnapshotSummary, out *repositories.SnapshotSummary, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SnapshotSummary_To_repositories_SnapshotSummary(in, out, s)
+}
+
+func autoConvert_repositories_SnapshotSummary_To_v1alpha1_SnapshotSummary(in *repositories.SnapshotSummary, out *SnapshotSummary, s conversion.Scope) error {
+	out.BackupStart = (*v1.Time)(unsafe.Pointer(in.BackupStart))
+	out.BackupEnd = (*v1.Time)(unsafe.Pointer(in.BackupEnd))
+	out.FilesNew = in.FilesNew
+	out.FilesChanged = in.FilesChanged
+	out.FilesUnmodified = in.FilesUnmodified
+	out.DirsNew = in.DirsNew
+	out.DirsChanged = in.DirsChanged
+	out.DirsUnmodified = in.DirsUnmodified
+	out.TotalFilesProcessed = in.TotalFilesProcessed
+	out.DataAdded = in.DataAdded
+	out.TotalBytesProcessed = in.TotalBytesProcessed
+	return nil
+}
+
+// Convert_repositories_SnapshotSummary_To_v1alpha1_SnapshotSummary is an autogenerated conversion function.
+func Convert_repositories_SnapshotSummary_To_v1alpha1_SnapshotSummary(in *repositories.SnapshotSummary, out *SnapshotSummary, s conversion.Scope) error {
+	return autoConvert_repositories_SnapshotSummary_To_v1alpha1_SnapshotSummary(in, out, s)
+}
@@ -52,6 +52,59 @@ func (in *Snapshot) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotFile) DeepCopyInto(out *SnapshotFile) {
+	*out = *in
+	if in.ModTime != nil {
+		in, out := &in.ModTime, &out.ModTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotFile.
+func (in *SnapshotFile) DeepCopy() *SnapshotFile {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotFile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotFiles) DeepCopyInto(out *SnapshotFiles) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SnapshotFile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotFiles.
+func (in *SnapshotFiles) DeepCopy() *SnapshotFiles {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotFiles)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SnapshotFiles) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SnapshotList) DeepCopyInto(out *SnapshotList) {
 	*out = *in
@@ -98,6 +151,15 @@ func (in *SnapshotStatus) DeepCopyInto(out *SnapshotStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+	if in.Summary != nil {
+		in, out := &in.Summary, &out.Summary
+		*out = new(SnapshotSummary)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -110,3 +172,27 @@ func (in *SnapshotStatus) DeepCopy() *SnapshotStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotSummary) DeepCopyInto(out *SnapshotSummary) {
+	*out = *in
+	if in.BackupStart != nil {
+		in, out := &in.BackupStart, &out.BackupStart
+		*out = (*in).DeepCopy()
+	}
+	if in.BackupEnd != nil {
+		in, out := &in.BackupEnd, &out.BackupEnd
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotSummary.
+func (in *SnapshotSummary) DeepCopy() *SnapshotSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotSummary)
+	in.DeepCopyInto(out)
+	return out
+}
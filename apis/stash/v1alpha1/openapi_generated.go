@@ -443,11 +443,16 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kmodules.xyz/prober/api/v1.HTTPPostAction":                                        schema_kmodulesxyz_prober_api_v1_HTTPPostAction(ref),
 		"kmodules.xyz/prober/api/v1.Handler":                                               schema_kmodulesxyz_prober_api_v1_Handler(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1alpha1.AllowedNamespaces":            schema_apimachinery_apis_stash_v1alpha1_AllowedNamespaces(ref),
+		"stash.appscode.dev/apimachinery/apis/stash/v1alpha1.GrowthAlert":                  schema_apimachinery_apis_stash_v1alpha1_GrowthAlert(ref),
+		"stash.appscode.dev/apimachinery/apis/stash/v1alpha1.ImmutabilityPolicy":           schema_apimachinery_apis_stash_v1alpha1_ImmutabilityPolicy(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1alpha1.LocalTypedReference":          schema_apimachinery_apis_stash_v1alpha1_LocalTypedReference(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1alpha1.Repository":                   schema_apimachinery_apis_stash_v1alpha1_Repository(ref),
+		"stash.appscode.dev/apimachinery/apis/stash/v1alpha1.RepositoryHostStatus":         schema_apimachinery_apis_stash_v1alpha1_RepositoryHostStatus(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1alpha1.RepositoryList":               schema_apimachinery_apis_stash_v1alpha1_RepositoryList(ref),
+		"stash.appscode.dev/apimachinery/apis/stash/v1alpha1.RepositoryQuota":              schema_apimachinery_apis_stash_v1alpha1_RepositoryQuota(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1alpha1.RepositorySpec":               schema_apimachinery_apis_stash_v1alpha1_RepositorySpec(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1alpha1.RepositoryStatus":             schema_apimachinery_apis_stash_v1alpha1_RepositoryStatus(ref),
+		"stash.appscode.dev/apimachinery/apis/stash/v1alpha1.RepositoryUsage":              schema_apimachinery_apis_stash_v1alpha1_RepositoryUsage(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1alpha1.RetentionPolicy":              schema_apimachinery_apis_stash_v1alpha1_RetentionPolicy(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1alpha1.UsagePolicy":                  schema_apimachinery_apis_stash_v1alpha1_UsagePolicy(ref),
 	}
@@ -23295,6 +23300,63 @@ func schema_apimachinery_apis_stash_v1alpha1_AllowedNamespaces(ref common.Refere
 	}
 }
 
+func schema_apimachinery_apis_stash_v1alpha1_GrowthAlert(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "GrowthAlert specifies the thresholds of the repository growth in a single backup session",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"maxGrowth": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxGrowth specifies the maximum size the repository can grow in a single backup session",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"maxGrowthPercentage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxGrowthPercentage specifies the maximum percentage of its previous size the repository can grow in a single backup session",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_apimachinery_apis_stash_v1alpha1_ImmutabilityPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ImmutabilityPolicy specifies the object lock (WORM) settings of the backend of a Repository",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"mode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mode specifies the lock mode of the backend. Possible values are: * Governance: Users with special permission can remove the locked objects before the lock expires. * Compliance: No one can remove the locked objects before the lock expires.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"retentionPeriod": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetentionPeriod specifies the duration for which the backed up data remains locked after a backup.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+				Required: []string{"retentionPeriod"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
 func schema_apimachinery_apis_stash_v1alpha1_LocalTypedReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -23375,6 +23437,82 @@ func schema_apimachinery_apis_stash_v1alpha1_Repository(ref common.ReferenceCall
 	}
 }
 
+func schema_apimachinery_apis_stash_v1alpha1_RepositoryHostStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RepositoryHostStatus shows the status of a host of a backup target in the repository",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"target": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Target refers to the backup target this host belongs to",
+							Ref:         ref("kmodules.xyz/client-go/api/v1.TypedObjectReference"),
+						},
+					},
+					"hostname": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hostname indicates the name of the host",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastSnapshot": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastSnapshot indicates the ID of the latest snapshot of this host",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastSnapshotTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastSnapshotTime indicates the timestamp when the latest snapshot of this host was taken",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"snapshotCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SnapshotCount shows number of snapshots of this host stored in the repository",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"size": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Size shows the size of the data of this host stored in the repository",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastSuccessTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastSuccessTime indicates the timestamp when the backup of this host succeeded last time",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"lastFailureTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastFailureTime indicates the timestamp when the backup of this host failed last time",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"lastFailureReason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastFailureReason shows why the backup of this host failed last time",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"hostname"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "kmodules.xyz/client-go/api/v1.TypedObjectReference"},
+	}
+}
+
 func schema_apimachinery_apis_stash_v1alpha1_RepositoryList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -23422,6 +23560,41 @@ func schema_apimachinery_apis_stash_v1alpha1_RepositoryList(ref common.Reference
 	}
 }
 
+func schema_apimachinery_apis_stash_v1alpha1_RepositoryQuota(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RepositoryQuota specifies the limits of the storage usage of a Repository",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"maxSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxSize specifies the maximum size of the repository",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"maxSnapshots": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxSnapshots specifies the maximum number of snapshots the repository can hold",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"action": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Action specifies what to do with the new backup sessions when the quota has been exceeded. Possible values are: * Warn: Only set the QuotaExceeded condition of the Repository. * Skip: Skip the new backup sessions. * Fail: Fail the new backup sessions.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
 func schema_apimachinery_apis_stash_v1alpha1_RepositorySpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -23448,11 +23621,29 @@ func schema_apimachinery_apis_stash_v1alpha1_RepositorySpec(ref common.Reference
 							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1alpha1.UsagePolicy"),
 						},
 					},
+					"quota": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Quota specifies the limits of the storage usage of this Repository. The quota is evaluated after each backup session.",
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1alpha1.RepositoryQuota"),
+						},
+					},
+					"growthAlert": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GrowthAlert specifies the thresholds of the repository growth in a single backup session.",
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1alpha1.GrowthAlert"),
+						},
+					},
+					"immutability": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Immutability specifies the object lock settings of the backend. Stash will not remove the snapshots that are younger than the lock period.",
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1alpha1.ImmutabilityPolicy"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kmodules.xyz/objectstore-api/api/v1.Backend", "stash.appscode.dev/apimachinery/apis/stash/v1alpha1.UsagePolicy"},
+			"kmodules.xyz/objectstore-api/api/v1.Backend", "stash.appscode.dev/apimachinery/apis/stash/v1alpha1.GrowthAlert", "stash.appscode.dev/apimachinery/apis/stash/v1alpha1.ImmutabilityPolicy", "stash.appscode.dev/apimachinery/apis/stash/v1alpha1.RepositoryQuota", "stash.appscode.dev/apimachinery/apis/stash/v1alpha1.UsagePolicy"},
 	}
 }
 
@@ -23469,6 +23660,13 @@ func schema_apimachinery_apis_stash_v1alpha1_RepositoryStatus(ref common.Referen
 							Format:      "int64",
 						},
 					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase indicates the overall health of the Repository. It is calculated from the conditions of the Repository.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"firstBackupTime": {
 						SchemaProps: spec.SchemaProps{
 							Description: "FirstBackupTime indicates the timestamp when the first backup was taken",
@@ -23523,11 +23721,82 @@ func schema_apimachinery_apis_stash_v1alpha1_RepositoryStatus(ref common.Referen
 							},
 						},
 					},
+					"compressionRatio": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompressionRatio shows the ratio of the uncompressed data size to the size of the data stored in the repository",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastCheckTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastCheckTime indicates the timestamp when the repository integrity was checked last time",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"hosts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hosts shows the status of the individual hosts of the targets that are backed up into this repository",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("stash.appscode.dev/apimachinery/apis/stash/v1alpha1.RepositoryHostStatus"),
+									},
+								},
+							},
+						},
+					},
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions shows current state of the Repository",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("kmodules.xyz/client-go/api/v1.Condition"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "kmodules.xyz/client-go/api/v1.TypedObjectReference"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "kmodules.xyz/client-go/api/v1.Condition", "kmodules.xyz/client-go/api/v1.TypedObjectReference", "stash.appscode.dev/apimachinery/apis/stash/v1alpha1.RepositoryHostStatus"},
+	}
+}
+
+func schema_apimachinery_apis_stash_v1alpha1_RepositoryUsage(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RepositoryUsage shows the storage usage of a Repository after a backup session",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"Size": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Size is the size of the repository in bytes",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"SnapshotCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SnapshotCount is the number of snapshots stored in the repository",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"Size", "SnapshotCount"},
+			},
+		},
 	}
 }
 
@@ -23594,6 +23863,42 @@ func schema_apimachinery_apis_stash_v1alpha1_RetentionPolicy(ref common.Referenc
 							},
 						},
 					},
+					"keepWithin": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KeepWithin keeps all the snapshots taken within this duration of the latest snapshot",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"keepWithinHourly": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KeepWithinHourly keeps the latest snapshot of each hour within this duration of the latest snapshot",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"keepWithinDaily": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KeepWithinDaily keeps the latest snapshot of each day within this duration of the latest snapshot",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"keepWithinWeekly": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KeepWithinWeekly keeps the latest snapshot of each week within this duration of the latest snapshot",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"keepWithinMonthly": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KeepWithinMonthly keeps the latest snapshot of each month within this duration of the latest snapshot",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"keepWithinYearly": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KeepWithinYearly keeps the latest snapshot of each year within this duration of the latest snapshot",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
 					"prune": {
 						SchemaProps: spec.SchemaProps{
 							Default: false,
@@ -23611,6 +23916,8 @@ func schema_apimachinery_apis_stash_v1alpha1_RetentionPolicy(ref common.Referenc
 				Required: []string{"name", "prune"},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
@@ -23628,6 +23935,24 @@ func schema_apimachinery_apis_stash_v1alpha1_UsagePolicy(ref common.ReferenceCal
 							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1alpha1.AllowedNamespaces"),
 						},
 					},
+					"backup": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Backup specifies which namespaces are allowed to take backup into the resource. If not specified, AllowedNamespaces is used.",
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1alpha1.AllowedNamespaces"),
+						},
+					},
+					"restore": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Restore specifies which namespaces are allowed to restore from the resource. If not specified, AllowedNamespaces is used.",
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1alpha1.AllowedNamespaces"),
+						},
+					},
+					"deleteSnapshot": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DeleteSnapshot specifies which namespaces are allowed to delete snapshots from the resource. If not specified, AllowedNamespaces is used.",
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1alpha1.AllowedNamespaces"),
+						},
+					},
 				},
 			},
 		},
@@ -23,13 +23,40 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/klog/v2"
+	kmapi "kmodules.xyz/client-go/api/v1"
 	"kmodules.xyz/client-go/apiextensions"
+	cutil "kmodules.xyz/client-go/conditions"
 )
 
 func (Repository) CustomResourceDefinition() *apiextensions.CustomResourceDefinition {
 	return crds.MustCustomResourceDefinition(SchemeGroupVersion.WithResource(ResourcePluralRepository))
 }
 
+// Default sets the default values of a Repository
+func (r *Repository) Default() {
+	r.Spec.Default()
+}
+
+// Default restricts the usage of the Repository to its own namespace if no usage policy has been specified.
+// It also sets the default action of the quota and the default lock mode of the immutability policy.
+func (r *RepositorySpec) Default() {
+	if r.UsagePolicy == nil {
+		r.UsagePolicy = &UsagePolicy{}
+	}
+	for _, allowed := range []*AllowedNamespaces{&r.UsagePolicy.AllowedNamespaces, r.UsagePolicy.Backup, r.UsagePolicy.Restore, r.UsagePolicy.DeleteSnapshot} {
+		if allowed != nil && allowed.From == nil {
+			from := NamespacesFromSame
+			allowed.From = &from
+		}
+	}
+	if r.Quota != nil && r.Quota.Action == "" {
+		r.Quota.Action = QuotaExceededActionWarn
+	}
+	if r.Immutability != nil && r.Immutability.Mode == "" {
+		r.Immutability.Mode = ImmutabilityModeGovernance
+	}
+}
+
 func (r *Repository) LocalNetworkVolume() bool {
 	if r.Spec.Backend.Local != nil &&
 		r.Spec.Backend.Local.NFS != nil {
@@ -47,16 +74,27 @@ func (r *Repository) LocalNetworkVolumePath() string {
 	return ""
 }
 
+// UsageAllowed returns true if the Repository can be used from the given namespace for all the verbs.
+// It returns false if the policy of any verb does not allow the namespace. Use UsageAllowedFor to check a single verb.
 func (r *Repository) UsageAllowed(srcNamespace *core.Namespace) bool {
+	for _, verb := range []UsageVerb{UsageVerbBackup, UsageVerbRestore, UsageVerbDeleteSnapshot} {
+		if !r.UsageAllowedFor(srcNamespace, verb) {
+			return false
+		}
+	}
+	return true
+}
+
+// UsageAllowedFor returns true if the Repository can be used from the given namespace for the given verb.
+// The allowed namespaces of the verb are used if specified. Otherwise, the common allowed namespaces are used.
+func (r *Repository) UsageAllowedFor(srcNamespace *core.Namespace, verb UsageVerb) bool {
 	if r.Spec.UsagePolicy == nil {
 		return r.Namespace == srcNamespace.Name
 	}
-	return r.isNamespaceAllowed(srcNamespace)
+	return r.isNamespaceAllowed(r.Spec.UsagePolicy.AllowedNamespacesFor(verb), srcNamespace)
 }
 
-func (r *Repository) isNamespaceAllowed(srcNamespace *core.Namespace) bool {
-	allowedNamespaces := r.Spec.UsagePolicy.AllowedNamespaces
-
+func (r *Repository) isNamespaceAllowed(allowedNamespaces AllowedNamespaces, srcNamespace *core.Namespace) bool {
 	if allowedNamespaces.From == nil {
 		return false
 	}
@@ -80,3 +118,76 @@ func selectorMatches(ls *metav1.LabelSelector, srcLabels map[string]string) bool
 	}
 	return selector.Matches(labels.Set(srcLabels))
 }
+
+// GetHostStatus returns the status of the given host of a target. Target can be nil if the host does not belong to any target.
+func (s *RepositoryStatus) GetHostStatus(target *kmapi.TypedObjectReference, hostname string) *RepositoryHostStatus {
+	for i := range s.Hosts {
+		if s.Hosts[i].Hostname == hostname && sameTarget(s.Hosts[i].Target, target) {
+			return &s.Hosts[i]
+		}
+	}
+	return nil
+}
+
+// SetHostStatus inserts the status of a host or updates the existing one.
+// The last success and the last failure are kept unless they are set in the new status.
+func (s *RepositoryStatus) SetHostStatus(newStatus RepositoryHostStatus) {
+	cur := s.GetHostStatus(newStatus.Target, newStatus.Hostname)
+	if cur == nil {
+		s.Hosts = append(s.Hosts, newStatus)
+		return
+	}
+	if newStatus.LastSuccessTime == nil {
+		newStatus.LastSuccessTime = cur.LastSuccessTime
+	}
+	if newStatus.LastFailureTime == nil {
+		newStatus.LastFailureTime = cur.LastFailureTime
+		newStatus.LastFailureReason = cur.LastFailureReason
+	}
+	*cur = newStatus
+}
+
+// SetHostBackupFailed records the failure of the last backup of a host
+func (s *RepositoryStatus) SetHostBackupFailed(target *kmapi.TypedObjectReference, hostname, reason string, failedAt metav1.Time) {
+	cur := s.GetHostStatus(target, hostname)
+	if cur == nil {
+		s.Hosts = append(s.Hosts, RepositoryHostStatus{Target: target, Hostname: hostname})
+		cur = &s.Hosts[len(s.Hosts)-1]
+	}
+	cur.LastFailureTime = &failedAt
+	cur.LastFailureReason = reason
+}
+
+func sameTarget(t1, t2 *kmapi.TypedObjectReference) bool {
+	if t1 == nil || t2 == nil {
+		return t1 == nil && t2 == nil
+	}
+	return t1.APIGroup == t2.APIGroup &&
+		t1.Kind == t2.Kind &&
+		t1.Namespace == t2.Namespace &&
+		t1.Name == t2.Name
+}
+
+// SetCondition sets the given condition and recalculates the phase of the Repository
+func (s *RepositoryStatus) SetCondition(newCondition kmapi.Condition) {
+	s.Conditions = cutil.SetCondition(s.Conditions, newCondition)
+	s.Phase = calculateRepositoryPhase(s.Conditions)
+}
+
+func calculateRepositoryPhase(conditions []kmapi.Condition) RepositoryPhase {
+	if cutil.IsConditionFalse(conditions, RepositoryBackendReachable) ||
+		cutil.IsConditionFalse(conditions, RepositoryInitialized) ||
+		cutil.IsConditionFalse(conditions, RepositoryIntegrityVerified) {
+		return RepositoryNotReady
+	}
+	if !cutil.IsConditionTrue(conditions, RepositoryBackendReachable) ||
+		!cutil.IsConditionTrue(conditions, RepositoryInitialized) {
+		return RepositoryPending
+	}
+	if cutil.IsConditionTrue(conditions, RepositoryLocked) ||
+		cutil.IsConditionTrue(conditions, RepositoryQuotaExceeded) ||
+		cutil.IsConditionFalse(conditions, RepositoryReplicated) {
+		return RepositoryDegraded
+	}
+	return RepositoryReady
+}
@@ -21,6 +21,7 @@ import (
 
 	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kmapi "kmodules.xyz/client-go/api/v1"
 )
 
 func TestRepository_UsageAllowed(t *testing.T) {
@@ -169,6 +170,76 @@ func TestRepository_UsageAllowed(t *testing.T) {
 	}
 }
 
+func TestRepository_UsageAllowedFor(t *testing.T) {
+	repoNamespace := "dr"
+	recoveryNamespace := "recovery"
+
+	sameNamespace := NamespacesFromSame
+	namespacesFromSelector := NamespacesFromSelector
+	recoverySelector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{"kubernetes.io/metadata.name": recoveryNamespace},
+	}
+
+	policy := &UsagePolicy{
+		AllowedNamespaces: AllowedNamespaces{From: &sameNamespace},
+		Restore:           &AllowedNamespaces{From: &namespacesFromSelector, Selector: recoverySelector},
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		verb      UsageVerb
+		want      bool
+	}{
+		{
+			name:      "Allow restore from the selected namespace",
+			namespace: recoveryNamespace,
+			verb:      UsageVerbRestore,
+			want:      true,
+		},
+		{
+			name:      "Don't allow backup from the restore only namespace",
+			namespace: recoveryNamespace,
+			verb:      UsageVerbBackup,
+			want:      false,
+		},
+		{
+			name:      "Don't allow snapshot deletion from the restore only namespace",
+			namespace: recoveryNamespace,
+			verb:      UsageVerbDeleteSnapshot,
+			want:      false,
+		},
+		{
+			name:      "Fallback to the allowed namespaces for backup",
+			namespace: repoNamespace,
+			verb:      UsageVerbBackup,
+			want:      true,
+		},
+		{
+			name:      "Restore policy overrides the allowed namespaces",
+			namespace: repoNamespace,
+			verb:      UsageVerbRestore,
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRepository(repoNamespace, policy)
+			ns := newTestNamespace(tt.namespace)
+			if got := r.UsageAllowedFor(ns, tt.verb); got != tt.want {
+				t.Errorf("UsageAllowedFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// UsageAllowed must not allow a namespace that is denied by the policy of any verb
+	for _, namespace := range []string{repoNamespace, recoveryNamespace} {
+		if newTestRepository(repoNamespace, policy).UsageAllowed(newTestNamespace(namespace)) {
+			t.Errorf("UsageAllowed() = true for namespace %s, want false", namespace)
+		}
+	}
+}
+
 func newTestRepository(namespace string, policy *UsagePolicy) *Repository {
 	return &Repository{
 		ObjectMeta: metav1.ObjectMeta{
@@ -181,6 +252,113 @@ func newTestRepository(namespace string, policy *UsagePolicy) *Repository {
 	}
 }
 
+func TestRepositoryStatus_SetHostStatus(t *testing.T) {
+	target := &kmapi.TypedObjectReference{APIGroup: "apps", Kind: "StatefulSet", Namespace: "demo", Name: "db"}
+	succeededAt := metav1.Unix(100, 0)
+	failedAt := metav1.Unix(200, 0)
+
+	var status RepositoryStatus
+	status.SetHostStatus(RepositoryHostStatus{Target: target, Hostname: "host-0", SnapshotCount: 1, LastSuccessTime: &succeededAt})
+	status.SetHostStatus(RepositoryHostStatus{Hostname: "host-0", SnapshotCount: 5})
+	status.SetHostBackupFailed(target, "host-0", "backup timed out", failedAt)
+	status.SetHostStatus(RepositoryHostStatus{Target: target, Hostname: "host-0", SnapshotCount: 2})
+
+	if len(status.Hosts) != 2 {
+		t.Errorf("expected 2 hosts, found %d", len(status.Hosts))
+		return
+	}
+	got := status.GetHostStatus(target.DeepCopy(), "host-0")
+	if got == nil {
+		t.Error("host status of the target not found")
+		return
+	}
+	if got.SnapshotCount != 2 {
+		t.Errorf("SnapshotCount = %d, want 2", got.SnapshotCount)
+	}
+	if got.LastSuccessTime == nil || !got.LastSuccessTime.Equal(&succeededAt) {
+		t.Errorf("LastSuccessTime = %v, want %v", got.LastSuccessTime, succeededAt)
+	}
+	if got.LastFailureTime == nil || !got.LastFailureTime.Equal(&failedAt) || got.LastFailureReason != "backup timed out" {
+		t.Errorf("last failure = %v %q, want %v %q", got.LastFailureTime, got.LastFailureReason, failedAt, "backup timed out")
+	}
+	if other := status.GetHostStatus(nil, "host-0"); other == nil || other.SnapshotCount != 5 {
+		t.Errorf("host status without target = %v, want snapshot count 5", other)
+	}
+}
+
+func TestRepositoryStatus_SetCondition(t *testing.T) {
+	cond := func(condType kmapi.ConditionType, status metav1.ConditionStatus) kmapi.Condition {
+		return kmapi.Condition{Type: condType, Status: status}
+	}
+
+	tests := []struct {
+		name       string
+		conditions []kmapi.Condition
+		want       RepositoryPhase
+	}{
+		{
+			name:       "backend not checked yet",
+			conditions: []kmapi.Condition{cond(RepositoryLocked, metav1.ConditionFalse)},
+			want:       RepositoryPending,
+		},
+		{
+			name: "reachable and initialized",
+			conditions: []kmapi.Condition{
+				cond(RepositoryBackendReachable, metav1.ConditionTrue),
+				cond(RepositoryInitialized, metav1.ConditionTrue),
+				cond(RepositoryIntegrityVerified, metav1.ConditionTrue),
+			},
+			want: RepositoryReady,
+		},
+		{
+			name: "backend unreachable",
+			conditions: []kmapi.Condition{
+				cond(RepositoryInitialized, metav1.ConditionTrue),
+				cond(RepositoryBackendReachable, metav1.ConditionFalse),
+			},
+			want: RepositoryNotReady,
+		},
+		{
+			name: "integrity check failed",
+			conditions: []kmapi.Condition{
+				cond(RepositoryBackendReachable, metav1.ConditionTrue),
+				cond(RepositoryInitialized, metav1.ConditionTrue),
+				cond(RepositoryIntegrityVerified, metav1.ConditionFalse),
+			},
+			want: RepositoryNotReady,
+		},
+		{
+			name: "quota exceeded",
+			conditions: []kmapi.Condition{
+				cond(RepositoryBackendReachable, metav1.ConditionTrue),
+				cond(RepositoryInitialized, metav1.ConditionTrue),
+				cond(RepositoryQuotaExceeded, metav1.ConditionTrue),
+			},
+			want: RepositoryDegraded,
+		},
+		{
+			name: "replication failed",
+			conditions: []kmapi.Condition{
+				cond(RepositoryBackendReachable, metav1.ConditionTrue),
+				cond(RepositoryInitialized, metav1.ConditionTrue),
+				cond(RepositoryReplicated, metav1.ConditionFalse),
+			},
+			want: RepositoryDegraded,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var status RepositoryStatus
+			for _, c := range test.conditions {
+				status.SetCondition(c)
+			}
+			if status.Phase != test.want {
+				t.Errorf("Phase = %v, want %v", status.Phase, test.want)
+			}
+		})
+	}
+}
+
 func newTestNamespace(name string) *core.Namespace {
 	return &core.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,44 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+)
+
+// IsImmutable returns true if the backend of the Repository locks the backed up data.
+func (r *Repository) IsImmutable() bool {
+	return r.Spec.Immutability != nil && r.Spec.Immutability.RetentionPeriod.Duration > 0
+}
+
+// LockedUntil returns the time until which a snapshot taken at snapshotTime remains locked.
+func (p ImmutabilityPolicy) LockedUntil(snapshotTime time.Time) time.Time {
+	return snapshotTime.Add(p.RetentionPeriod.Duration)
+}
+
+// IsLocked returns true if a snapshot taken at snapshotTime is still locked at the given time.
+func (p ImmutabilityPolicy) IsLocked(snapshotTime, now time.Time) bool {
+	return now.Before(p.LockedUntil(snapshotTime))
+}
+
+// GetMode returns the lock mode of the backend. It defaults to Governance.
+func (p ImmutabilityPolicy) GetMode() ImmutabilityMode {
+	if p.Mode == "" {
+		return ImmutabilityModeGovernance
+	}
+	return p.Mode
+}
@@ -0,0 +1,129 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	store "kmodules.xyz/objectstore-api/api/v1"
+)
+
+func TestRetentionPolicy_ValidateImmutability(t *testing.T) {
+	policy := &ImmutabilityPolicy{Mode: ImmutabilityModeCompliance, RetentionPeriod: metav1.Duration{Duration: 24 * time.Hour}}
+
+	tests := []struct {
+		name      string
+		retention RetentionPolicy
+		policy    *ImmutabilityPolicy
+		wantErrs  int
+	}{
+		{
+			name:      "no immutability policy",
+			retention: RetentionPolicy{KeepLast: 1},
+		},
+		{
+			name:      "keepWithin not specified",
+			retention: RetentionPolicy{KeepLast: 1},
+			policy:    policy,
+			wantErrs:  1,
+		},
+		{
+			name:      "keepWithin shorter than the lock",
+			retention: RetentionPolicy{KeepLast: 1, KeepWithin: &metav1.Duration{Duration: 12 * time.Hour}},
+			policy:    policy,
+			wantErrs:  1,
+		},
+		{
+			name:      "keepWithin covers the lock",
+			retention: RetentionPolicy{KeepLast: 1, KeepWithin: &metav1.Duration{Duration: 48 * time.Hour}},
+			policy:    policy,
+		},
+		{
+			name:      "dry run",
+			retention: RetentionPolicy{KeepLast: 1, DryRun: true},
+			policy:    policy,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := test.retention.ValidateImmutability(test.policy, field.NewPath("retentionPolicy"))
+			if len(errs) != test.wantErrs {
+				t.Errorf("expected %d errors, found %v", test.wantErrs, errs)
+			}
+		})
+	}
+}
+
+func TestRepository_ValidateUpdateImmutability(t *testing.T) {
+	newRepo := func(policy *ImmutabilityPolicy) *Repository {
+		return &Repository{Spec: RepositorySpec{
+			Backend:      store.Backend{S3: &store.S3Spec{Bucket: "stash-backup", Prefix: "demo"}},
+			Immutability: policy,
+		}}
+	}
+	compliance := &ImmutabilityPolicy{Mode: ImmutabilityModeCompliance, RetentionPeriod: metav1.Duration{Duration: 48 * time.Hour}}
+
+	tests := []struct {
+		name     string
+		old      *ImmutabilityPolicy
+		new      *ImmutabilityPolicy
+		wantErrs int
+	}{
+		{
+			name: "extend the lock",
+			old:  compliance,
+			new:  &ImmutabilityPolicy{Mode: ImmutabilityModeCompliance, RetentionPeriod: metav1.Duration{Duration: 72 * time.Hour}},
+		},
+		{
+			name:     "shorten the lock",
+			old:      compliance,
+			new:      &ImmutabilityPolicy{Mode: ImmutabilityModeCompliance, RetentionPeriod: metav1.Duration{Duration: 24 * time.Hour}},
+			wantErrs: 1,
+		},
+		{
+			name:     "switch to Governance",
+			old:      compliance,
+			new:      &ImmutabilityPolicy{Mode: ImmutabilityModeGovernance, RetentionPeriod: metav1.Duration{Duration: 48 * time.Hour}},
+			wantErrs: 1,
+		},
+		{
+			name:     "remove the lock",
+			old:      compliance,
+			wantErrs: 1,
+		},
+		{
+			name: "remove Governance lock",
+			old:  &ImmutabilityPolicy{Mode: ImmutabilityModeGovernance, RetentionPeriod: metav1.Duration{Duration: 48 * time.Hour}},
+		},
+		{
+			name:     "invalid retention period",
+			new:      &ImmutabilityPolicy{Mode: ImmutabilityModeGovernance, RetentionPeriod: metav1.Duration{Duration: 30 * time.Minute}},
+			wantErrs: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := newRepo(test.new).ValidateUpdate(newRepo(test.old))
+			if len(errs) != test.wantErrs {
+				t.Errorf("expected %d errors, found %v", test.wantErrs, errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kmapi "kmodules.xyz/client-go/api/v1"
+	cutil "kmodules.xyz/client-go/conditions"
+)
+
+// RepositoryUsage shows the storage usage of a Repository after a backup session
+type RepositoryUsage struct {
+	// Size is the size of the repository in bytes
+	Size int64
+	// SnapshotCount is the number of snapshots stored in the repository
+	SnapshotCount int64
+}
+
+// EvaluateQuota evaluates the quota and the growth alert of the Repository against its usage after a backup session.
+// The previousSize is the size of the repository in bytes before the session. It sets the QuotaExceeded and the
+// GrowthThresholdExceeded conditions of the Repository and returns true if the quota has been exceeded.
+func (r *Repository) EvaluateQuota(usage RepositoryUsage, previousSize int64) bool {
+	exceeded := false
+	if r.Spec.Quota != nil {
+		var reasons, messages []string
+		if q := r.Spec.Quota.MaxSize; q != nil && usage.Size > q.Value() {
+			reasons = append(reasons, RepositorySizeQuotaExceeded)
+			messages = append(messages, fmt.Sprintf("repository size %s exceeded the quota %s", formatQuantity(usage.Size), q.String()))
+		}
+		if q := r.Spec.Quota.MaxSnapshots; q != nil && usage.SnapshotCount > *q {
+			reasons = append(reasons, SnapshotCountQuotaExceeded)
+			messages = append(messages, fmt.Sprintf("snapshot count %d exceeded the quota %d", usage.SnapshotCount, *q))
+		}
+		cond := kmapi.Condition{
+			Type:               RepositoryQuotaExceeded,
+			Status:             metav1.ConditionFalse,
+			Reason:             RepositoryWithinQuota,
+			Message:            "Repository usage is within the quota.",
+			LastTransitionTime: metav1.Now(),
+		}
+		if len(reasons) > 0 {
+			exceeded = true
+			cond.Status = metav1.ConditionTrue
+			cond.Reason = reasons[0]
+			cond.Message = fmt.Sprintf("Repository has exceeded its quota: %s.", strings.Join(messages, ", "))
+		}
+		r.Status.SetCondition(cond)
+	}
+
+	if r.Spec.GrowthAlert != nil {
+		growth := usage.Size - previousSize
+		var messages []string
+		if t := r.Spec.GrowthAlert.MaxGrowth; t != nil && growth > t.Value() {
+			messages = append(messages, fmt.Sprintf("grew %s which is more than %s", formatQuantity(growth), t.String()))
+		}
+		if t := r.Spec.GrowthAlert.MaxGrowthPercentage; t != nil && previousSize > 0 && growth*100 > int64(*t)*previousSize {
+			messages = append(messages, fmt.Sprintf("grew %d%% which is more than %d%%", growth*100/previousSize, *t))
+		}
+		cond := kmapi.Condition{
+			Type:               RepositoryGrowthThresholdExceeded,
+			Status:             metav1.ConditionFalse,
+			Reason:             RepositoryGrowthWithinThreshold,
+			Message:            "Repository growth is within the thresholds.",
+			LastTransitionTime: metav1.Now(),
+		}
+		if len(messages) > 0 {
+			cond.Status = metav1.ConditionTrue
+			cond.Reason = RepositoryGrowthExceeded
+			cond.Message = fmt.Sprintf("Repository %s in the last backup session.", strings.Join(messages, " and "))
+		}
+		r.Status.SetCondition(cond)
+	}
+	return exceeded
+}
+
+// QuotaExceeded returns true if the QuotaExceeded condition of the Repository is true
+func (r Repository) QuotaExceeded() bool {
+	return cutil.IsConditionTrue(r.Status.Conditions, RepositoryQuotaExceeded)
+}
+
+// GetQuotaExceededAction returns the action to take for the new backup sessions when the quota has been exceeded
+func (r Repository) GetQuotaExceededAction() QuotaExceededAction {
+	if r.Spec.Quota == nil || r.Spec.Quota.Action == "" {
+		return QuotaExceededActionWarn
+	}
+	return r.Spec.Quota.Action
+}
+
+func formatQuantity(bytes int64) string {
+	return resource.NewQuantity(bytes, resource.BinarySI).String()
+}
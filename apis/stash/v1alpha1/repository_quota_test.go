@@ -0,0 +1,128 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"gomodules.xyz/pointer"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cutil "kmodules.xyz/client-go/conditions"
+)
+
+func TestRepository_EvaluateQuota(t *testing.T) {
+	maxSize := resource.MustParse("1Gi")
+	maxGrowth := resource.MustParse("100Mi")
+
+	tests := []struct {
+		name          string
+		quota         *RepositoryQuota
+		growthAlert   *GrowthAlert
+		usage         RepositoryUsage
+		previousSize  int64
+		wantExceeded  bool
+		wantQuota     metav1.ConditionStatus
+		wantQuotaWhy  string
+		wantGrowth    metav1.ConditionStatus
+		wantCondCount int
+	}{
+		{
+			name:          "no quota or growth alert",
+			usage:         RepositoryUsage{Size: 2 << 30},
+			wantCondCount: 0,
+		},
+		{
+			name:          "within quota",
+			quota:         &RepositoryQuota{MaxSize: &maxSize, MaxSnapshots: pointer.Int64P(10)},
+			usage:         RepositoryUsage{Size: 512 << 20, SnapshotCount: 10},
+			wantQuota:     metav1.ConditionFalse,
+			wantQuotaWhy:  RepositoryWithinQuota,
+			wantCondCount: 1,
+		},
+		{
+			name:          "size quota exceeded",
+			quota:         &RepositoryQuota{MaxSize: &maxSize},
+			usage:         RepositoryUsage{Size: 2 << 30},
+			wantExceeded:  true,
+			wantQuota:     metav1.ConditionTrue,
+			wantQuotaWhy:  RepositorySizeQuotaExceeded,
+			wantCondCount: 1,
+		},
+		{
+			name:          "snapshot quota exceeded",
+			quota:         &RepositoryQuota{MaxSize: &maxSize, MaxSnapshots: pointer.Int64P(5)},
+			usage:         RepositoryUsage{Size: 1 << 20, SnapshotCount: 6},
+			wantExceeded:  true,
+			wantQuota:     metav1.ConditionTrue,
+			wantQuotaWhy:  SnapshotCountQuotaExceeded,
+			wantCondCount: 1,
+		},
+		{
+			name:          "growth exceeded",
+			growthAlert:   &GrowthAlert{MaxGrowth: &maxGrowth},
+			usage:         RepositoryUsage{Size: 300 << 20},
+			previousSize:  100 << 20,
+			wantGrowth:    metav1.ConditionTrue,
+			wantCondCount: 1,
+		},
+		{
+			name:          "growth percentage exceeded",
+			growthAlert:   &GrowthAlert{MaxGrowth: &maxGrowth, MaxGrowthPercentage: pointer.Int32P(10)},
+			usage:         RepositoryUsage{Size: 120 << 20},
+			previousSize:  100 << 20,
+			wantGrowth:    metav1.ConditionTrue,
+			wantCondCount: 1,
+		},
+		{
+			name:          "growth within threshold",
+			growthAlert:   &GrowthAlert{MaxGrowthPercentage: pointer.Int32P(50)},
+			usage:         RepositoryUsage{Size: 120 << 20},
+			previousSize:  100 << 20,
+			wantGrowth:    metav1.ConditionFalse,
+			wantCondCount: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repo := &Repository{Spec: RepositorySpec{Quota: test.quota, GrowthAlert: test.growthAlert}}
+			if got := repo.EvaluateQuota(test.usage, test.previousSize); got != test.wantExceeded {
+				t.Errorf("EvaluateQuota() = %v, want %v", got, test.wantExceeded)
+				return
+			}
+			if len(repo.Status.Conditions) != test.wantCondCount {
+				t.Errorf("expected %d conditions, found %d", test.wantCondCount, len(repo.Status.Conditions))
+				return
+			}
+			if test.wantQuota != "" {
+				_, cond := cutil.GetCondition(repo.Status.Conditions, RepositoryQuotaExceeded)
+				if cond == nil || cond.Status != test.wantQuota || cond.Reason != test.wantQuotaWhy {
+					t.Errorf("unexpected QuotaExceeded condition: %+v", cond)
+				}
+			}
+			if test.wantGrowth != "" {
+				_, cond := cutil.GetCondition(repo.Status.Conditions, RepositoryGrowthThresholdExceeded)
+				if cond == nil || cond.Status != test.wantGrowth {
+					t.Errorf("unexpected GrowthThresholdExceeded condition: %+v", cond)
+				}
+			}
+			if repo.QuotaExceeded() != test.wantExceeded {
+				t.Errorf("QuotaExceeded() = %v, want %v", repo.QuotaExceeded(), test.wantExceeded)
+			}
+		})
+	}
+}
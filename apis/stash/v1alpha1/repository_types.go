@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kmapi "kmodules.xyz/client-go/api/v1"
 	store "kmodules.xyz/objectstore-api/api/v1"
@@ -35,6 +36,7 @@ const (
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=repositories,singular=repository,shortName=repo,categories={stash,appscode}
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
 // +kubebuilder:printcolumn:name="Integrity",type="boolean",JSONPath=".status.integrity"
 // +kubebuilder:printcolumn:name="Size",type="string",JSONPath=".status.totalSize"
 // +kubebuilder:printcolumn:name="Snapshot-Count",type="integer",JSONPath=".status.snapshotCount"
@@ -59,13 +61,90 @@ type RepositorySpec struct {
 	// This field is optional. If you don't provide the usagePolicy, then it can be used only from the current namespace.
 	// +optional
 	UsagePolicy *UsagePolicy `json:"usagePolicy,omitempty"`
+
+	// Quota specifies the limits of the storage usage of this Repository.
+	// The quota is evaluated after each backup session.
+	// +optional
+	Quota *RepositoryQuota `json:"quota,omitempty"`
+
+	// GrowthAlert specifies the thresholds of the repository growth in a single backup session.
+	// +optional
+	GrowthAlert *GrowthAlert `json:"growthAlert,omitempty"`
+
+	// Immutability specifies the object lock settings of the backend.
+	// Stash will not remove the snapshots that are younger than the lock period.
+	// +optional
+	Immutability *ImmutabilityPolicy `json:"immutability,omitempty"`
+}
+
+// RepositoryQuota specifies the limits of the storage usage of a Repository
+type RepositoryQuota struct {
+	// MaxSize specifies the maximum size of the repository
+	// +optional
+	MaxSize *resource.Quantity `json:"maxSize,omitempty"`
+	// MaxSnapshots specifies the maximum number of snapshots the repository can hold
+	// +optional
+	MaxSnapshots *int64 `json:"maxSnapshots,omitempty"`
+	// Action specifies what to do with the new backup sessions when the quota has been exceeded.
+	// Possible values are:
+	// * Warn: Only set the QuotaExceeded condition of the Repository.
+	// * Skip: Skip the new backup sessions.
+	// * Fail: Fail the new backup sessions.
+	//
+	// +optional
+	// +kubebuilder:default=Warn
+	Action QuotaExceededAction `json:"action,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Warn;Skip;Fail
+type QuotaExceededAction string
+
+const (
+	QuotaExceededActionWarn QuotaExceededAction = "Warn"
+	QuotaExceededActionSkip QuotaExceededAction = "Skip"
+	QuotaExceededActionFail QuotaExceededAction = "Fail"
+)
+
+// GrowthAlert specifies the thresholds of the repository growth in a single backup session
+type GrowthAlert struct {
+	// MaxGrowth specifies the maximum size the repository can grow in a single backup session
+	// +optional
+	MaxGrowth *resource.Quantity `json:"maxGrowth,omitempty"`
+	// MaxGrowthPercentage specifies the maximum percentage of its previous size the repository can grow in a single backup session
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxGrowthPercentage *int32 `json:"maxGrowthPercentage,omitempty"`
+}
+
+// ImmutabilityPolicy specifies the object lock (WORM) settings of the backend of a Repository
+type ImmutabilityPolicy struct {
+	// Mode specifies the lock mode of the backend.
+	// Possible values are:
+	// * Governance: Users with special permission can remove the locked objects before the lock expires.
+	// * Compliance: No one can remove the locked objects before the lock expires.
+	//
+	// +kubebuilder:default=Governance
+	Mode ImmutabilityMode `json:"mode,omitempty"`
+	// RetentionPeriod specifies the duration for which the backed up data remains locked after a backup.
+	RetentionPeriod metav1.Duration `json:"retentionPeriod"`
 }
 
+// +kubebuilder:validation:Enum=Governance;Compliance
+type ImmutabilityMode string
+
+const (
+	ImmutabilityModeGovernance ImmutabilityMode = "Governance"
+	ImmutabilityModeCompliance ImmutabilityMode = "Compliance"
+)
+
 type RepositoryStatus struct {
 	// ObservedGeneration is the most recent generation observed for this Repository. It corresponds to the
 	// Repository's generation, which is updated on mutation by the API Server.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Phase indicates the overall health of the Repository. It is calculated from the conditions of the Repository.
+	// +optional
+	Phase RepositoryPhase `json:"phase,omitempty"`
 	// FirstBackupTime indicates the timestamp when the first backup was taken
 	FirstBackupTime *metav1.Time `json:"firstBackupTime,omitempty"`
 	// LastBackupTime indicates the timestamp when the latest backup was taken
@@ -81,6 +160,62 @@ type RepositoryStatus struct {
 	// References holds a list of resource references that using this Repository
 	// +optional
 	References []kmapi.TypedObjectReference `json:"references,omitempty"`
+	// CompressionRatio shows the ratio of the uncompressed data size to the size of the data stored in the repository
+	// +optional
+	CompressionRatio string `json:"compressionRatio,omitempty"`
+	// LastCheckTime indicates the timestamp when the repository integrity was checked last time
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+	// Hosts shows the status of the individual hosts of the targets that are backed up into this repository
+	// +optional
+	Hosts []RepositoryHostStatus `json:"hosts,omitempty"`
+	// Conditions shows current state of the Repository
+	// +optional
+	Conditions []kmapi.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Pending;Ready;Degraded;NotReady
+type RepositoryPhase string
+
+const (
+	// RepositoryPending indicates that the state of the Repository has not been checked yet
+	RepositoryPending RepositoryPhase = "Pending"
+	// RepositoryReady indicates that the Repository is reachable, initialized and has no known problem
+	RepositoryReady RepositoryPhase = "Ready"
+	// RepositoryDegraded indicates that the Repository is usable but it is locked, has exceeded its quota or failed to replicate
+	RepositoryDegraded RepositoryPhase = "Degraded"
+	// RepositoryNotReady indicates that the Repository is unreachable, uninitialized or has failed the integrity check
+	RepositoryNotReady RepositoryPhase = "NotReady"
+)
+
+// RepositoryHostStatus shows the status of a host of a backup target in the repository
+type RepositoryHostStatus struct {
+	// Target refers to the backup target this host belongs to
+	// +optional
+	Target *kmapi.TypedObjectReference `json:"target,omitempty"`
+	// Hostname indicates the name of the host
+	Hostname string `json:"hostname"`
+	// LastSnapshot indicates the ID of the latest snapshot of this host
+	// +optional
+	LastSnapshot string `json:"lastSnapshot,omitempty"`
+	// LastSnapshotTime indicates the timestamp when the latest snapshot of this host was taken
+	// +optional
+	LastSnapshotTime *metav1.Time `json:"lastSnapshotTime,omitempty"`
+	// SnapshotCount shows number of snapshots of this host stored in the repository
+	// +optional
+	SnapshotCount int64 `json:"snapshotCount,omitempty"`
+	// Size shows the size of the data of this host stored in the repository
+	// +optional
+	Size string `json:"size,omitempty"`
+	// LastSuccessTime indicates the timestamp when the backup of this host succeeded last time
+	// +optional
+	LastSuccessTime *metav1.Time `json:"lastSuccessTime,omitempty"`
+	// LastFailureTime indicates the timestamp when the backup of this host failed last time
+	// +optional
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+	// LastFailureReason shows why the backup of this host failed last time
+	// +optional
+	LastFailureReason string `json:"lastFailureReason,omitempty"`
 }
 
 // UsagePolicy specifies a policy that restrict the usage of a resource across namespaces.
@@ -88,6 +223,47 @@ type UsagePolicy struct {
 	// AllowedNamespaces specifies which namespaces are allowed to use the resource
 	// +optional
 	AllowedNamespaces AllowedNamespaces `json:"allowedNamespaces,omitempty"`
+	// Backup specifies which namespaces are allowed to take backup into the resource.
+	// If not specified, AllowedNamespaces is used.
+	// +optional
+	Backup *AllowedNamespaces `json:"backup,omitempty"`
+	// Restore specifies which namespaces are allowed to restore from the resource.
+	// If not specified, AllowedNamespaces is used.
+	// +optional
+	Restore *AllowedNamespaces `json:"restore,omitempty"`
+	// DeleteSnapshot specifies which namespaces are allowed to delete snapshots from the resource.
+	// If not specified, AllowedNamespaces is used.
+	// +optional
+	DeleteSnapshot *AllowedNamespaces `json:"deleteSnapshot,omitempty"`
+}
+
+// UsageVerb specifies how a resource is being used
+type UsageVerb string
+
+const (
+	// UsageVerbBackup indicates that the resource is used to take backup (write)
+	UsageVerbBackup UsageVerb = "backup"
+	// UsageVerbRestore indicates that the resource is used to restore (read)
+	UsageVerbRestore UsageVerb = "restore"
+	// UsageVerbDeleteSnapshot indicates that the resource is used to delete snapshots
+	UsageVerbDeleteSnapshot UsageVerb = "deleteSnapshot"
+)
+
+// AllowedNamespacesFor returns the namespaces that are allowed to use the resource for the given verb
+func (p UsagePolicy) AllowedNamespacesFor(verb UsageVerb) AllowedNamespaces {
+	var allowed *AllowedNamespaces
+	switch verb {
+	case UsageVerbBackup:
+		allowed = p.Backup
+	case UsageVerbRestore:
+		allowed = p.Restore
+	case UsageVerbDeleteSnapshot:
+		allowed = p.DeleteSnapshot
+	}
+	if allowed == nil {
+		return p.AllowedNamespaces
+	}
+	return *allowed
 }
 
 // AllowedNamespaces indicate which namespaces the resource should be selected from.
@@ -126,17 +302,23 @@ const (
 	NamespacesFromSame FromNamespaces = "Same"
 )
 
-// +kubebuilder:validation:Enum=--keep-last;--keep-hourly;--keep-daily;--keep-weekly;--keep-monthly;--keep-yearly;--keep-tag
+// +kubebuilder:validation:Enum=--keep-last;--keep-hourly;--keep-daily;--keep-weekly;--keep-monthly;--keep-yearly;--keep-tag;--keep-within;--keep-within-hourly;--keep-within-daily;--keep-within-weekly;--keep-within-monthly;--keep-within-yearly
 type RetentionStrategy string
 
 const (
-	KeepLast    RetentionStrategy = "--keep-last"
-	KeepHourly  RetentionStrategy = "--keep-hourly"
-	KeepDaily   RetentionStrategy = "--keep-daily"
-	KeepWeekly  RetentionStrategy = "--keep-weekly"
-	KeepMonthly RetentionStrategy = "--keep-monthly"
-	KeepYearly  RetentionStrategy = "--keep-yearly"
-	KeepTag     RetentionStrategy = "--keep-tag"
+	KeepLast          RetentionStrategy = "--keep-last"
+	KeepHourly        RetentionStrategy = "--keep-hourly"
+	KeepDaily         RetentionStrategy = "--keep-daily"
+	KeepWeekly        RetentionStrategy = "--keep-weekly"
+	KeepMonthly       RetentionStrategy = "--keep-monthly"
+	KeepYearly        RetentionStrategy = "--keep-yearly"
+	KeepTag           RetentionStrategy = "--keep-tag"
+	KeepWithin        RetentionStrategy = "--keep-within"
+	KeepWithinHourly  RetentionStrategy = "--keep-within-hourly"
+	KeepWithinDaily   RetentionStrategy = "--keep-within-daily"
+	KeepWithinWeekly  RetentionStrategy = "--keep-within-weekly"
+	KeepWithinMonthly RetentionStrategy = "--keep-within-monthly"
+	KeepWithinYearly  RetentionStrategy = "--keep-within-yearly"
 )
 
 type RetentionPolicy struct {
@@ -148,8 +330,26 @@ type RetentionPolicy struct {
 	KeepMonthly int64    `json:"keepMonthly,omitempty"`
 	KeepYearly  int64    `json:"keepYearly,omitempty"`
 	KeepTags    []string `json:"keepTags,omitempty"`
-	Prune       bool     `json:"prune"`
-	DryRun      bool     `json:"dryRun,omitempty"`
+	// KeepWithin keeps all the snapshots taken within this duration of the latest snapshot
+	// +optional
+	KeepWithin *metav1.Duration `json:"keepWithin,omitempty"`
+	// KeepWithinHourly keeps the latest snapshot of each hour within this duration of the latest snapshot
+	// +optional
+	KeepWithinHourly *metav1.Duration `json:"keepWithinHourly,omitempty"`
+	// KeepWithinDaily keeps the latest snapshot of each day within this duration of the latest snapshot
+	// +optional
+	KeepWithinDaily *metav1.Duration `json:"keepWithinDaily,omitempty"`
+	// KeepWithinWeekly keeps the latest snapshot of each week within this duration of the latest snapshot
+	// +optional
+	KeepWithinWeekly *metav1.Duration `json:"keepWithinWeekly,omitempty"`
+	// KeepWithinMonthly keeps the latest snapshot of each month within this duration of the latest snapshot
+	// +optional
+	KeepWithinMonthly *metav1.Duration `json:"keepWithinMonthly,omitempty"`
+	// KeepWithinYearly keeps the latest snapshot of each year within this duration of the latest snapshot
+	// +optional
+	KeepWithinYearly *metav1.Duration `json:"keepWithinYearly,omitempty"`
+	Prune            bool             `json:"prune"`
+	DryRun           bool             `json:"dryRun,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -159,3 +359,60 @@ type RepositoryList struct {
 	metav1.ListMeta `json:"metadata,omitempty"`
 	Items           []Repository `json:"items,omitempty"`
 }
+
+// ========================== Condition Types ===================
+const (
+	// RepositoryBackendReachable indicates whether the backend of the repository is reachable or not
+	RepositoryBackendReachable = "BackendReachable"
+	// RepositoryInitialized indicates whether the restic repository has been initialized in the backend or not
+	RepositoryInitialized = "Initialized"
+	// RepositoryIntegrityVerified indicates whether the repository has passed the last integrity check or not
+	RepositoryIntegrityVerified = "IntegrityVerified"
+	// RepositoryLocked indicates whether the repository is currently locked or not
+	RepositoryLocked = "Locked"
+	// RepositoryReplicated indicates whether the repository has been replicated successfully or not
+	RepositoryReplicated = "Replicated"
+	// RepositoryQuotaExceeded indicates whether the repository has exceeded its quota
+	RepositoryQuotaExceeded = "QuotaExceeded"
+	// RepositoryGrowthThresholdExceeded indicates whether the repository has grown more than the growth alert thresholds in the last backup session
+	RepositoryGrowthThresholdExceeded = "GrowthThresholdExceeded"
+)
+
+// ======================== Condition Reasons ===================
+const (
+	// BackendReachable indicates that the condition transitioned to this state because the backend responded successfully
+	BackendReachable = "BackendReachable"
+	// BackendUnreachable indicates that the condition transitioned to this state because the backend could not be reached
+	BackendUnreachable = "BackendUnreachable"
+
+	// RepositoryInitializationSucceeded indicates that the condition transitioned to this state because the restic repository was found or initialized in the backend
+	RepositoryInitializationSucceeded = "RepositoryInitializationSucceeded"
+	// RepositoryInitializationFailed indicates that the condition transitioned to this state because the restic repository could not be initialized in the backend
+	RepositoryInitializationFailed = "RepositoryInitializationFailed"
+
+	// IntegrityCheckPassed indicates that the condition transitioned to this state because the repository passed the integrity check
+	IntegrityCheckPassed = "IntegrityCheckPassed"
+	// IntegrityCheckFailed indicates that the condition transitioned to this state because the repository failed the integrity check
+	IntegrityCheckFailed = "IntegrityCheckFailed"
+
+	// RepositoryLockFound indicates that the condition transitioned to this state because one or more locks were found in the repository
+	RepositoryLockFound = "RepositoryLockFound"
+	// RepositoryLockNotFound indicates that the condition transitioned to this state because no lock was found in the repository
+	RepositoryLockNotFound = "RepositoryLockNotFound"
+
+	// ReplicationSucceeded indicates that the condition transitioned to this state because the repository was replicated successfully
+	ReplicationSucceeded = "ReplicationSucceeded"
+	// ReplicationFailed indicates that the condition transitioned to this state because the repository could not be replicated
+	ReplicationFailed = "ReplicationFailed"
+
+	// RepositorySizeQuotaExceeded indicates that the condition transitioned to this state because the repository size exceeded the quota
+	RepositorySizeQuotaExceeded = "RepositorySizeQuotaExceeded"
+	// SnapshotCountQuotaExceeded indicates that the condition transitioned to this state because the number of snapshots exceeded the quota
+	SnapshotCountQuotaExceeded = "SnapshotCountQuotaExceeded"
+	// RepositoryWithinQuota indicates that the condition transitioned to this state because the repository usage is within the quota
+	RepositoryWithinQuota = "RepositoryWithinQuota"
+	// RepositoryGrowthExceeded indicates that the condition transitioned to this state because the repository grew more than the thresholds
+	RepositoryGrowthExceeded = "RepositoryGrowthExceeded"
+	// RepositoryGrowthWithinThreshold indicates that the condition transitioned to this state because the repository growth is within the thresholds
+	RepositoryGrowthWithinThreshold = "RepositoryGrowthWithinThreshold"
+)
@@ -18,7 +18,12 @@ package v1alpha1
 
 import (
 	"fmt"
+	"path"
 	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 func (r Repository) IsValid() error {
@@ -41,3 +46,228 @@ func (r Repository) IsValid() error {
 	}
 	return nil
 }
+
+func (r RetentionPolicy) IsValid() error {
+	return r.Validate(field.NewPath("retentionPolicy")).ToAggregate()
+}
+
+// Validate checks the sanity of the retention rules. fldPath is the path of the RetentionPolicy in the parent object.
+func (r RetentionPolicy) Validate(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	counts := []struct {
+		name  string
+		count int64
+	}{
+		{"keepLast", r.KeepLast},
+		{"keepHourly", r.KeepHourly},
+		{"keepDaily", r.KeepDaily},
+		{"keepWeekly", r.KeepWeekly},
+		{"keepMonthly", r.KeepMonthly},
+		{"keepYearly", r.KeepYearly},
+	}
+	for _, c := range counts {
+		if c.count < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(c.name), c.count, "must not be negative"))
+		}
+	}
+
+	durations := []struct {
+		name     string
+		duration *metav1.Duration
+	}{
+		{"keepWithin", r.KeepWithin},
+		{"keepWithinHourly", r.KeepWithinHourly},
+		{"keepWithinDaily", r.KeepWithinDaily},
+		{"keepWithinWeekly", r.KeepWithinWeekly},
+		{"keepWithinMonthly", r.KeepWithinMonthly},
+		{"keepWithinYearly", r.KeepWithinYearly},
+	}
+	for _, d := range durations {
+		if d.duration == nil {
+			continue
+		}
+		if d.duration.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(d.name), d.duration.Duration.String(), "must be a positive duration"))
+		} else if d.duration.Duration%time.Hour != 0 {
+			// restic does not support any unit smaller than an hour
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(d.name), d.duration.Duration.String(), "must be a multiple of an hour"))
+		}
+	}
+
+	for i, tag := range r.KeepTags {
+		if strings.TrimSpace(tag) == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("keepTags").Index(i), tag, "must not be empty"))
+		}
+	}
+	return allErrs
+}
+
+// ValidateImmutability checks whether the retention rules keep the snapshots that are locked by the immutability policy
+// of the Repository. The "keepWithin" rule must cover the retention period of the lock as restic evaluates the other rules
+// regardless of the age of the snapshots.
+func (r RetentionPolicy) ValidateImmutability(policy *ImmutabilityPolicy, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if policy == nil || r.DryRun {
+		return allErrs
+	}
+	period := policy.RetentionPeriod.Duration
+	if r.KeepWithin == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("keepWithin"),
+			fmt.Sprintf("must be specified for an immutable repository and must be at least the retention period %s of the lock", period)))
+	} else if r.KeepWithin.Duration < period {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("keepWithin"), r.KeepWithin.Duration.String(),
+			fmt.Sprintf("must be at least the retention period %s of the lock as the locked snapshots can not be removed", period)))
+	}
+	return allErrs
+}
+
+// ValidateCreate validates a Repository on creation
+func (r Repository) ValidateCreate() field.ErrorList {
+	return r.Spec.Validate(field.NewPath("spec"))
+}
+
+// ValidateUpdate validates a Repository on update. The storage location of the Repository can not be changed
+// as the existing snapshots would become inaccessible. However, the storage Secret can be updated.
+func (r Repository) ValidateUpdate(old *Repository) field.ErrorList {
+	allErrs := r.ValidateCreate()
+
+	fldPath := field.NewPath("spec", "backend")
+	oldProvider, _ := old.Spec.Backend.Provider()
+	newProvider, _ := r.Spec.Backend.Provider()
+	if oldProvider != newProvider {
+		allErrs = append(allErrs, field.Invalid(fldPath, newProvider, "storage provider of a Repository is immutable"))
+		return allErrs
+	}
+	oldContainer, _ := old.Spec.Backend.Container()
+	newContainer, _ := r.Spec.Backend.Container()
+	oldPrefix, _ := old.Spec.Backend.Prefix()
+	newPrefix, _ := r.Spec.Backend.Prefix()
+	if oldContainer != newContainer || oldPrefix != newPrefix {
+		allErrs = append(allErrs, field.Invalid(fldPath, path.Join(newContainer, newPrefix), "storage location of a Repository is immutable"))
+	}
+
+	// the lock of the Compliance mode can not be relaxed
+	if old.Spec.Immutability != nil && old.Spec.Immutability.GetMode() == ImmutabilityModeCompliance {
+		immutabilityPath := field.NewPath("spec", "immutability")
+		switch {
+		case r.Spec.Immutability == nil:
+			allErrs = append(allErrs, field.Forbidden(immutabilityPath, "immutability policy can not be removed in Compliance mode"))
+		case r.Spec.Immutability.GetMode() != ImmutabilityModeCompliance:
+			allErrs = append(allErrs, field.Forbidden(immutabilityPath.Child("mode"), "lock mode can not be changed from Compliance"))
+		case r.Spec.Immutability.RetentionPeriod.Duration < old.Spec.Immutability.RetentionPeriod.Duration:
+			allErrs = append(allErrs, field.Forbidden(immutabilityPath.Child("retentionPeriod"), "retention period can not be shortened in Compliance mode"))
+		}
+	}
+	return allErrs
+}
+
+// Validate checks the backend, the usage policy, the quota, the growth alert and the immutability policy of a RepositorySpec.
+func (r RepositorySpec) Validate(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	backendPath := fldPath.Child("backend")
+	if _, err := r.Backend.Provider(); err != nil {
+		allErrs = append(allErrs, field.Required(backendPath, "a storage provider must be specified"))
+	}
+	if r.WipeOut {
+		if r.Backend.Local != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("wipeOut"), "wipe out operation is not supported for local backend"))
+		} else if r.Backend.B2 != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("wipeOut"), "wipe out operation is not supported for B2 backend"))
+		}
+	}
+	if r.Backend.Local != nil && r.Backend.Local.MountPath != "" {
+		parts := strings.Split(r.Backend.Local.MountPath, "/")
+		if len(parts) >= 2 && parts[1] == "stash" {
+			allErrs = append(allErrs, field.Invalid(backendPath.Child("local", "mountPath"), r.Backend.Local.MountPath,
+				"stash binary resides in the root directory. Hence, `/stash` or `/stash/*` can not be used as mountPath"))
+		}
+	}
+
+	if r.UsagePolicy != nil {
+		policyPath := fldPath.Child("usagePolicy")
+		allErrs = append(allErrs, validateAllowedNamespaces(r.UsagePolicy.AllowedNamespaces, policyPath.Child("allowedNamespaces"))...)
+		if r.UsagePolicy.Backup != nil {
+			allErrs = append(allErrs, validateAllowedNamespaces(*r.UsagePolicy.Backup, policyPath.Child("backup"))...)
+		}
+		if r.UsagePolicy.Restore != nil {
+			allErrs = append(allErrs, validateAllowedNamespaces(*r.UsagePolicy.Restore, policyPath.Child("restore"))...)
+		}
+		if r.UsagePolicy.DeleteSnapshot != nil {
+			allErrs = append(allErrs, validateAllowedNamespaces(*r.UsagePolicy.DeleteSnapshot, policyPath.Child("deleteSnapshot"))...)
+		}
+	}
+
+	if r.Quota != nil {
+		quotaPath := fldPath.Child("quota")
+		if r.Quota.MaxSize == nil && r.Quota.MaxSnapshots == nil {
+			allErrs = append(allErrs, field.Required(quotaPath, "at least one of maxSize or maxSnapshots must be specified"))
+		}
+		if r.Quota.MaxSize != nil && r.Quota.MaxSize.Sign() <= 0 {
+			allErrs = append(allErrs, field.Invalid(quotaPath.Child("maxSize"), r.Quota.MaxSize.String(), "must be greater than zero"))
+		}
+		if r.Quota.MaxSnapshots != nil && *r.Quota.MaxSnapshots <= 0 {
+			allErrs = append(allErrs, field.Invalid(quotaPath.Child("maxSnapshots"), *r.Quota.MaxSnapshots, "must be greater than zero"))
+		}
+		switch r.Quota.Action {
+		case "", QuotaExceededActionWarn, QuotaExceededActionSkip, QuotaExceededActionFail:
+		default:
+			allErrs = append(allErrs, field.NotSupported(quotaPath.Child("action"), r.Quota.Action,
+				[]QuotaExceededAction{QuotaExceededActionWarn, QuotaExceededActionSkip, QuotaExceededActionFail}))
+		}
+	}
+	if r.GrowthAlert != nil {
+		alertPath := fldPath.Child("growthAlert")
+		if r.GrowthAlert.MaxGrowth == nil && r.GrowthAlert.MaxGrowthPercentage == nil {
+			allErrs = append(allErrs, field.Required(alertPath, "at least one of maxGrowth or maxGrowthPercentage must be specified"))
+		}
+		if r.GrowthAlert.MaxGrowth != nil && r.GrowthAlert.MaxGrowth.Sign() <= 0 {
+			allErrs = append(allErrs, field.Invalid(alertPath.Child("maxGrowth"), r.GrowthAlert.MaxGrowth.String(), "must be greater than zero"))
+		}
+		if r.GrowthAlert.MaxGrowthPercentage != nil && *r.GrowthAlert.MaxGrowthPercentage < 1 {
+			allErrs = append(allErrs, field.Invalid(alertPath.Child("maxGrowthPercentage"), *r.GrowthAlert.MaxGrowthPercentage, "must be at least 1"))
+		}
+	}
+	if r.Immutability != nil {
+		immutabilityPath := fldPath.Child("immutability")
+		switch r.Immutability.Mode {
+		case "", ImmutabilityModeGovernance, ImmutabilityModeCompliance:
+		default:
+			allErrs = append(allErrs, field.NotSupported(immutabilityPath.Child("mode"), r.Immutability.Mode,
+				[]ImmutabilityMode{ImmutabilityModeGovernance, ImmutabilityModeCompliance}))
+		}
+		if period := r.Immutability.RetentionPeriod.Duration; period <= 0 {
+			allErrs = append(allErrs, field.Invalid(immutabilityPath.Child("retentionPeriod"), period.String(), "must be a positive duration"))
+		} else if period%time.Hour != 0 {
+			// restic does not support any unit smaller than an hour for the retention rules
+			allErrs = append(allErrs, field.Invalid(immutabilityPath.Child("retentionPeriod"), period.String(), "must be a multiple of an hour"))
+		}
+		if r.WipeOut {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("wipeOut"), "wipe out operation is not supported for an immutable repository"))
+		}
+	}
+	return allErrs
+}
+
+func validateAllowedNamespaces(allowed AllowedNamespaces, nsPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if allowed.From != nil {
+		switch *allowed.From {
+		case NamespacesFromAll, NamespacesFromSame:
+		case NamespacesFromSelector:
+			if allowed.Selector == nil {
+				allErrs = append(allErrs, field.Required(nsPath.Child("selector"), "selector must be specified when from is set to Selector"))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(nsPath.Child("from"), *allowed.From,
+				[]FromNamespaces{NamespacesFromAll, NamespacesFromSelector, NamespacesFromSame}))
+		}
+	}
+	if allowed.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(allowed.Selector); err != nil {
+			allErrs = append(allErrs, field.Invalid(nsPath.Child("selector"), allowed.Selector, err.Error()))
+		}
+	}
+	return allErrs
+}
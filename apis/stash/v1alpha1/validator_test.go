@@ -0,0 +1,101 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRetentionPolicy_IsValid(t *testing.T) {
+	duration := func(d time.Duration) *metav1.Duration {
+		return &metav1.Duration{Duration: d}
+	}
+
+	tests := []struct {
+		name    string
+		policy  RetentionPolicy
+		wantErr string
+	}{
+		{
+			name:   "no duration rule",
+			policy: RetentionPolicy{KeepLast: 5},
+		},
+		{
+			name: "valid duration rules",
+			policy: RetentionPolicy{
+				KeepWithin:        duration(36 * time.Hour),
+				KeepWithinHourly:  duration(24 * time.Hour),
+				KeepWithinDaily:   duration(7 * 24 * time.Hour),
+				KeepWithinWeekly:  duration(30 * 24 * time.Hour),
+				KeepWithinMonthly: duration(365 * 24 * time.Hour),
+				KeepWithinYearly:  duration(5 * 365 * 24 * time.Hour),
+			},
+		},
+		{
+			name:    "zero keepWithin",
+			policy:  RetentionPolicy{KeepWithin: duration(0)},
+			wantErr: "retentionPolicy.keepWithin",
+		},
+		{
+			name:    "negative keepWithinDaily",
+			policy:  RetentionPolicy{KeepWithinDaily: duration(-time.Hour)},
+			wantErr: "retentionPolicy.keepWithinDaily",
+		},
+		{
+			name:    "keepWithinHourly is not a multiple of an hour",
+			policy:  RetentionPolicy{KeepWithinHourly: duration(90 * time.Minute)},
+			wantErr: "retentionPolicy.keepWithinHourly",
+		},
+		{
+			name:    "keepWithinYearly is smaller than an hour",
+			policy:  RetentionPolicy{KeepWithinYearly: duration(30 * time.Minute)},
+			wantErr: "retentionPolicy.keepWithinYearly",
+		},
+		{
+			name:    "negative keepLast",
+			policy:  RetentionPolicy{KeepLast: -1},
+			wantErr: "retentionPolicy.keepLast",
+		},
+		{
+			name:    "empty keepTags",
+			policy:  RetentionPolicy{KeepTags: []string{"daily", " "}},
+			wantErr: "retentionPolicy.keepTags[1]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.IsValid()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("IsValid() returned unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Errorf("IsValid() returned no error, want error for %s", tt.wantErr)
+				return
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("IsValid() error = %v, want error for %s", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -53,6 +53,49 @@ func (in *AllowedNamespaces) DeepCopy() *AllowedNamespaces {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthAlert) DeepCopyInto(out *GrowthAlert) {
+	*out = *in
+	if in.MaxGrowth != nil {
+		in, out := &in.MaxGrowth, &out.MaxGrowth
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxGrowthPercentage != nil {
+		in, out := &in.MaxGrowthPercentage, &out.MaxGrowthPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthAlert.
+func (in *GrowthAlert) DeepCopy() *GrowthAlert {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthAlert)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImmutabilityPolicy) DeepCopyInto(out *ImmutabilityPolicy) {
+	*out = *in
+	out.RetentionPeriod = in.RetentionPeriod
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImmutabilityPolicy.
+func (in *ImmutabilityPolicy) DeepCopy() *ImmutabilityPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ImmutabilityPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalTypedReference) DeepCopyInto(out *LocalTypedReference) {
 	*out = *in
@@ -97,6 +140,39 @@ func (in *Repository) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryHostStatus) DeepCopyInto(out *RepositoryHostStatus) {
+	*out = *in
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(apiv1.TypedObjectReference)
+		**out = **in
+	}
+	if in.LastSnapshotTime != nil {
+		in, out := &in.LastSnapshotTime, &out.LastSnapshotTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessTime != nil {
+		in, out := &in.LastSuccessTime, &out.LastSuccessTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFailureTime != nil {
+		in, out := &in.LastFailureTime, &out.LastFailureTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryHostStatus.
+func (in *RepositoryHostStatus) DeepCopy() *RepositoryHostStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryHostStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RepositoryList) DeepCopyInto(out *RepositoryList) {
 	*out = *in
@@ -130,6 +206,32 @@ func (in *RepositoryList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryQuota) DeepCopyInto(out *RepositoryQuota) {
+	*out = *in
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxSnapshots != nil {
+		in, out := &in.MaxSnapshots, &out.MaxSnapshots
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryQuota.
+func (in *RepositoryQuota) DeepCopy() *RepositoryQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RepositorySpec) DeepCopyInto(out *RepositorySpec) {
 	*out = *in
@@ -139,6 +241,21 @@ func (in *RepositorySpec) DeepCopyInto(out *RepositorySpec) {
 		*out = new(UsagePolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Quota != nil {
+		in, out := &in.Quota, &out.Quota
+		*out = new(RepositoryQuota)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GrowthAlert != nil {
+		in, out := &in.GrowthAlert, &out.GrowthAlert
+		*out = new(GrowthAlert)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Immutability != nil {
+		in, out := &in.Immutability, &out.Immutability
+		*out = new(ImmutabilityPolicy)
+		**out = **in
+	}
 	return
 }
 
@@ -173,6 +290,24 @@ func (in *RepositoryStatus) DeepCopyInto(out *RepositoryStatus) {
 		*out = make([]apiv1.TypedObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]RepositoryHostStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]apiv1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -186,6 +321,22 @@ func (in *RepositoryStatus) DeepCopy() *RepositoryStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryUsage) DeepCopyInto(out *RepositoryUsage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryUsage.
+func (in *RepositoryUsage) DeepCopy() *RepositoryUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RetentionPolicy) DeepCopyInto(out *RetentionPolicy) {
 	*out = *in
@@ -194,6 +345,36 @@ func (in *RetentionPolicy) DeepCopyInto(out *RetentionPolicy) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.KeepWithin != nil {
+		in, out := &in.KeepWithin, &out.KeepWithin
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.KeepWithinHourly != nil {
+		in, out := &in.KeepWithinHourly, &out.KeepWithinHourly
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.KeepWithinDaily != nil {
+		in, out := &in.KeepWithinDaily, &out.KeepWithinDaily
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.KeepWithinWeekly != nil {
+		in, out := &in.KeepWithinWeekly, &out.KeepWithinWeekly
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.KeepWithinMonthly != nil {
+		in, out := &in.KeepWithinMonthly, &out.KeepWithinMonthly
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.KeepWithinYearly != nil {
+		in, out := &in.KeepWithinYearly, &out.KeepWithinYearly
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -211,6 +392,21 @@ func (in *RetentionPolicy) DeepCopy() *RetentionPolicy {
 func (in *UsagePolicy) DeepCopyInto(out *UsagePolicy) {
 	*out = *in
 	in.AllowedNamespaces.DeepCopyInto(&out.AllowedNamespaces)
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(AllowedNamespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Restore != nil {
+		in, out := &in.Restore, &out.Restore
+		*out = new(AllowedNamespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeleteSnapshot != nil {
+		in, out := &in.DeleteSnapshot, &out.DeleteSnapshot
+		*out = new(AllowedNamespaces)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
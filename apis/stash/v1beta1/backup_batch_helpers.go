@@ -22,6 +22,7 @@ import (
 
 	"stash.appscode.dev/apimachinery/crds"
 
+	"gomodules.xyz/pointer"
 	"kmodules.xyz/client-go/apiextensions"
 	meta_util "kmodules.xyz/client-go/meta"
 )
@@ -36,6 +37,29 @@ func (b BackupBatch) GetSpecHash() string {
 	return strconv.FormatUint(hash.Sum64(), 10)
 }
 
+// Default sets the default values of a BackupBatch
+func (b *BackupBatch) Default() {
+	if b.Spec.Driver == "" {
+		b.Spec.Driver = ResticSnapshotter
+	}
+	if b.Spec.ExecutionOrder == "" {
+		b.Spec.ExecutionOrder = Parallel
+	}
+	if b.Spec.BackupHistoryLimit == nil {
+		b.Spec.BackupHistoryLimit = pointer.Int32P(DefaultBackupHistoryLimit)
+	}
+	if b.Spec.ConcurrencyPolicy == "" {
+		b.Spec.ConcurrencyPolicy = ForbidConcurrent
+	}
+	defaultBackupHooks(b.Spec.Hooks)
+	for i := range b.Spec.Members {
+		defaultBackupHooks(b.Spec.Members[i].Hooks)
+		defaultRetryConfig(b.Spec.Members[i].RetryConfig)
+	}
+	defaultRetryConfig(b.Spec.RetryConfig)
+	defaultFailurePolicy(b.Spec.FailurePolicy)
+}
+
 // OffshootLabels return labels consist of the labels provided by user to BackupBatch crd and
 // stash specific generic labels. It overwrites the the user provided labels if it matched with stash specific generic labels.
 func (b BackupBatch) OffshootLabels() map[string]string {
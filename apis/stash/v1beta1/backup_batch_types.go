@@ -55,6 +55,10 @@ type BackupBatchSpec struct {
 	// Schedule specifies the schedule for invoking backup sessions
 	// +optional
 	Schedule string `json:"schedule,omitempty"`
+	// TimeZone is the IANA name of the time zone (i.e. "Asia/Dhaka") in which the schedule is evaluated.
+	// If it is not specified, the schedule is evaluated in the time zone of the cluster.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
 	// RuntimeSettings allow to specify Resources, NodeSelector, Affinity, Toleration, ReadinessProbe etc,
 	// and used to create service account for CronJob.
 	// +optional
@@ -84,6 +88,8 @@ type BackupBatchSpec struct {
 	Hooks *BackupHooks `json:"hooks,omitempty"`
 	// ExecutionOrder indicate whether to backup the members in the sequential order as they appear in the members list.
 	// The default value is "Parallel" which means the members will be backed up in parallel.
+	// If any member depends on the other members, the members are started one by one as their dependencies
+	// succeed, regardless of this field.
 	// +kubebuilder:default=Parallel
 	// +optional
 	ExecutionOrder ExecutionOrder `json:"executionOrder,omitempty"`
@@ -96,6 +102,22 @@ type BackupBatchSpec struct {
 	// By default, Stash does not retry any failed backup.
 	// +optional
 	RetryConfig *RetryConfig `json:"retryConfig,omitempty"`
+
+	// FailurePolicy specifies how to handle the failure of a member.
+	// By default, all the members are backed up and the session fails if any member fails.
+	// +optional
+	FailurePolicy *FailurePolicy `json:"failurePolicy,omitempty"`
+
+	// ConcurrencyPolicy specifies how to treat a new BackupSession when a previous BackupSession is still running.
+	// Default value is "Forbid".
+	// +optional
+	// +kubebuilder:default=Forbid
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// StartingDeadline specifies the maximum delay after the creation of a BackupSession within which the backup must start.
+	// BackupSessions that could not start within this time will be skipped. By default, there is no deadline.
+	// +optional
+	StartingDeadline *metav1.Duration `json:"startingDeadline,omitempty"`
 }
 
 type BackupBatchStatus struct {
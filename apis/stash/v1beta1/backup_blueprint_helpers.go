@@ -17,11 +17,18 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
 	"hash/fnv"
+	"slices"
 	"strconv"
+	"strings"
 
 	"stash.appscode.dev/apimachinery/crds"
 
+	"gomodules.xyz/pointer"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"kmodules.xyz/client-go/apiextensions"
 	meta_util "kmodules.xyz/client-go/meta"
 )
@@ -35,3 +42,79 @@ func (bb BackupBlueprint) GetSpecHash() string {
 	meta_util.DeepHashObject(hash, bb.Spec)
 	return strconv.FormatUint(hash.Sum64(), 10)
 }
+
+// Default sets the default values of a BackupBlueprint
+func (bb *BackupBlueprint) Default() {
+	bb.Spec.RepositorySpec.Default()
+	if bb.Spec.BackupHistoryLimit == nil {
+		bb.Spec.BackupHistoryLimit = pointer.Int32P(DefaultBackupHistoryLimit)
+	}
+	defaultBackupHooks(bb.Spec.Hooks)
+	defaultRetryConfig(bb.Spec.RetryConfig)
+}
+
+// Selects returns true if the selector of the blueprint selects the given object of the given kind.
+// A blueprint without any selector does not select any object.
+func (bb BackupBlueprint) Selects(obj metav1.Object, kind string, namespace *core.Namespace) bool {
+	sel := bb.Spec.Selector
+	if sel == nil {
+		return false
+	}
+	if len(sel.Kinds) > 0 && !slices.ContainsFunc(sel.Kinds, func(k string) bool { return strings.EqualFold(k, kind) }) {
+		return false
+	}
+	if sel.NamespaceSelector != nil && (namespace == nil || !labelSelectorMatches(sel.NamespaceSelector, namespace.Labels)) {
+		return false
+	}
+	return sel.ObjectSelector == nil || labelSelectorMatches(sel.ObjectSelector, obj.GetLabels())
+}
+
+// FindBackupBlueprint finds the BackupBlueprint applicable to the given object of the given kind.
+// The blueprint referred by the "stash.appscode.com/backup-blueprint" annotation of the object has the highest precedence.
+// Otherwise, the blueprint with the highest priority among the blueprints that select the object is returned.
+// It returns error if multiple blueprints with the highest priority select the object. It returns nil if no blueprint
+// is applicable to the object.
+func FindBackupBlueprint(blueprints []BackupBlueprint, obj metav1.Object, kind string, namespace *core.Namespace) (*BackupBlueprint, error) {
+	if name, ok := obj.GetAnnotations()[KeyBackupBlueprint]; ok {
+		for i := range blueprints {
+			if blueprints[i].Name == name {
+				return &blueprints[i], nil
+			}
+		}
+		return nil, fmt.Errorf("BackupBlueprint %s referred by %s %s/%s not found", name, kind, obj.GetNamespace(), obj.GetName())
+	}
+
+	var matched []*BackupBlueprint
+	for i := range blueprints {
+		if !blueprints[i].Selects(obj, kind, namespace) {
+			continue
+		}
+		switch {
+		case len(matched) == 0 || blueprints[i].Spec.Selector.Priority == matched[0].Spec.Selector.Priority:
+			matched = append(matched, &blueprints[i])
+		case blueprints[i].Spec.Selector.Priority > matched[0].Spec.Selector.Priority:
+			matched = []*BackupBlueprint{&blueprints[i]}
+		}
+	}
+	switch len(matched) {
+	case 0:
+		return nil, nil
+	case 1:
+		return matched[0], nil
+	}
+	names := make([]string, 0, len(matched))
+	for _, bb := range matched {
+		names = append(names, bb.Name)
+	}
+	slices.Sort(names)
+	return nil, fmt.Errorf("%s %s/%s is selected by multiple BackupBlueprints with priority %d: %s",
+		kind, obj.GetNamespace(), obj.GetName(), matched[0].Spec.Selector.Priority, strings.Join(names, ", "))
+}
+
+func labelSelectorMatches(ls *metav1.LabelSelector, srcLabels map[string]string) bool {
+	selector, err := metav1.LabelSelectorAsSelector(ls)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(srcLabels))
+}
@@ -0,0 +1,141 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestFindBackupBlueprint(t *testing.T) {
+	blueprint := func(name string, sel *BlueprintSelector) BackupBlueprint {
+		return BackupBlueprint{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: BackupBlueprintSpec{Selector: sel}}
+	}
+	prodPVC := &BlueprintSelector{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+		ObjectSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "prod"}},
+		Kinds:             []string{"PersistentVolumeClaim"},
+		Priority:          10,
+	}
+	allPVC := &BlueprintSelector{Kinds: []string{"persistentvolumeclaim"}}
+	blueprints := []BackupBlueprint{
+		blueprint("annotation-only", nil),
+		blueprint("all-pvc", allPVC),
+		blueprint("prod-pvc", prodPVC),
+	}
+	prod := &core.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod", Labels: map[string]string{"env": "prod"}}}
+	dev := &core.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev", Labels: map[string]string{"env": "dev"}}}
+
+	tests := []struct {
+		name       string
+		blueprints []BackupBlueprint
+		obj        metav1.ObjectMeta
+		kind       string
+		namespace  *core.Namespace
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:      "highest priority wins",
+			obj:       metav1.ObjectMeta{Name: "data", Namespace: "prod", Labels: map[string]string{"tier": "prod"}},
+			kind:      "PersistentVolumeClaim",
+			namespace: prod,
+			want:      "prod-pvc",
+		},
+		{
+			name:      "namespace not selected",
+			obj:       metav1.ObjectMeta{Name: "data", Namespace: "dev", Labels: map[string]string{"tier": "prod"}},
+			kind:      "PersistentVolumeClaim",
+			namespace: dev,
+			want:      "all-pvc",
+		},
+		{
+			name:      "kind not selected",
+			obj:       metav1.ObjectMeta{Name: "app", Namespace: "prod", Labels: map[string]string{"tier": "prod"}},
+			kind:      "Deployment",
+			namespace: prod,
+		},
+		{
+			name: "annotation has the highest precedence",
+			obj: metav1.ObjectMeta{
+				Name: "data", Namespace: "prod", Labels: map[string]string{"tier": "prod"},
+				Annotations: map[string]string{KeyBackupBlueprint: "annotation-only"},
+			},
+			kind:      "PersistentVolumeClaim",
+			namespace: prod,
+			want:      "annotation-only",
+		},
+		{
+			name:      "annotation refers to a missing blueprint",
+			obj:       metav1.ObjectMeta{Name: "data", Namespace: "prod", Annotations: map[string]string{KeyBackupBlueprint: "missing"}},
+			kind:      "PersistentVolumeClaim",
+			namespace: prod,
+			wantErr:   true,
+		},
+		{
+			name:       "conflicting blueprints with the same priority",
+			blueprints: append(blueprints, blueprint("prod-pvc-copy", prodPVC)),
+			obj:        metav1.ObjectMeta{Name: "data", Namespace: "prod", Labels: map[string]string{"tier": "prod"}},
+			kind:       "PersistentVolumeClaim",
+			namespace:  prod,
+			wantErr:    true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.blueprints == nil {
+				test.blueprints = blueprints
+			}
+			bb, err := FindBackupBlueprint(test.blueprints, &test.obj, test.kind, test.namespace)
+			if (err != nil) != test.wantErr {
+				t.Errorf("FindBackupBlueprint() error = %v, wantErr %v", err, test.wantErr)
+				return
+			}
+			got := ""
+			if bb != nil {
+				got = bb.Name
+			}
+			if got != test.want {
+				t.Errorf("expected BackupBlueprint %q, found %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateBlueprintSelector(t *testing.T) {
+	sel := &BlueprintSelector{
+		NamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "env", Operator: "Invalid"}}},
+		Kinds:             []string{"PersistentVolumeClaim", "", "persistentvolumeclaim"},
+	}
+	want := []string{"spec.selector.namespaceSelector", "spec.selector.kinds[1]", "spec.selector.kinds[2]"}
+	errs := validateBlueprintSelector(sel, field.NewPath("spec", "selector"))
+	if len(errs) != len(want) {
+		t.Errorf("expected errors on %v, found %v", want, errs)
+		return
+	}
+	for i := range errs {
+		if errs[i].Field != want[i] {
+			t.Errorf("expected error on %s, found %v", want[i], errs[i])
+		}
+	}
+	if errs = validateBlueprintSelector(&BlueprintSelector{}, field.NewPath("spec", "selector")); len(errs) != 1 {
+		t.Errorf("expected an error for an empty selector, found %v", errs)
+	}
+}
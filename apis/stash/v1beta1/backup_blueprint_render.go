@@ -0,0 +1,294 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"stash.appscode.dev/apimachinery/apis"
+	"stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	kmapi "kmodules.xyz/client-go/api/v1"
+)
+
+// paramRef matches the references of the template parameters i.e. "${NAME}"
+var paramRef = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)}`)
+
+// paramName matches the valid names of the template parameters
+var paramName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// builtinParams are the variables that are always available to a BackupBlueprint
+var builtinParams = []string{apis.TargetAPIVersion, apis.TargetKind, apis.TargetName, apis.TargetNamespace}
+
+// BlueprintTarget holds the information of the workload a BackupBlueprint is applied to
+type BlueprintTarget struct {
+	// Ref refers to the workload
+	Ref TargetRef
+	// Annotations are the annotations of the workload. The values of the template parameters,
+	// the schedule, the target paths and the volume mounts are read from them.
+	Annotations map[string]string
+}
+
+// ResolveParams resolves the values of the template parameters of the blueprint for the given target.
+// The values are read from the "params.stash.appscode.com/<name>" annotations of the target. The declared default
+// is used if the target does not supply any value. It returns error if a required parameter is missing, a value does
+// not match the pattern of the parameter or the target supplies a value for a parameter that is not declared.
+func (bb BackupBlueprint) ResolveParams(target BlueprintTarget) (map[string]string, field.ErrorList) {
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("metadata", "annotations")
+
+	values := map[string]string{
+		apis.TargetAPIVersion: target.Ref.APIVersion,
+		apis.TargetKind:       target.Ref.Kind,
+		apis.TargetName:       target.Ref.Name,
+		apis.TargetNamespace:  target.Ref.Namespace,
+	}
+
+	declared := make(map[string]bool, len(bb.Spec.Params))
+	for _, p := range bb.Spec.Params {
+		declared[p.Name] = true
+		key := KeyParams + "/" + p.Name
+		value, found := target.Annotations[key]
+		switch {
+		case found:
+		case p.Default != nil:
+			value = *p.Default
+		case p.Required:
+			allErrs = append(allErrs, field.Required(fldPath.Key(key), fmt.Sprintf("parameter %q of BackupBlueprint %s is required", p.Name, bb.Name)))
+			continue
+		default:
+			// an optional parameter without any value resolves to an empty string
+		}
+		if p.Pattern != "" && (found || p.Default != nil) {
+			// the pattern has been validated with the BackupBlueprint
+			if re, err := regexp.Compile(p.Pattern); err == nil && !re.MatchString(value) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Key(key), value, fmt.Sprintf("must match the pattern %q", p.Pattern)))
+				continue
+			}
+		}
+		values[p.Name] = value
+	}
+
+	prefix := KeyParams + "/"
+	for _, key := range slices.Sorted(maps.Keys(target.Annotations)) {
+		if name, ok := strings.CutPrefix(key, prefix); ok && !declared[name] {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(key), target.Annotations[key],
+				fmt.Sprintf("parameter %q is not declared in BackupBlueprint %s", name, bb.Name)))
+		}
+	}
+	return values, allErrs
+}
+
+// Render renders the blueprint for the given target. It returns the BackupConfiguration and the Repository
+// that should be created for the target. The rendered objects are validated before they are returned.
+func (bb BackupBlueprint) Render(target BlueprintTarget) (*BackupConfiguration, *v1alpha1.Repository, error) {
+	values, errs := bb.ResolveParams(target)
+	if len(errs) > 0 {
+		return nil, nil, errs.ToAggregate()
+	}
+	spec, err := renderBlueprintSpec(bb.Spec, values)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	name := BlueprintObjectName(target.Ref)
+	backupNamespace := target.Ref.Namespace
+	if spec.BackupNamespace != "" {
+		backupNamespace = spec.BackupNamespace
+	}
+	repoNamespace := backupNamespace
+	if spec.RepoNamespace != "" {
+		repoNamespace = spec.RepoNamespace
+	}
+	annotations := map[string]string{
+		KeyBackupBlueprint: bb.Name,
+	}
+
+	repo := &v1alpha1.Repository{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       v1alpha1.ResourceKindRepository,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   repoNamespace,
+			Annotations: annotations,
+		},
+		Spec: spec.RepositorySpec,
+	}
+
+	targetRef := target.Ref
+	if targetRef.Namespace == backupNamespace {
+		targetRef.Namespace = ""
+	}
+	backupTarget := &BackupTarget{
+		Ref:   targetRef,
+		Paths: splitAnnotation(target.Annotations[KeyTargetPaths]),
+	}
+	if backupTarget.VolumeMounts, err = parseVolumeMounts(target.Annotations[KeyVolumeMounts]); err != nil {
+		return nil, nil, err
+	}
+	schedule := spec.Schedule
+	if s, ok := target.Annotations[KeySchedule]; ok {
+		schedule = s
+	}
+
+	bc := &BackupConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: SchemeGroupVersion.String(),
+			Kind:       ResourceKindBackupConfiguration,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   backupNamespace,
+			Annotations: annotations,
+		},
+		Spec: BackupConfigurationSpec{
+			BackupConfigurationTemplateSpec: BackupConfigurationTemplateSpec{
+				Task:                  spec.Task,
+				Target:                backupTarget,
+				RuntimeSettings:       spec.RuntimeSettings,
+				TempDir:               spec.TempDir,
+				InterimVolumeTemplate: spec.InterimVolumeTemplate,
+				Hooks:                 spec.Hooks,
+				TimeOut:               spec.TimeOut,
+				RetryConfig:           spec.RetryConfig,
+			},
+			Schedule: schedule,
+			TimeZone: spec.TimeZone,
+			Driver:   ResticSnapshotter,
+			Repository: kmapi.ObjectReference{
+				Name:      repo.Name,
+				Namespace: repo.Namespace,
+			},
+			RetentionPolicy:    spec.RetentionPolicy,
+			BackupHistoryLimit: spec.BackupHistoryLimit,
+		},
+	}
+	if repo.Namespace == bc.Namespace {
+		bc.Spec.Repository.Namespace = ""
+	}
+
+	errs = repo.ValidateCreate()
+	errs = append(errs, bc.ValidateCreate()...)
+	if len(errs) > 0 {
+		return nil, nil, errs.ToAggregate()
+	}
+	return bc, repo, nil
+}
+
+// BlueprintObjectName returns the name of the BackupConfiguration and the Repository created for a target from a BackupBlueprint
+func BlueprintObjectName(ref TargetRef) string {
+	return strings.ToLower(ref.Kind) + "-" + ref.Name
+}
+
+// renderBlueprintSpec replaces the references of the parameters in the spec with their values.
+// It returns error if the spec refers to any variable that is neither a declared parameter nor a built-in variable.
+func renderBlueprintSpec(spec BackupBlueprintSpec, values map[string]string) (*BackupBlueprintSpec, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	unknown := sets.New[string]()
+	data = paramRef.ReplaceAllFunc(data, func(ref []byte) []byte {
+		name := string(paramRef.FindSubmatch(ref)[1])
+		value, ok := values[name]
+		if !ok {
+			unknown.Insert(name)
+			return ref
+		}
+		// escape the value as it is placed inside a json string
+		escaped, _ := json.Marshal(value)
+		return escaped[1 : len(escaped)-1]
+	})
+	if unknown.Len() > 0 {
+		return nil, fmt.Errorf("blueprint refers to undeclared parameters: %s", strings.Join(sets.List(unknown), ", "))
+	}
+	var rendered BackupBlueprintSpec
+	if err = json.Unmarshal(data, &rendered); err != nil {
+		return nil, err
+	}
+	rendered.Params = nil
+	return &rendered, nil
+}
+
+func splitAnnotation(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseVolumeMounts parses the volume mounts from the "stash.appscode.com/volume-mounts" annotation.
+// The annotation holds a comma separated list of "<volume name>:<mount path>[:<sub path>]".
+func parseVolumeMounts(value string) ([]core.VolumeMount, error) {
+	var mounts []core.VolumeMount
+	for _, item := range splitAnnotation(value) {
+		parts := strings.Split(item, ":")
+		if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid volume mount %q in annotation %s, expected format is <volume name>:<mount path>[:<sub path>]", item, KeyVolumeMounts)
+		}
+		mount := core.VolumeMount{Name: parts[0], MountPath: parts[1]}
+		if len(parts) == 3 {
+			mount.SubPath = parts[2]
+		}
+		mounts = append(mounts, mount)
+	}
+	return mounts, nil
+}
+
+func validateBlueprintParams(params []BlueprintParam, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	names := map[string]bool{}
+	for _, name := range builtinParams {
+		names[name] = true
+	}
+	for i, p := range params {
+		idxPath := fldPath.Index(i)
+		if !paramName.MatchString(p.Name) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), p.Name, "must consist of alphanumeric characters or '_' and must not start with a digit"))
+		} else if names[p.Name] {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), p.Name))
+		}
+		names[p.Name] = true
+		if p.Required && p.Default != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("default"), *p.Default, "a required parameter must not have a default value"))
+		}
+		if p.Pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile(p.Pattern)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("pattern"), p.Pattern, err.Error()))
+		} else if p.Default != nil && !re.MatchString(*p.Default) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("default"), *p.Default, fmt.Sprintf("must match the pattern %q", p.Pattern)))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,184 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	"stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+
+	"gomodules.xyz/pointer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	store "kmodules.xyz/objectstore-api/api/v1"
+)
+
+func newTestBlueprint() BackupBlueprint {
+	return BackupBlueprint{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload-backup"},
+		Spec: BackupBlueprintSpec{
+			RepositorySpec: v1alpha1.RepositorySpec{
+				Backend: store.Backend{
+					S3:                &store.S3Spec{Bucket: "${BUCKET}", Prefix: "stash/${TARGET_NAMESPACE}/${TARGET_KIND}/${TARGET_NAME}"},
+					StorageSecretName: "s3-secret",
+				},
+			},
+			Schedule: "${SCHEDULE}",
+			TimeZone: "Asia/Dhaka",
+			RetentionPolicy: v1alpha1.RetentionPolicy{
+				Name:     "keep-last-5",
+				KeepLast: 5,
+			},
+			Params: []BlueprintParam{
+				{Name: "BUCKET", Required: true, Pattern: "^[a-z0-9-]+$"},
+				{Name: "SCHEDULE", Default: pointer.StringP("*/30 * * * *")},
+				{Name: "TEAM"},
+			},
+		},
+	}
+}
+
+func TestBackupBlueprint_Render(t *testing.T) {
+	bb := newTestBlueprint()
+	target := BlueprintTarget{
+		Ref: TargetRef{APIVersion: "apps/v1", Kind: "Deployment", Name: "app", Namespace: "demo"},
+		Annotations: map[string]string{
+			KeyParams + "/BUCKET": "team-backup",
+			KeyTargetPaths:        "/data, /config",
+			KeyVolumeMounts:       "data:/data,config:/config:app",
+		},
+	}
+
+	bc, repo, err := bb.Render(target)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if repo.Name != "deployment-app" || repo.Namespace != "demo" {
+		t.Errorf("unexpected Repository %s/%s", repo.Namespace, repo.Name)
+	}
+	if got := repo.Spec.Backend.S3.Bucket; got != "team-backup" {
+		t.Errorf("expected bucket team-backup, found %s", got)
+	}
+	if got := repo.Spec.Backend.S3.Prefix; got != "stash/demo/Deployment/app" {
+		t.Errorf("expected prefix stash/demo/Deployment/app, found %s", got)
+	}
+	if bc.Spec.Schedule != "*/30 * * * *" || bc.Spec.TimeZone != "Asia/Dhaka" {
+		t.Errorf("unexpected schedule %q in time zone %q", bc.Spec.Schedule, bc.Spec.TimeZone)
+	}
+	if bc.Spec.Repository.Name != repo.Name || bc.Spec.Repository.Namespace != "" {
+		t.Errorf("unexpected repository reference %+v", bc.Spec.Repository)
+	}
+	if len(bc.Spec.Target.Paths) != 2 || len(bc.Spec.Target.VolumeMounts) != 2 || bc.Spec.Target.VolumeMounts[1].SubPath != "app" {
+		t.Errorf("unexpected target %+v", bc.Spec.Target)
+	}
+	if bb.Spec.Backend.S3.Bucket != "${BUCKET}" {
+		t.Errorf("rendering must not modify the blueprint")
+	}
+
+	// the schedule annotation has higher precedence than the blueprint
+	target.Annotations[KeySchedule] = "0 1 * * *"
+	if bc, _, err = bb.Render(target); err != nil || bc.Spec.Schedule != "0 1 * * *" {
+		t.Errorf("expected the schedule of the annotation, found %v, err: %v", bc, err)
+	}
+
+	// the references of the undeclared variables must not be left in the rendered objects
+	bb.Spec.Backend.S3.Prefix = "stash/${CLUSTER}/${TARGET_NAME}/${ENV}"
+	if _, _, err = bb.Render(target); err == nil || err.Error() != "blueprint refers to undeclared parameters: CLUSTER, ENV" {
+		t.Errorf("expected error for the undeclared parameters, found %v", err)
+	}
+}
+
+func TestBackupBlueprint_ResolveParams(t *testing.T) {
+	ref := TargetRef{APIVersion: "apps/v1", Kind: "Deployment", Name: "app", Namespace: "demo"}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        map[string]string
+		wantErrs    []string
+	}{
+		{
+			name:        "defaults are applied",
+			annotations: map[string]string{KeyParams + "/BUCKET": "backup"},
+			want:        map[string]string{"BUCKET": "backup", "SCHEDULE": "*/30 * * * *", "TEAM": "", "TARGET_NAME": "app"},
+		},
+		{
+			name:        "required parameter is missing",
+			annotations: map[string]string{},
+			wantErrs:    []string{"metadata.annotations[params.stash.appscode.com/BUCKET]"},
+		},
+		{
+			name:        "value does not match the pattern",
+			annotations: map[string]string{KeyParams + "/BUCKET": "Backup_Bucket"},
+			wantErrs:    []string{"metadata.annotations[params.stash.appscode.com/BUCKET]"},
+		},
+		{
+			name:        "undeclared parameter",
+			annotations: map[string]string{KeyParams + "/BUCKET": "backup", KeyParams + "/BUCKIT": "backup"},
+			wantErrs:    []string{"metadata.annotations[params.stash.appscode.com/BUCKIT]"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			values, errs := newTestBlueprint().ResolveParams(BlueprintTarget{Ref: ref, Annotations: test.annotations})
+			if len(errs) != len(test.wantErrs) {
+				t.Errorf("expected errors on %v, found %v", test.wantErrs, errs)
+				return
+			}
+			for i := range errs {
+				if errs[i].Field != test.wantErrs[i] {
+					t.Errorf("expected error on %s, found %v", test.wantErrs[i], errs[i])
+				}
+			}
+			for k, v := range test.want {
+				if values[k] != v {
+					t.Errorf("expected %s=%q, found %q", k, v, values[k])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateBlueprintParams(t *testing.T) {
+	params := []BlueprintParam{
+		{Name: "BUCKET", Required: true, Default: pointer.StringP("backup")},
+		{Name: "TARGET_NAME"},
+		{Name: "1ST"},
+		{Name: "REGION", Pattern: "["},
+		{Name: "ZONE", Pattern: "^[a-z]+$", Default: pointer.StringP("Zone-1")},
+		{Name: "BUCKET"},
+	}
+	want := []string{
+		"spec.params[0].default",
+		"spec.params[1].name",
+		"spec.params[2].name",
+		"spec.params[3].pattern",
+		"spec.params[4].default",
+		"spec.params[5].name",
+	}
+	errs := validateBlueprintParams(params, field.NewPath("spec", "params"))
+	if len(errs) != len(want) {
+		t.Errorf("expected errors on %v, found %v", want, errs)
+		return
+	}
+	for i := range errs {
+		if errs[i].Field != want[i] {
+			t.Errorf("expected error on %s, found %v", want[i], errs[i])
+		}
+	}
+}
@@ -60,6 +60,10 @@ type BackupBlueprintSpec struct {
 	// Schedule specifies the default schedule for backup.
 	// You can overwrite this schedule for a particular target using 'stash.appscode.com/schedule' annotation.
 	Schedule string `json:"schedule,omitempty"`
+	// TimeZone is the IANA name of the time zone (i.e. "Asia/Dhaka") in which the schedule is evaluated.
+	// If it is not specified, the schedule is evaluated in the time zone of the cluster.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
 	// Task specify the Task crd that specifies steps for backup process
 	// +optional
 	Task TaskRef `json:"task,omitempty"`
@@ -94,6 +98,50 @@ type BackupBlueprintSpec struct {
 	// By default, Stash does not retry any failed backup.
 	// +optional
 	RetryConfig *RetryConfig `json:"retryConfig,omitempty"`
+
+	// Params declares the template parameters of the blueprint. They can be referred as "${<name>}" in the blueprint.
+	// A workload supplies the value of a parameter using "params.stash.appscode.com/<name>" annotation.
+	// +optional
+	Params []BlueprintParam `json:"params,omitempty"`
+
+	// Selector selects the objects the blueprint is applied to without the "stash.appscode.com/backup-blueprint" annotation.
+	// If it is not specified, the blueprint is applied only to the objects that refer to it using the annotation.
+	// +optional
+	Selector *BlueprintSelector `json:"selector,omitempty"`
+}
+
+// BlueprintSelector selects the objects a BackupBlueprint is applied to
+type BlueprintSelector struct {
+	// NamespaceSelector selects the namespaces of the objects. If it is not specified, the objects of all namespaces are selected.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// ObjectSelector selects the objects by their labels. If it is not specified, all objects of the selected kinds are selected.
+	// +optional
+	ObjectSelector *metav1.LabelSelector `json:"objectSelector,omitempty"`
+	// Kinds specifies the kinds of the objects i.e. "Deployment", "PersistentVolumeClaim".
+	// If it is not specified, the objects of all kinds are selected.
+	// +optional
+	Kinds []string `json:"kinds,omitempty"`
+	// Priority is used to choose a blueprint when multiple blueprints select the same object.
+	// The blueprint with the highest priority is applied. The object is not backed up if multiple blueprints
+	// with the highest priority select it. Default value is 0.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+}
+
+// BlueprintParam declares a template parameter of a BackupBlueprint
+type BlueprintParam struct {
+	// Name of the parameter. It must not conflict with the built-in variables i.e. "TARGET_NAME".
+	Name string `json:"name"`
+	// Default specifies the value to use when the workload does not supply any value for the parameter.
+	// +optional
+	Default *string `json:"default,omitempty"`
+	// Required specifies whether the workload must supply a value for the parameter.
+	// +optional
+	Required bool `json:"required,omitempty"`
+	// Pattern specifies a regular expression that the value of the parameter must match.
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -22,6 +22,7 @@ import (
 
 	"stash.appscode.dev/apimachinery/crds"
 
+	"gomodules.xyz/pointer"
 	"kmodules.xyz/client-go/apiextensions"
 	meta_util "kmodules.xyz/client-go/meta"
 )
@@ -36,6 +37,21 @@ func (b BackupConfiguration) GetSpecHash() string {
 	return strconv.FormatUint(hash.Sum64(), 10)
 }
 
+// Default sets the default values of a BackupConfiguration
+func (b *BackupConfiguration) Default() {
+	if b.Spec.Driver == "" {
+		b.Spec.Driver = ResticSnapshotter
+	}
+	if b.Spec.BackupHistoryLimit == nil {
+		b.Spec.BackupHistoryLimit = pointer.Int32P(DefaultBackupHistoryLimit)
+	}
+	if b.Spec.ConcurrencyPolicy == "" {
+		b.Spec.ConcurrencyPolicy = ForbidConcurrent
+	}
+	defaultBackupHooks(b.Spec.Hooks)
+	defaultRetryConfig(b.Spec.RetryConfig)
+}
+
 // OffshootLabels return labels consist of the labels provided by user to BackupConfiguration crd and
 // stash specific generic labels. It overwrites the the user provided labels if it matched with stash specific generic labels.
 func (b BackupConfiguration) OffshootLabels() map[string]string {
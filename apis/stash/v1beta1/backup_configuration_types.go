@@ -74,6 +74,23 @@ type BackupConfigurationTemplateSpec struct {
 	// Actions that Stash should take in response to backup sessions.
 	// +optional
 	Hooks *BackupHooks `json:"hooks,omitempty"`
+	// DependsOn specifies the targets of the other members of the BackupBatch that must complete their backup
+	// before this member starts. The members without any dependency are backed up in parallel.
+	// If any member specifies its dependencies, the executionOrder of the BackupBatch is ignored.
+	// It is only used for the members of a BackupBatch.
+	// +optional
+	DependsOn []TargetRef `json:"dependsOn,omitempty"`
+	// TimeOut specifies the maximum duration of backup. BackupSession will be considered Failed
+	// if backup does not complete within this time limit. By default, Stash don't set any timeout for backup.
+	// For a member of a BackupBatch, it specifies the maximum duration of the backup of this member only.
+	// +optional
+	TimeOut *metav1.Duration `json:"timeOut,omitempty"`
+	// RetryConfig specify a configuration for retry a backup if it fails.
+	// By default, Stash does not retry any failed backup.
+	// For a member of a BackupBatch, only the backup of this member is retried when it fails
+	// without re-running the backup of the other members.
+	// +optional
+	RetryConfig *RetryConfig `json:"retryConfig,omitempty"`
 }
 
 type BackupConfigurationSpec struct {
@@ -81,6 +98,10 @@ type BackupConfigurationSpec struct {
 	// Schedule specifies the schedule for invoking backup sessions
 	// +optional
 	Schedule string `json:"schedule,omitempty"`
+	// TimeZone is the IANA name of the time zone (i.e. "Asia/Dhaka") in which the schedule is evaluated.
+	// If it is not specified, the schedule is evaluated in the time zone of the cluster.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
 	// Driver indicates the name of the agent to use to backup the target.
 	// Supported values are "Restic", "VolumeSnapshotter".
 	// Default value is "Restic".
@@ -100,15 +121,102 @@ type BackupConfigurationSpec struct {
 	// Default: 1
 	// +optional
 	BackupHistoryLimit *int32 `json:"backupHistoryLimit,omitempty"`
-	// TimeOut specifies the maximum duration of backup. BackupSession will be considered Failed
-	// if backup does not complete within this time limit. By default, Stash don't set any timeout for backup.
+	// BackupWindow specifies the time of the day when a backup is allowed to start.
+	// BackupSessions triggered outside of the window will be skipped.
 	// +optional
-	TimeOut *metav1.Duration `json:"timeOut,omitempty"`
+	BackupWindow *BackupWindow `json:"backupWindow,omitempty"`
 
-	// RetryConfig specify a configuration for retry a backup if it fails.
-	// By default, Stash does not retry any failed backup.
+	// Blackouts specifies the periods when no backup is allowed to start.
+	// BackupSessions triggered during a blackout period will be skipped.
 	// +optional
-	RetryConfig *RetryConfig `json:"retryConfig,omitempty"`
+	Blackouts []BlackoutPeriod `json:"blackouts,omitempty"`
+
+	// ConcurrencyPolicy specifies how to treat a new BackupSession when a previous BackupSession is still running.
+	// Default value is "Forbid".
+	// +optional
+	// +kubebuilder:default=Forbid
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// StartingDeadline specifies the maximum delay after the creation of a BackupSession within which the backup must start.
+	// BackupSessions that could not start within this time will be skipped. By default, there is no deadline.
+	// +optional
+	StartingDeadline *metav1.Duration `json:"startingDeadline,omitempty"`
+
+	// Schedules specifies additional schedules of the backup. Each of them tags its snapshots and can use its own
+	// repository and retention policy, i.e. hourly backup in a local repository and weekly backup in an offsite repository.
+	// The schedules are evaluated in the time zone specified in the timeZone field.
+	// +optional
+	Schedules []ScheduledPolicy `json:"schedules,omitempty"`
+}
+
+// ScheduledPolicy specifies a schedule of a BackupConfiguration along with the policies of the backups it triggers
+type ScheduledPolicy struct {
+	// Name identifies the schedule. It is recorded in the BackupSessions triggered by this schedule.
+	Name string `json:"name"`
+	// Schedule specifies the cron expression of the schedule
+	Schedule string `json:"schedule"`
+	// Tag is added to the snapshots taken by this schedule. The retention policy of this schedule is applied only
+	// on the snapshots with this tag. Default value is the name of the schedule.
+	// +optional
+	Tag string `json:"tag,omitempty"`
+	// Repository overrides the Repository of the BackupConfiguration for the backups triggered by this schedule
+	// +optional
+	Repository *kmapi.ObjectReference `json:"repository,omitempty"`
+	// RetentionPolicy overrides the retention policy of the BackupConfiguration for the snapshots taken by this schedule
+	// +optional
+	RetentionPolicy *v1alpha1.RetentionPolicy `json:"retentionPolicy,omitempty"`
+}
+
+// ConcurrencyPolicy specifies how to treat a new BackupSession when a previous BackupSession is still running
+// +kubebuilder:validation:Enum=Allow;Forbid;Replace;Queue
+type ConcurrencyPolicy string
+
+const (
+	// AllowConcurrent allows the BackupSessions to run concurrently
+	AllowConcurrent ConcurrencyPolicy = "Allow"
+	// ForbidConcurrent skips the new BackupSession if the previous one is still running
+	ForbidConcurrent ConcurrencyPolicy = "Forbid"
+	// ReplaceConcurrent stops the running BackupSession and starts the new one
+	ReplaceConcurrent ConcurrencyPolicy = "Replace"
+	// QueueConcurrent keeps the new BackupSession pending until the previous ones have completed
+	QueueConcurrent ConcurrencyPolicy = "Queue"
+)
+
+// BackupWindow specifies a daily time window when a backup is allowed to start
+type BackupWindow struct {
+	// Start is the beginning of the window in "HH:MM" (24-hour) format
+	Start string `json:"start"`
+	// End is the end of the window in "HH:MM" (24-hour) format.
+	// If End is earlier than Start, the window spans over midnight.
+	End string `json:"end"`
+	// TimeZone specifies the IANA time zone (i.e. "Asia/Dhaka") of the Start and End time.
+	// If it is not specified, the time zone of the schedule is used, which defaults to the time zone of the cluster.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// BlackoutPeriod specifies a period when no backup is allowed to start.
+// Specify either Start and End for a fixed date range, or Schedule and Duration for a recurring period.
+type BlackoutPeriod struct {
+	// Name is an identifier for the blackout period. It is used in the skip message.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Start is the beginning of a fixed blackout period
+	// +optional
+	Start *metav1.Time `json:"start,omitempty"`
+	// End is the end of a fixed blackout period
+	// +optional
+	End *metav1.Time `json:"end,omitempty"`
+	// Schedule is a cron expression specifying when a recurring blackout period begins
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+	// Duration specifies how long a recurring blackout period lasts
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// TimeZone specifies the IANA time zone of the Schedule.
+	// If it is not specified, the time zone of the backup schedule is used, which defaults to the time zone of the cluster.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
 }
 
 // Hooks describes actions that Stash should take in response to backup sessions. For the PostBackup
@@ -127,11 +235,12 @@ type BackupHooks struct {
 type PostBackupHook struct {
 	*prober.Handler `json:",inline"`
 	// ExecutionPolicy specifies when to execute a hook.
-	// Supported values are "Always", "OnFailure", "OnSuccess", "OnFinalRetryFailure".
+	// Supported values are "Always", "OnFailure", "OnSuccess", "OnPartialSuccess", "OnFinalRetryFailure".
+	// "OnFailure" also executes the hook when the session has partially succeeded as some targets have failed.
 	// Default value: "Always".
 	// +optional
 	// +kubebuilder:default=Always
-	// +kubebuilder:validation:Enum=Always;OnFailure;OnSuccess;OnFinalRetryFailure
+	// +kubebuilder:validation:Enum=Always;OnFailure;OnSuccess;OnPartialSuccess;OnFinalRetryFailure
 	ExecutionPolicy HookExecutionPolicy `json:"executionPolicy,omitempty"`
 }
 
@@ -142,6 +251,9 @@ const (
 	ExecuteOnFailure      HookExecutionPolicy = "OnFailure"
 	ExecuteOnSuccess      HookExecutionPolicy = "OnSuccess"
 	ExecuteOnRetryFailure HookExecutionPolicy = "OnFinalRetryFailure"
+	// ExecuteOnPartialSuccess executes the hook only if some members of a batch have failed
+	// but the failure policy allows the session to be considered partially successful.
+	ExecuteOnPartialSuccess HookExecutionPolicy = "OnPartialSuccess"
 )
 
 type EmptyDirSettings struct {
@@ -35,3 +35,44 @@ func (bs BackupSession) GetSpecHash() string {
 	meta_util.DeepHashObject(hash, bs.Spec)
 	return strconv.FormatUint(hash.Sum64(), 10)
 }
+
+// IncludesTarget checks whether the given target should be backed up in this session.
+// All the targets are included if the session does not specify any target.
+func (bs BackupSession) IncludesTarget(ref TargetRef) bool {
+	if len(bs.Spec.Targets) == 0 {
+		return true
+	}
+	key := targetKey(ref, bs.Namespace)
+	for _, t := range bs.Spec.Targets {
+		if targetKey(t, bs.Namespace) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// OverrideParams returns the given Task parameters after applying the parameter overrides of the session
+func (bs BackupSession) OverrideParams(params []Param) []Param {
+	if len(bs.Spec.Params) == 0 {
+		return params
+	}
+	result := make([]Param, 0, len(params)+len(bs.Spec.Params))
+	overrides := make(map[string]string, len(bs.Spec.Params))
+	for _, p := range bs.Spec.Params {
+		overrides[p.Name] = p.Value
+	}
+	for _, p := range params {
+		if v, ok := overrides[p.Name]; ok {
+			p.Value = v
+			delete(overrides, p.Name)
+		}
+		result = append(result, p)
+	}
+	// add the parameters that were not present in the Task
+	for _, p := range bs.Spec.Params {
+		if _, ok := overrides[p.Name]; ok {
+			result = append(result, p)
+		}
+	}
+	return result
+}
@@ -0,0 +1,65 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBackupSession_OverrideParams(t *testing.T) {
+	params := []Param{
+		{Name: "args", Value: "--all-databases"},
+		{Name: "enableCache", Value: "true"},
+	}
+
+	tests := []struct {
+		name      string
+		overrides []Param
+		want      []Param
+	}{
+		{
+			name: "No override",
+			want: params,
+		},
+		{
+			name:      "Override existing parameter",
+			overrides: []Param{{Name: "args", Value: "--databases=app"}},
+			want: []Param{
+				{Name: "args", Value: "--databases=app"},
+				{Name: "enableCache", Value: "true"},
+			},
+		},
+		{
+			name:      "Add new parameter",
+			overrides: []Param{{Name: "scratchDir", Value: "/tmp"}},
+			want: []Param{
+				{Name: "args", Value: "--all-databases"},
+				{Name: "enableCache", Value: "true"},
+				{Name: "scratchDir", Value: "/tmp"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := BackupSession{Spec: BackupSessionSpec{Params: tt.overrides}}
+			if got := session.OverrideParams(params); !slices.Equal(got, tt.want) {
+				t.Errorf("OverrideParams() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -55,9 +55,50 @@ type BackupSessionSpec struct {
 	// If this set to non-zero, Stash will create a new BackupSession if the current one fails.
 	// +optional
 	RetryLeft int32 `json:"retryLeft,omitempty"`
+
+	// Targets specifies the members of the BackupBatch to backup in this session.
+	// If it is empty, all the members are backed up.
+	// +optional
+	Targets []TargetRef `json:"targets,omitempty"`
+
+	// Tags specifies the additional tags to add to the snapshots taken in this session.
+	// Use these tags in the "keepTags" field of the retention policy to keep the snapshots indefinitely.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	// SkipRetentionPolicy indicates that the retention policy should not be applied after this session
+	// +optional
+	SkipRetentionPolicy bool `json:"skipRetentionPolicy,omitempty"`
+
+	// Params overrides the parameters of the backup Task for this session
+	// +optional
+	Params []Param `json:"params,omitempty"`
+
+	// Reason specifies why the session has been triggered
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Requestor specifies who has triggered the session
+	// +optional
+	Requestor string `json:"requestor,omitempty"`
+
+	// IgnoreRestrictions allows an on-demand session to start outside of the backup window
+	// or during a blackout period of the invoker. It can not be set for a scheduled session.
+	// +optional
+	IgnoreRestrictions bool `json:"ignoreRestrictions,omitempty"`
+
+	// Schedule specifies the name of the scheduled policy of the invoker that has triggered the session.
+	// It is empty for the sessions triggered by the default schedule or on demand.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// FailurePolicy specifies how to handle the failure of a target. It is copied from the invoker
+	// when the session is created so that the phase of the session can be calculated without the invoker.
+	// +optional
+	FailurePolicy *FailurePolicy `json:"failurePolicy,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=Pending;Skipped;Running;Succeeded;Failed;Unknown
+// +kubebuilder:validation:Enum=Pending;Skipped;Running;Succeeded;PartiallySucceeded;Failed;Unknown
 type BackupSessionPhase string
 
 const (
@@ -65,8 +106,11 @@ const (
 	BackupSessionSkipped   BackupSessionPhase = "Skipped"
 	BackupSessionRunning   BackupSessionPhase = "Running"
 	BackupSessionSucceeded BackupSessionPhase = "Succeeded"
-	BackupSessionFailed    BackupSessionPhase = "Failed"
-	BackupSessionUnknown   BackupSessionPhase = "Unknown"
+	// BackupSessionPartiallySucceeded indicates that some of the targets have failed but the failure policy
+	// of the invoker allows the session to be considered partially successful.
+	BackupSessionPartiallySucceeded BackupSessionPhase = "PartiallySucceeded"
+	BackupSessionFailed             BackupSessionPhase = "Failed"
+	BackupSessionUnknown            BackupSessionPhase = "Unknown"
 )
 
 // +kubebuilder:validation:Enum=Succeeded;Failed
@@ -77,7 +121,7 @@ const (
 	HostBackupFailed    HostBackupPhase = "Failed"
 )
 
-// +kubebuilder:validation:Enum=Pending;Succeeded;Running;Failed
+// +kubebuilder:validation:Enum=Pending;Succeeded;Running;Failed;Skipped
 type TargetPhase string
 
 const (
@@ -85,11 +129,13 @@ const (
 	TargetBackupSucceeded TargetPhase = "Succeeded"
 	TargetBackupRunning   TargetPhase = "Running"
 	TargetBackupFailed    TargetPhase = "Failed"
+	TargetBackupSkipped   TargetPhase = "Skipped"
 )
 
 type BackupSessionStatus struct {
 	// Phase indicates the overall phase of the backup process for this BackupSession. Phase will be "Succeeded" only if
-	// phase of all hosts are "Succeeded". If any of the host fail to complete backup, Phase will be "Failed".
+	// phase of all hosts are "Succeeded". If any of the host fail to complete backup, Phase will be "Failed"
+	// unless the failure policy allows partial success. In that case, Phase will be "PartiallySucceeded".
 	// +optional
 	Phase BackupSessionPhase `json:"phase,omitempty"`
 	// SessionDuration specify total time taken to complete current backup session (sum of backup duration of all targets)
@@ -139,6 +185,14 @@ type BackupTargetStatus struct {
 	// Conditions shows condition of different operations/steps of the backup process for this target
 	// +optional
 	Conditions []kmapi.Condition `json:"conditions,omitempty"`
+	// Deadline specifies the deadline of the backup of this target. It is set only if the target has its own timeOut.
+	// The target will be considered Failed if its backup does not complete within this deadline.
+	// +optional
+	Deadline *metav1.Time `json:"deadline,omitempty"`
+	// RetryLeft specifies number of retry attempts left for this target. It is set only if the target has its own retryConfig.
+	// Zero means the target has used all of its retry attempts.
+	// +optional
+	RetryLeft *int32 `json:"retryLeft,omitempty"`
 }
 
 type HostBackupStats struct {
@@ -239,6 +293,16 @@ const (
 
 	// BackupDisrupted indicates whether the backup was disrupted or not
 	BackupDisrupted = "BackupDisrupted"
+
+	// RepositoryQuotaExceeded indicates whether the backup was failed because the repository exceeded its quota
+	RepositoryQuotaExceeded = "RepositoryQuotaExceeded"
+
+	// BackupReplaced indicates whether the backup was stopped because a newer BackupSession replaced it
+	// according to the "Replace" concurrency policy
+	BackupReplaced = "BackupReplaced"
+
+	// TargetSkipped indicates whether the target was skipped without being backed up or restored
+	TargetSkipped = "TargetSkipped"
 )
 
 // =========================== Condition Reasons =======================
@@ -265,6 +329,20 @@ const (
 
 	// SkippedTakingNewBackup indicates that the backup was skipped because another backup was running or backup invoker is not ready state.
 	SkippedTakingNewBackup = "SkippedTakingNewBackup"
+	// SkippedOutsideBackupWindow indicates that the backup was skipped because it was triggered outside of the backup window.
+	SkippedOutsideBackupWindow = "SkippedOutsideBackupWindow"
+	// SkippedDuringBlackout indicates that the backup was skipped because it was triggered during a blackout period.
+	SkippedDuringBlackout = "SkippedDuringBlackout"
+	// SkippedConcurrentBackupRunning indicates that the backup was skipped because another backup of the same invoker was running and the concurrency policy is "Forbid".
+	SkippedConcurrentBackupRunning = "SkippedConcurrentBackupRunning"
+	// SkippedMissedStartingDeadline indicates that the backup was skipped because it could not start within the starting deadline.
+	SkippedMissedStartingDeadline = "SkippedMissedStartingDeadline"
+	// SkippedRepositoryQuotaExceeded indicates that the backup was skipped because the repository exceeded its quota.
+	SkippedRepositoryQuotaExceeded = "SkippedRepositoryQuotaExceeded"
+	// SkippedDueToDependencyFailure indicates that the target was skipped because a member it depends on did not succeed.
+	SkippedDueToDependencyFailure = "SkippedDueToDependencyFailure"
+	// SkippedDueToFailurePolicy indicates that the target was skipped because another member failed and the failure policy does not process the remaining members.
+	SkippedDueToFailurePolicy = "SkippedDueToFailurePolicy"
 
 	SuccessfullyCleanedBackupHistory = "SuccessfullyCleanedBackupHistory"
 	FailedToCleanBackupHistory       = "FailedToCleanBackupHistory"
@@ -281,4 +359,8 @@ const (
 	FailedToCompleteWithinDeadline = "FailedToCompleteWithinDeadline"
 
 	FailedToCompleteDueToDisruption = "FailedToCompleteDueToDisruption"
+
+	FailedDueToReplacement = "FailedDueToReplacement"
+
+	FailedDueToRepositoryQuotaExceeded = "FailedDueToRepositoryQuotaExceeded"
 )
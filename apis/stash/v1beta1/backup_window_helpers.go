@@ -0,0 +1,268 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	windowTimeFormat = "15:04"
+	// maxScheduleLookAhead limits how far the schedule is evaluated to find the next allowed backup time
+	maxScheduleLookAhead = 366 * 24 * time.Hour
+)
+
+// BackupRestriction describes why a backup is not allowed to start at a particular time
+type BackupRestriction struct {
+	// Reason is the reason of the BackupSkipped condition
+	Reason string
+	// Message is a human-readable explanation of the restriction
+	Message string
+}
+
+// GetBackupRestriction returns the restriction that prevents a backup from starting at the given time.
+// It returns nil if the backup is allowed.
+func (s BackupConfigurationSpec) GetBackupRestriction(t time.Time) (*BackupRestriction, error) {
+	r, err := s.backupRestrictions()
+	if err != nil {
+		return nil, err
+	}
+	restriction, _ := r.at(t)
+	return restriction, nil
+}
+
+// NextBackupTime returns the first scheduled time after the given time when a backup is allowed to start.
+// It considers the default schedule as well as the schedules of the scheduled policies and returns the earliest one.
+// The schedules are evaluated in the time zone of the BackupConfiguration. Whenever a scheduled time is restricted,
+// the search continues from the time the restriction is lifted (i.e. the next opening of the backup window or the end
+// of the blackout period). It returns nil if there is no schedule or no allowed time has been found within a year.
+func (s BackupConfigurationSpec) NextBackupTime(after time.Time) (*time.Time, error) {
+	schedules := s.GetSchedules()
+	if len(schedules) == 0 {
+		return nil, nil
+	}
+	r, err := s.backupRestrictions()
+	if err != nil {
+		return nil, err
+	}
+	var earliest *time.Time
+	for _, schedule := range schedules {
+		next, err := r.nextAllowed(schedule, after)
+		if err != nil {
+			return nil, err
+		}
+		if next != nil && (earliest == nil || next.Before(*earliest)) {
+			earliest = next
+		}
+	}
+	return earliest, nil
+}
+
+// backupRestrictions holds the backup window and the blackout periods of a BackupConfiguration
+// with their time zones and schedules resolved, so that they can be evaluated repeatedly.
+type backupRestrictions struct {
+	spec      BackupConfigurationSpec
+	window    *windowRange
+	blackouts []blackoutRange
+}
+
+// nextAllowed returns the first time of the schedule after the given time when a backup is allowed to start.
+// It returns nil if no allowed time has been found within a year.
+func (r *backupRestrictions) nextAllowed(schedule BackupSchedule, after time.Time) (*time.Time, error) {
+	sched, err := schedule.Parse()
+	if err != nil {
+		return nil, err
+	}
+	limit := after.Add(maxScheduleLookAhead)
+	for next := sched.Next(after); !next.IsZero() && next.Before(limit); {
+		restriction, liftedAt := r.at(next)
+		if restriction == nil {
+			return &next, nil
+		}
+		// cron schedule returns the time strictly after the given time. So, start right before the restriction is lifted.
+		next = sched.Next(liftedAt.Add(-time.Nanosecond))
+	}
+	return nil, nil
+}
+
+func (s BackupConfigurationSpec) backupRestrictions() (*backupRestrictions, error) {
+	r := &backupRestrictions{spec: s}
+	if s.BackupWindow != nil {
+		w, err := s.BackupWindow.resolve(s.TimeZone)
+		if err != nil {
+			return nil, err
+		}
+		r.window = w
+	}
+	for _, blackout := range s.Blackouts {
+		b, err := blackout.resolve(s.TimeZone)
+		if err != nil {
+			return nil, err
+		}
+		r.blackouts = append(r.blackouts, *b)
+	}
+	return r, nil
+}
+
+// at returns the restriction that prevents a backup from starting at the given time along with
+// the time when the restriction will be lifted. It returns nil if the backup is allowed.
+func (r *backupRestrictions) at(t time.Time) (*BackupRestriction, time.Time) {
+	if r.window != nil && !r.window.contains(t) {
+		w := r.spec.BackupWindow
+		return &BackupRestriction{
+			Reason: SkippedOutsideBackupWindow,
+			Message: fmt.Sprintf("Skipped taking backup at %s as it is outside of the backup window %s-%s (%s).",
+				t.UTC().Format(time.RFC3339), w.Start, w.End, r.window.loc),
+		}, r.window.nextStart(t)
+	}
+	for i := range r.blackouts {
+		end, active := r.blackouts[i].activeUntil(t)
+		if active {
+			name := r.spec.Blackouts[i].Name
+			if name == "" {
+				name = fmt.Sprintf("blackouts[%d]", i)
+			}
+			return &BackupRestriction{
+				Reason:  SkippedDuringBlackout,
+				Message: fmt.Sprintf("Skipped taking backup at %s as it is within the blackout period %q.", t.UTC().Format(time.RFC3339), name),
+			}, end
+		}
+	}
+	return nil, time.Time{}
+}
+
+// windowRange is a backup window with its time zone resolved. The start and the end are minutes of the day.
+type windowRange struct {
+	loc      *time.Location
+	from, to int
+}
+
+// resolve resolves the window in its own time zone. If it has none, the given default time zone is used.
+func (w BackupWindow) resolve(defaultTimeZone string) (*windowRange, error) {
+	loc, err := loadTimeZone(w.TimeZone, defaultTimeZone)
+	if err != nil {
+		return nil, err
+	}
+	start, err := time.Parse(windowTimeFormat, w.Start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup window start %q: %w", w.Start, err)
+	}
+	end, err := time.Parse(windowTimeFormat, w.End)
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup window end %q: %w", w.End, err)
+	}
+	return &windowRange{
+		loc:  loc,
+		from: start.Hour()*60 + start.Minute(),
+		to:   end.Hour()*60 + end.Minute(),
+	}, nil
+}
+
+func (w *windowRange) contains(t time.Time) bool {
+	t = t.In(w.loc)
+	cur := t.Hour()*60 + t.Minute()
+	if w.from <= w.to {
+		return cur >= w.from && cur < w.to
+	}
+	// the window spans over midnight
+	return cur >= w.from || cur < w.to
+}
+
+// nextStart returns the first time after t when the window opens
+func (w *windowRange) nextStart(t time.Time) time.Time {
+	t = t.In(w.loc)
+	start := time.Date(t.Year(), t.Month(), t.Day(), w.from/60, w.from%60, 0, 0, w.loc)
+	if !start.After(t) {
+		start = start.AddDate(0, 0, 1)
+	}
+	return start
+}
+
+// Contains checks whether the given time is inside the backup window
+func (w BackupWindow) Contains(t time.Time) (bool, error) {
+	r, err := w.resolve("")
+	if err != nil {
+		return false, err
+	}
+	return r.contains(t), nil
+}
+
+// blackoutRange is a blackout period with its schedule and time zone resolved
+type blackoutRange struct {
+	start, end *metav1.Time
+	sched      cron.Schedule
+	loc        *time.Location
+	duration   time.Duration
+}
+
+// resolve resolves the blackout period in its own time zone. If it has none, the given default time zone is used.
+func (b BlackoutPeriod) resolve(defaultTimeZone string) (*blackoutRange, error) {
+	r := &blackoutRange{start: b.Start, end: b.End}
+	if (b.Start != nil && b.End != nil) || b.Schedule == "" || b.Duration == nil {
+		return r, nil
+	}
+	sched, err := cron.ParseStandard(b.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := loadTimeZone(b.TimeZone, defaultTimeZone)
+	if err != nil {
+		return nil, err
+	}
+	r.sched, r.loc, r.duration = sched, loc, b.Duration.Duration
+	return r, nil
+}
+
+// activeUntil checks whether the blackout is active at the given time. It also returns the time when it ends.
+func (b *blackoutRange) activeUntil(t time.Time) (time.Time, bool) {
+	if b.start != nil && b.end != nil {
+		return b.end.Time, !t.Before(b.start.Time) && t.Before(b.end.Time)
+	}
+	if b.sched == nil {
+		return time.Time{}, false
+	}
+	// the blackout is active if it has begun within the last "duration"
+	begin := b.sched.Next(t.Add(-b.duration).In(b.loc))
+	if begin.IsZero() || begin.After(t) {
+		return time.Time{}, false
+	}
+	return begin.Add(b.duration), true
+}
+
+// Contains checks whether the given time is inside the blackout period
+func (b BlackoutPeriod) Contains(t time.Time) (bool, error) {
+	r, err := b.resolve("")
+	if err != nil {
+		return false, err
+	}
+	_, active := r.activeUntil(t)
+	return active, nil
+}
+
+// loadTimeZone loads the first specified time zone. Like the schedule, it falls back to the time zone of the cluster.
+func loadTimeZone(timeZones ...string) (*time.Location, error) {
+	for _, tz := range timeZones {
+		if tz != "" {
+			return time.LoadLocation(tz)
+		}
+	}
+	return time.Local, nil
+}
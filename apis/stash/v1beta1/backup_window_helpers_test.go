@@ -0,0 +1,170 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"gomodules.xyz/pointer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBackupConfigurationSpec_NextBackupTime(t *testing.T) {
+	now := time.Date(2024, time.March, 4, 10, 30, 0, 0, time.UTC) // Monday
+	at := func(day, hour int) time.Time {
+		return time.Date(2024, time.March, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name string
+		spec BackupConfigurationSpec
+		want *time.Time
+	}{
+		{
+			name: "No restriction",
+			spec: BackupConfigurationSpec{Schedule: "0 * * * *", TimeZone: "UTC"},
+			want: pointer.TimeP(at(4, 11)),
+		},
+		{
+			name: "Backup window spanning over midnight",
+			spec: BackupConfigurationSpec{
+				Schedule:     "0 * * * *",
+				TimeZone:     "UTC",
+				BackupWindow: &BackupWindow{Start: "22:00", End: "04:00"},
+			},
+			want: pointer.TimeP(at(4, 22)),
+		},
+		{
+			name: "Backup window in a different time zone",
+			spec: BackupConfigurationSpec{
+				Schedule:     "0 * * * *",
+				TimeZone:     "UTC",
+				BackupWindow: &BackupWindow{Start: "01:00", End: "02:00", TimeZone: "Asia/Dhaka"}, // UTC+6
+			},
+			want: pointer.TimeP(at(4, 19)),
+		},
+		{
+			name: "Backup window in the time zone of the schedule",
+			spec: BackupConfigurationSpec{
+				Schedule:     "0 * * * *",
+				TimeZone:     "Asia/Dhaka", // UTC+6
+				BackupWindow: &BackupWindow{Start: "01:00", End: "02:00"},
+			},
+			want: pointer.TimeP(at(4, 19)),
+		},
+		{
+			name: "Fixed blackout period",
+			spec: BackupConfigurationSpec{
+				Schedule: "0 0 * * *",
+				TimeZone: "UTC",
+				Blackouts: []BlackoutPeriod{
+					{
+						Start: &metav1.Time{Time: at(4, 12)},
+						End:   &metav1.Time{Time: at(7, 0)},
+					},
+				},
+			},
+			want: pointer.TimeP(at(7, 0)),
+		},
+		{
+			name: "Recurring blackout period",
+			spec: BackupConfigurationSpec{
+				Schedule: "0 */6 * * *",
+				TimeZone: "UTC",
+				Blackouts: []BlackoutPeriod{
+					{
+						Schedule: "0 12 * * *",
+						Duration: &metav1.Duration{Duration: 7 * time.Hour},
+					},
+				},
+			},
+			want: pointer.TimeP(at(5, 0)),
+		},
+		{
+			name: "Schedule in a different time zone",
+			spec: BackupConfigurationSpec{
+				Schedule: "0 2 * * *",
+				TimeZone: "Asia/Dhaka", // UTC+6
+			},
+			want: pointer.TimeP(at(4, 20)),
+		},
+		{
+			name: "Frequent schedule during a long blackout period",
+			spec: BackupConfigurationSpec{
+				Schedule: "* * * * *",
+				TimeZone: "UTC",
+				Blackouts: []BlackoutPeriod{
+					{
+						Start: &metav1.Time{Time: at(1, 0)},
+						End:   &metav1.Time{Time: at(31, 0)},
+					},
+				},
+			},
+			want: pointer.TimeP(at(31, 0)),
+		},
+		{
+			name: "Schedule never inside the backup window",
+			spec: BackupConfigurationSpec{
+				Schedule:     "0 12 * * *",
+				TimeZone:     "UTC",
+				BackupWindow: &BackupWindow{Start: "22:00", End: "04:00"},
+			},
+			want: nil,
+		},
+		{
+			name: "Only scheduled policies",
+			spec: BackupConfigurationSpec{
+				TimeZone: "UTC",
+				Schedules: []ScheduledPolicy{
+					{Name: "weekly", Schedule: "0 1 * * 0"},
+					{Name: "daily", Schedule: "0 1 * * *"},
+				},
+			},
+			want: pointer.TimeP(at(5, 1)),
+		},
+		{
+			name: "Earliest allowed time among the default schedule and the scheduled policies",
+			spec: BackupConfigurationSpec{
+				Schedule: "0 12 * * *",
+				TimeZone: "UTC",
+				Schedules: []ScheduledPolicy{
+					{Name: "nightly", Schedule: "0 23 * * *"},
+				},
+				BackupWindow: &BackupWindow{Start: "22:00", End: "04:00"},
+			},
+			want: pointer.TimeP(at(4, 23)),
+		},
+		{
+			name: "No schedule",
+			spec: BackupConfigurationSpec{},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.spec.NextBackupTime(now)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && !got.Equal(*tt.want)) {
+				t.Errorf("NextBackupTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// GetType returns the type of the failure policy. The type defaults to FailFast. It returns an empty type if there is
+// no failure policy, in which case all the members are processed and the session fails if any of them fails.
+func (p *FailurePolicy) GetType() FailurePolicyType {
+	if p == nil {
+		return ""
+	}
+	if p.Type == "" {
+		return FailFast
+	}
+	return p.Type
+}
+
+// StopOnFailure returns true if the members that have not started yet must not be processed once a member fails.
+func (p *FailurePolicy) StopOnFailure() bool {
+	return p.GetType() == FailFast
+}
+
+// AllowsPartialSuccess returns true if a session whose members have completed with the given number of
+// successful members but at least one failed member should be considered PartiallySucceeded instead of Failed.
+func (p *FailurePolicy) AllowsPartialSuccess(succeeded int) bool {
+	switch p.GetType() {
+	case ContinueOnError:
+		return succeeded > 0
+	case MinSuccessful:
+		return p.MinSuccessful != nil && succeeded >= int(*p.MinSuccessful)
+	default:
+		return false
+	}
+}
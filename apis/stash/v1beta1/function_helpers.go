@@ -25,3 +25,8 @@ import (
 func (Function) CustomResourceDefinition() *apiextensions.CustomResourceDefinition {
 	return crds.MustCustomResourceDefinition(SchemeGroupVersion.WithResource(ResourcePluralFunction))
 }
+
+// Default sets the default values of a Function. The fields of a Function are passed to the container as they are
+// and the container runtime applies its own defaults. So, nothing is set here. It exists so that every kind of
+// this group can be defaulted the same way.
+func (f *Function) Default() {}
@@ -0,0 +1,128 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"slices"
+)
+
+// MemberDependencies returns the indices of the members each member of the BackupBatch depends on.
+// The dependencies that do not refer to any member are ignored.
+func (b BackupBatch) MemberDependencies() [][]int {
+	refs, dependsOn := b.Spec.memberDependencies()
+	return dependencyGraph(refs, dependsOn, b.Namespace)
+}
+
+// MemberDependencies returns the indices of the members each member of the RestoreBatch depends on.
+// The dependencies that do not refer to any member are ignored.
+func (r RestoreBatch) MemberDependencies() [][]int {
+	refs, dependsOn := r.Spec.memberDependencies()
+	return dependencyGraph(refs, dependsOn, r.Namespace)
+}
+
+// memberDependencies returns the target and the dependencies of each member
+func (s BackupBatchSpec) memberDependencies() ([]*TargetRef, [][]TargetRef) {
+	refs := make([]*TargetRef, len(s.Members))
+	dependsOn := make([][]TargetRef, len(s.Members))
+	for i, m := range s.Members {
+		if m.Target != nil {
+			refs[i] = &m.Target.Ref
+		}
+		dependsOn[i] = m.DependsOn
+	}
+	return refs, dependsOn
+}
+
+// memberDependencies returns the target and the dependencies of each member
+func (s RestoreBatchSpec) memberDependencies() ([]*TargetRef, [][]TargetRef) {
+	refs := make([]*TargetRef, len(s.Members))
+	dependsOn := make([][]TargetRef, len(s.Members))
+	for i, m := range s.Members {
+		if m.Target != nil {
+			refs[i] = &m.Target.Ref
+		}
+		dependsOn[i] = m.DependsOn
+	}
+	return refs, dependsOn
+}
+
+// HasDependencies returns true if any member of the dependency graph depends on another member
+func HasDependencies(graph [][]int) bool {
+	return slices.ContainsFunc(graph, func(deps []int) bool { return len(deps) > 0 })
+}
+
+// dependencyGraph resolves the dependencies of the members into the indices of the members they refer to.
+// refs holds the target of each member. A nil target can not be referred. The dependencies that do not refer
+// to any member are ignored.
+func dependencyGraph(refs []*TargetRef, dependsOn [][]TargetRef, namespace string) [][]int {
+	index := make(map[string]int, len(refs))
+	for i, ref := range refs {
+		if ref != nil {
+			index[targetKey(*ref, namespace)] = i
+		}
+	}
+	graph := make([][]int, len(refs))
+	for i, deps := range dependsOn {
+		for _, dep := range deps {
+			if k, ok := index[targetKey(dep, namespace)]; ok {
+				graph[i] = append(graph[i], k)
+			}
+		}
+	}
+	return graph
+}
+
+// findCycle returns the members that form a cycle in the dependency graph in the order of their dependencies.
+// The first member is repeated at the end. It returns nil if the graph does not have any cycle.
+func findCycle(graph [][]int) []int {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(graph))
+	var path []int
+
+	var visit func(i int) []int
+	visit = func(i int) []int {
+		state[i] = visiting
+		path = append(path, i)
+		for _, dep := range graph[i] {
+			switch state[dep] {
+			case visiting:
+				start := slices.Index(path, dep)
+				return append(slices.Clone(path[start:]), dep)
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = visited
+		return nil
+	}
+
+	for i := range graph {
+		if state[i] == unvisited {
+			if cycle := visit(i); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
@@ -456,22 +456,32 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupConfigurationTemplateSpec": schema_apimachinery_apis_stash_v1beta1_BackupConfigurationTemplateSpec(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupHooks":                     schema_apimachinery_apis_stash_v1beta1_BackupHooks(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupInvokerRef":                schema_apimachinery_apis_stash_v1beta1_BackupInvokerRef(ref),
+		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupRestriction":               schema_apimachinery_apis_stash_v1beta1_BackupRestriction(ref),
+		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupSchedule":                  schema_apimachinery_apis_stash_v1beta1_BackupSchedule(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupSession":                   schema_apimachinery_apis_stash_v1beta1_BackupSession(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupSessionList":               schema_apimachinery_apis_stash_v1beta1_BackupSessionList(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupSessionSpec":               schema_apimachinery_apis_stash_v1beta1_BackupSessionSpec(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupSessionStatus":             schema_apimachinery_apis_stash_v1beta1_BackupSessionStatus(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupTarget":                    schema_apimachinery_apis_stash_v1beta1_BackupTarget(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupTargetStatus":              schema_apimachinery_apis_stash_v1beta1_BackupTargetStatus(ref),
+		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupWindow":                    schema_apimachinery_apis_stash_v1beta1_BackupWindow(ref),
+		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.BlackoutPeriod":                  schema_apimachinery_apis_stash_v1beta1_BlackoutPeriod(ref),
+		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.BlueprintParam":                  schema_apimachinery_apis_stash_v1beta1_BlueprintParam(ref),
+		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.BlueprintSelector":               schema_apimachinery_apis_stash_v1beta1_BlueprintSelector(ref),
+		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.BlueprintTarget":                 schema_apimachinery_apis_stash_v1beta1_BlueprintTarget(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.EmptyDirSettings":                schema_apimachinery_apis_stash_v1beta1_EmptyDirSettings(ref),
+		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.FailurePolicy":                   schema_apimachinery_apis_stash_v1beta1_FailurePolicy(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.FileStats":                       schema_apimachinery_apis_stash_v1beta1_FileStats(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.Function":                        schema_apimachinery_apis_stash_v1beta1_Function(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.FunctionList":                    schema_apimachinery_apis_stash_v1beta1_FunctionList(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.FunctionRef":                     schema_apimachinery_apis_stash_v1beta1_FunctionRef(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.FunctionSpec":                    schema_apimachinery_apis_stash_v1beta1_FunctionSpec(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.HostBackupStats":                 schema_apimachinery_apis_stash_v1beta1_HostBackupStats(ref),
+		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.HostRestorePlan":                 schema_apimachinery_apis_stash_v1beta1_HostRestorePlan(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.HostRestoreStats":                schema_apimachinery_apis_stash_v1beta1_HostRestoreStats(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.MemberConditions":                schema_apimachinery_apis_stash_v1beta1_MemberConditions(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.Param":                           schema_apimachinery_apis_stash_v1beta1_Param(ref),
+		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.PathRewrite":                     schema_apimachinery_apis_stash_v1beta1_PathRewrite(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.PostBackupHook":                  schema_apimachinery_apis_stash_v1beta1_PostBackupHook(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.PostRestoreHook":                 schema_apimachinery_apis_stash_v1beta1_PostRestoreHook(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreBatch":                    schema_apimachinery_apis_stash_v1beta1_RestoreBatch(ref),
@@ -479,7 +489,10 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreBatchSpec":                schema_apimachinery_apis_stash_v1beta1_RestoreBatchSpec(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreBatchStatus":              schema_apimachinery_apis_stash_v1beta1_RestoreBatchStatus(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreHooks":                    schema_apimachinery_apis_stash_v1beta1_RestoreHooks(ref),
+		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreMapping":                  schema_apimachinery_apis_stash_v1beta1_RestoreMapping(ref),
+		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreMappingRef":               schema_apimachinery_apis_stash_v1beta1_RestoreMappingRef(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreMemberStatus":             schema_apimachinery_apis_stash_v1beta1_RestoreMemberStatus(ref),
+		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestorePlan":                     schema_apimachinery_apis_stash_v1beta1_RestorePlan(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreSession":                  schema_apimachinery_apis_stash_v1beta1_RestoreSession(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreSessionList":              schema_apimachinery_apis_stash_v1beta1_RestoreSessionList(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreSessionSpec":              schema_apimachinery_apis_stash_v1beta1_RestoreSessionSpec(ref),
@@ -488,9 +501,12 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreTargetSpec":               schema_apimachinery_apis_stash_v1beta1_RestoreTargetSpec(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.RetryConfig":                     schema_apimachinery_apis_stash_v1beta1_RetryConfig(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.Rule":                            schema_apimachinery_apis_stash_v1beta1_Rule(ref),
+		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.ScheduledPolicy":                 schema_apimachinery_apis_stash_v1beta1_ScheduledPolicy(ref),
+		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.SnapshotRestorePlan":             schema_apimachinery_apis_stash_v1beta1_SnapshotRestorePlan(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.SnapshotStats":                   schema_apimachinery_apis_stash_v1beta1_SnapshotStats(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.Summary":                         schema_apimachinery_apis_stash_v1beta1_Summary(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.TargetRef":                       schema_apimachinery_apis_stash_v1beta1_TargetRef(ref),
+		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.TargetRestorePlan":               schema_apimachinery_apis_stash_v1beta1_TargetRestorePlan(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.TargetStatus":                    schema_apimachinery_apis_stash_v1beta1_TargetStatus(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.Task":                            schema_apimachinery_apis_stash_v1beta1_Task(ref),
 		"stash.appscode.dev/apimachinery/apis/stash/v1beta1.TaskList":                        schema_apimachinery_apis_stash_v1beta1_TaskList(ref),
@@ -23433,6 +23449,13 @@ func schema_apimachinery_apis_stash_v1beta1_BackupBatchSpec(ref common.Reference
 							Format:      "",
 						},
 					},
+					"timeZone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeZone is the IANA name of the time zone (i.e. \"Asia/Dhaka\") in which the schedule is evaluated. If it is not specified, the schedule is evaluated in the time zone of the cluster.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"runtimeSettings": {
 						SchemaProps: spec.SchemaProps{
 							Description: "RuntimeSettings allow to specify Resources, NodeSelector, Affinity, Toleration, ReadinessProbe etc, and used to create service account for CronJob.",
@@ -23483,7 +23506,7 @@ func schema_apimachinery_apis_stash_v1beta1_BackupBatchSpec(ref common.Reference
 					},
 					"executionOrder": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ExecutionOrder indicate whether to backup the members in the sequential order as they appear in the members list. The default value is \"Parallel\" which means the members will be backed up in parallel.",
+							Description: "ExecutionOrder indicate whether to backup the members in the sequential order as they appear in the members list. The default value is \"Parallel\" which means the members will be backed up in parallel. If any member depends on the other members, the members are started one by one as their dependencies succeed, regardless of this field.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -23500,12 +23523,31 @@ func schema_apimachinery_apis_stash_v1beta1_BackupBatchSpec(ref common.Reference
 							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.RetryConfig"),
 						},
 					},
+					"failurePolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailurePolicy specifies how to handle the failure of a member. By default, all the members are backed up and the session fails if any member fails.",
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.FailurePolicy"),
+						},
+					},
+					"concurrencyPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConcurrencyPolicy specifies how to treat a new BackupSession when a previous BackupSession is still running. Default value is \"Forbid\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"startingDeadline": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartingDeadline specifies the maximum delay after the creation of a BackupSession within which the backup must start. BackupSessions that could not start within this time will be skipped. By default, there is no deadline.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
 				},
 				Required: []string{"retentionPolicy"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "kmodules.xyz/client-go/api/v1.ObjectReference", "kmodules.xyz/offshoot-api/api/v1.RuntimeSettings", "stash.appscode.dev/apimachinery/apis/stash/v1alpha1.RetentionPolicy", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupConfigurationTemplateSpec", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupHooks", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RetryConfig"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "kmodules.xyz/client-go/api/v1.ObjectReference", "kmodules.xyz/offshoot-api/api/v1.RuntimeSettings", "stash.appscode.dev/apimachinery/apis/stash/v1alpha1.RetentionPolicy", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupConfigurationTemplateSpec", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupHooks", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.FailurePolicy", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RetryConfig"},
 	}
 }
 
@@ -23678,6 +23720,24 @@ func schema_apimachinery_apis_stash_v1beta1_BackupBlueprintSpec(ref common.Refer
 							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1alpha1.UsagePolicy"),
 						},
 					},
+					"quota": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Quota specifies the limits of the storage usage of this Repository. The quota is evaluated after each backup session.",
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1alpha1.RepositoryQuota"),
+						},
+					},
+					"growthAlert": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GrowthAlert specifies the thresholds of the repository growth in a single backup session.",
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1alpha1.GrowthAlert"),
+						},
+					},
+					"immutability": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Immutability specifies the object lock settings of the backend. Stash will not remove the snapshots that are younger than the lock period.",
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1alpha1.ImmutabilityPolicy"),
+						},
+					},
 					"backupNamespace": {
 						SchemaProps: spec.SchemaProps{
 							Description: "BackupNamespace specifies the namespace where the backup resources (i.e. BackupConfiguration, BackupSession, Job, Repository etc.) will be created. If you don't provide this field, then the backup resources will be created in the target namespace.",
@@ -23699,6 +23759,13 @@ func schema_apimachinery_apis_stash_v1beta1_BackupBlueprintSpec(ref common.Refer
 							Format:      "",
 						},
 					},
+					"timeZone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeZone is the IANA name of the time zone (i.e. \"Asia/Dhaka\") in which the schedule is evaluated. If it is not specified, the schedule is evaluated in the time zone of the cluster.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"task": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Task specify the Task crd that specifies steps for backup process",
@@ -23758,12 +23825,32 @@ func schema_apimachinery_apis_stash_v1beta1_BackupBlueprintSpec(ref common.Refer
 							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.RetryConfig"),
 						},
 					},
+					"params": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Params declares the template parameters of the blueprint. They can be referred as \"${<name>}\" in the blueprint. A workload supplies the value of a parameter using \"params.stash.appscode.com/<name>\" annotation.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.BlueprintParam"),
+									},
+								},
+							},
+						},
+					},
+					"selector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Selector selects the objects the blueprint is applied to without the \"stash.appscode.com/backup-blueprint\" annotation. If it is not specified, the blueprint is applied only to the objects that refer to it using the annotation.",
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.BlueprintSelector"),
+						},
+					},
 				},
 				Required: []string{"retentionPolicy"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "kmodules.xyz/objectstore-api/api/v1.Backend", "kmodules.xyz/offshoot-api/api/v1.PersistentVolumeClaim", "kmodules.xyz/offshoot-api/api/v1.RuntimeSettings", "stash.appscode.dev/apimachinery/apis/stash/v1alpha1.RetentionPolicy", "stash.appscode.dev/apimachinery/apis/stash/v1alpha1.UsagePolicy", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupHooks", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.EmptyDirSettings", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RetryConfig", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.TaskRef"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "kmodules.xyz/objectstore-api/api/v1.Backend", "kmodules.xyz/offshoot-api/api/v1.PersistentVolumeClaim", "kmodules.xyz/offshoot-api/api/v1.RuntimeSettings", "stash.appscode.dev/apimachinery/apis/stash/v1alpha1.GrowthAlert", "stash.appscode.dev/apimachinery/apis/stash/v1alpha1.ImmutabilityPolicy", "stash.appscode.dev/apimachinery/apis/stash/v1alpha1.RepositoryQuota", "stash.appscode.dev/apimachinery/apis/stash/v1alpha1.RetentionPolicy", "stash.appscode.dev/apimachinery/apis/stash/v1alpha1.UsagePolicy", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupHooks", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.BlueprintParam", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.BlueprintSelector", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.EmptyDirSettings", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RetryConfig", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.TaskRef"},
 	}
 }
 
@@ -23905,6 +23992,32 @@ func schema_apimachinery_apis_stash_v1beta1_BackupConfigurationSpec(ref common.R
 							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupHooks"),
 						},
 					},
+					"dependsOn": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DependsOn specifies the targets of the other members of the BackupBatch that must complete their backup before this member starts. The members without any dependency are backed up in parallel. If any member specifies its dependencies, the executionOrder of the BackupBatch is ignored. It is only used for the members of a BackupBatch.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.TargetRef"),
+									},
+								},
+							},
+						},
+					},
+					"timeOut": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeOut specifies the maximum duration of backup. BackupSession will be considered Failed if backup does not complete within this time limit. By default, Stash don't set any timeout for backup. For a member of a BackupBatch, it specifies the maximum duration of the backup of this member only.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"retryConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetryConfig specify a configuration for retry a backup if it fails. By default, Stash does not retry any failed backup. For a member of a BackupBatch, only the backup of this member is retried when it fails without re-running the backup of the other members.",
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.RetryConfig"),
+						},
+					},
 					"schedule": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Schedule specifies the schedule for invoking backup sessions",
@@ -23912,6 +24025,13 @@ func schema_apimachinery_apis_stash_v1beta1_BackupConfigurationSpec(ref common.R
 							Format:      "",
 						},
 					},
+					"timeZone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeZone is the IANA name of the time zone (i.e. \"Asia/Dhaka\") in which the schedule is evaluated. If it is not specified, the schedule is evaluated in the time zone of the cluster.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"driver": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Driver indicates the name of the agent to use to backup the target. Supported values are \"Restic\", \"VolumeSnapshotter\". Default value is \"Restic\".",
@@ -23947,16 +24067,51 @@ func schema_apimachinery_apis_stash_v1beta1_BackupConfigurationSpec(ref common.R
 							Format:      "int32",
 						},
 					},
-					"timeOut": {
+					"backupWindow": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TimeOut specifies the maximum duration of backup. BackupSession will be considered Failed if backup does not complete within this time limit. By default, Stash don't set any timeout for backup.",
+							Description: "BackupWindow specifies the time of the day when a backup is allowed to start. BackupSessions triggered outside of the window will be skipped.",
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupWindow"),
+						},
+					},
+					"blackouts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Blackouts specifies the periods when no backup is allowed to start. BackupSessions triggered during a blackout period will be skipped.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.BlackoutPeriod"),
+									},
+								},
+							},
+						},
+					},
+					"concurrencyPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConcurrencyPolicy specifies how to treat a new BackupSession when a previous BackupSession is still running. Default value is \"Forbid\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"startingDeadline": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartingDeadline specifies the maximum delay after the creation of a BackupSession within which the backup must start. BackupSessions that could not start within this time will be skipped. By default, there is no deadline.",
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
-					"retryConfig": {
+					"schedules": {
 						SchemaProps: spec.SchemaProps{
-							Description: "RetryConfig specify a configuration for retry a backup if it fails. By default, Stash does not retry any failed backup.",
-							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.RetryConfig"),
+							Description: "Schedules specifies additional schedules of the backup. Each of them tags its snapshots and can use its own repository and retention policy, i.e. hourly backup in a local repository and weekly backup in an offsite repository. The schedules are evaluated in the time zone specified in the timeZone field.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.ScheduledPolicy"),
+									},
+								},
+							},
 						},
 					},
 				},
@@ -23964,7 +24119,7 @@ func schema_apimachinery_apis_stash_v1beta1_BackupConfigurationSpec(ref common.R
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "kmodules.xyz/client-go/api/v1.ObjectReference", "kmodules.xyz/offshoot-api/api/v1.PersistentVolumeClaim", "kmodules.xyz/offshoot-api/api/v1.RuntimeSettings", "stash.appscode.dev/apimachinery/apis/stash/v1alpha1.RetentionPolicy", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupHooks", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupTarget", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.EmptyDirSettings", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RetryConfig", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.TaskRef"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "kmodules.xyz/client-go/api/v1.ObjectReference", "kmodules.xyz/offshoot-api/api/v1.PersistentVolumeClaim", "kmodules.xyz/offshoot-api/api/v1.RuntimeSettings", "stash.appscode.dev/apimachinery/apis/stash/v1alpha1.RetentionPolicy", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupHooks", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupTarget", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupWindow", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.BlackoutPeriod", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.EmptyDirSettings", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RetryConfig", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.ScheduledPolicy", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.TargetRef", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.TaskRef"},
 	}
 }
 
@@ -24055,11 +24210,37 @@ func schema_apimachinery_apis_stash_v1beta1_BackupConfigurationTemplateSpec(ref
 							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupHooks"),
 						},
 					},
+					"dependsOn": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DependsOn specifies the targets of the other members of the BackupBatch that must complete their backup before this member starts. The members without any dependency are backed up in parallel. If any member specifies its dependencies, the executionOrder of the BackupBatch is ignored. It is only used for the members of a BackupBatch.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.TargetRef"),
+									},
+								},
+							},
+						},
+					},
+					"timeOut": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeOut specifies the maximum duration of backup. BackupSession will be considered Failed if backup does not complete within this time limit. By default, Stash don't set any timeout for backup. For a member of a BackupBatch, it specifies the maximum duration of the backup of this member only.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"retryConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetryConfig specify a configuration for retry a backup if it fails. By default, Stash does not retry any failed backup. For a member of a BackupBatch, only the backup of this member is retried when it fails without re-running the backup of the other members.",
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.RetryConfig"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kmodules.xyz/offshoot-api/api/v1.PersistentVolumeClaim", "kmodules.xyz/offshoot-api/api/v1.RuntimeSettings", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupHooks", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupTarget", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.EmptyDirSettings", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.TaskRef"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "kmodules.xyz/offshoot-api/api/v1.PersistentVolumeClaim", "kmodules.xyz/offshoot-api/api/v1.RuntimeSettings", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupHooks", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupTarget", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.EmptyDirSettings", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RetryConfig", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.TargetRef", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.TaskRef"},
 	}
 }
 
@@ -24127,6 +24308,74 @@ func schema_apimachinery_apis_stash_v1beta1_BackupInvokerRef(ref common.Referenc
 	}
 }
 
+func schema_apimachinery_apis_stash_v1beta1_BackupRestriction(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BackupRestriction describes why a backup is not allowed to start at a particular time",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"Reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason is the reason of the BackupSkipped condition",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"Message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is a human-readable explanation of the restriction",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"Reason", "Message"},
+			},
+		},
+	}
+}
+
+func schema_apimachinery_apis_stash_v1beta1_BackupSchedule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BackupSchedule is a cron schedule along with the time zone it is evaluated in",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"Name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the scheduled policy. It is empty for the default schedule.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"Cron": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cron is the cron expression of the schedule",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"TimeZone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeZone is the IANA name of the time zone of the schedule. Empty means the time zone of the cluster (i.e. the local time zone of the Stash operator).",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"Name", "Cron", "TimeZone"},
+			},
+		},
+	}
+}
+
 func schema_apimachinery_apis_stash_v1beta1_BackupSession(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -24240,11 +24489,95 @@ func schema_apimachinery_apis_stash_v1beta1_BackupSessionSpec(ref common.Referen
 							Format:      "int32",
 						},
 					},
+					"targets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Targets specifies the members of the BackupBatch to backup in this session. If it is empty, all the members are backed up.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.TargetRef"),
+									},
+								},
+							},
+						},
+					},
+					"tags": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tags specifies the additional tags to add to the snapshots taken in this session. Use these tags in the \"keepTags\" field of the retention policy to keep the snapshots indefinitely.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"skipRetentionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SkipRetentionPolicy indicates that the retention policy should not be applied after this session",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"params": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Params overrides the parameters of the backup Task for this session",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.Param"),
+									},
+								},
+							},
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason specifies why the session has been triggered",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"requestor": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Requestor specifies who has triggered the session",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"ignoreRestrictions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IgnoreRestrictions allows an on-demand session to start outside of the backup window or during a blackout period of the invoker. It can not be set for a scheduled session.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"schedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Schedule specifies the name of the scheduled policy of the invoker that has triggered the session. It is empty for the sessions triggered by the default schedule or on demand.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"failurePolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailurePolicy specifies how to handle the failure of a target. It is copied from the invoker when the session is created so that the phase of the session can be calculated without the invoker.",
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.FailurePolicy"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupInvokerRef"},
+			"stash.appscode.dev/apimachinery/apis/stash/v1beta1.BackupInvokerRef", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.FailurePolicy", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.Param", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.TargetRef"},
 	}
 }
 
@@ -24256,7 +24589,7 @@ func schema_apimachinery_apis_stash_v1beta1_BackupSessionStatus(ref common.Refer
 				Properties: map[string]spec.Schema{
 					"phase": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Phase indicates the overall phase of the backup process for this BackupSession. Phase will be \"Succeeded\" only if phase of all hosts are \"Succeeded\". If any of the host fail to complete backup, Phase will be \"Failed\".",
+							Description: "Phase indicates the overall phase of the backup process for this BackupSession. Phase will be \"Succeeded\" only if phase of all hosts are \"Succeeded\". If any of the host fail to complete backup, Phase will be \"Failed\" unless the failure policy allows partial success. In that case, Phase will be \"PartiallySucceeded\".",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -24509,11 +24842,246 @@ func schema_apimachinery_apis_stash_v1beta1_BackupTargetStatus(ref common.Refere
 							},
 						},
 					},
+					"deadline": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Deadline specifies the deadline of the backup of this target. It is set only if the target has its own timeOut. The target will be considered Failed if its backup does not complete within this deadline.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"retryLeft": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetryLeft specifies number of retry attempts left for this target. It is set only if the target has its own retryConfig. Zero means the target has used all of its retry attempts.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kmodules.xyz/client-go/api/v1.Condition", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.HostBackupStats", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.TargetRef"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "kmodules.xyz/client-go/api/v1.Condition", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.HostBackupStats", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.TargetRef"},
+	}
+}
+
+func schema_apimachinery_apis_stash_v1beta1_BackupWindow(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BackupWindow specifies a daily time window when a backup is allowed to start",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"start": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Start is the beginning of the window in \"HH:MM\" (24-hour) format",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"end": {
+						SchemaProps: spec.SchemaProps{
+							Description: "End is the end of the window in \"HH:MM\" (24-hour) format. If End is earlier than Start, the window spans over midnight.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"timeZone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeZone specifies the IANA time zone (i.e. \"Asia/Dhaka\") of the Start and End time. If it is not specified, the time zone of the schedule is used, which defaults to the time zone of the cluster.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"start", "end"},
+			},
+		},
+	}
+}
+
+func schema_apimachinery_apis_stash_v1beta1_BlackoutPeriod(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BlackoutPeriod specifies a period when no backup is allowed to start. Specify either Start and End for a fixed date range, or Schedule and Duration for a recurring period.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is an identifier for the blackout period. It is used in the skip message.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"start": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Start is the beginning of a fixed blackout period",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"end": {
+						SchemaProps: spec.SchemaProps{
+							Description: "End is the end of a fixed blackout period",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"schedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Schedule is a cron expression specifying when a recurring blackout period begins",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Duration specifies how long a recurring blackout period lasts",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"timeZone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeZone specifies the IANA time zone of the Schedule. If it is not specified, the time zone of the backup schedule is used, which defaults to the time zone of the cluster.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_apimachinery_apis_stash_v1beta1_BlueprintParam(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BlueprintParam declares a template parameter of a BackupBlueprint",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the parameter. It must not conflict with the built-in variables i.e. \"TARGET_NAME\".",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"default": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Default specifies the value to use when the workload does not supply any value for the parameter.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"required": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Required specifies whether the workload must supply a value for the parameter.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"pattern": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Pattern specifies a regular expression that the value of the parameter must match.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_apimachinery_apis_stash_v1beta1_BlueprintSelector(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BlueprintSelector selects the objects a BackupBlueprint is applied to",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"namespaceSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NamespaceSelector selects the namespaces of the objects. If it is not specified, the objects of all namespaces are selected.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"objectSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObjectSelector selects the objects by their labels. If it is not specified, all objects of the selected kinds are selected.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"kinds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kinds specifies the kinds of the objects i.e. \"Deployment\", \"PersistentVolumeClaim\". If it is not specified, the objects of all kinds are selected.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"priority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Priority is used to choose a blueprint when multiple blueprints select the same object. The blueprint with the highest priority is applied. The object is not backed up if multiple blueprints with the highest priority select it. Default value is 0.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+	}
+}
+
+func schema_apimachinery_apis_stash_v1beta1_BlueprintTarget(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BlueprintTarget holds the information of the workload a BackupBlueprint is applied to",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"Ref": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Ref refers to the workload",
+							Default:     map[string]interface{}{},
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.TargetRef"),
+						},
+					},
+					"Annotations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Annotations are the annotations of the workload. The values of the template parameters, the schedule, the target paths and the volume mounts are read from them.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"Ref", "Annotations"},
+			},
+		},
+		Dependencies: []string{
+			"stash.appscode.dev/apimachinery/apis/stash/v1beta1.TargetRef"},
 	}
 }
 
@@ -24549,6 +25117,32 @@ func schema_apimachinery_apis_stash_v1beta1_EmptyDirSettings(ref common.Referenc
 	}
 }
 
+func schema_apimachinery_apis_stash_v1beta1_FailurePolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type specifies how to handle the failure of a member of a batch. Supported values are \"FailFast\", \"ContinueOnError\" and \"MinSuccessful\". Default value is \"FailFast\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"minSuccessful": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MinSuccessful specifies the minimum number of members that must succeed for the session to be considered PartiallySucceeded. It is used only when the type is \"MinSuccessful\".",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_apimachinery_apis_stash_v1beta1_FileStats(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -24898,6 +25492,62 @@ func schema_apimachinery_apis_stash_v1beta1_HostBackupStats(ref common.Reference
 	}
 }
 
+func schema_apimachinery_apis_stash_v1beta1_HostRestorePlan(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"hostname": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hostname indicates the name of the host that would be restored",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"sourceHost": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SourceHost indicates the name of the host whose backed up data would be restored",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"snapshots": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Snapshots shows the snapshots that would be restored",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.SnapshotRestorePlan"),
+									},
+								},
+							},
+						},
+					},
+					"totalSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TotalSize indicates the total size of the data that would be restored",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"error": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Error indicates why the restore of this host could not be planned",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"stash.appscode.dev/apimachinery/apis/stash/v1beta1.SnapshotRestorePlan"},
+	}
+}
+
 func schema_apimachinery_apis_stash_v1beta1_HostRestoreStats(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -25002,6 +25652,36 @@ func schema_apimachinery_apis_stash_v1beta1_Param(ref common.ReferenceCallback)
 	}
 }
 
+func schema_apimachinery_apis_stash_v1beta1_PathRewrite(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PathRewrite restores the data backed up under the From path into the To path",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"from": {
+						SchemaProps: spec.SchemaProps{
+							Description: "From is the absolute path of the backed up data",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"to": {
+						SchemaProps: spec.SchemaProps{
+							Description: "To is the absolute path where the data should be restored",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"from", "to"},
+			},
+		},
+	}
+}
+
 func schema_apimachinery_apis_stash_v1beta1_PostBackupHook(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -25041,7 +25721,7 @@ func schema_apimachinery_apis_stash_v1beta1_PostBackupHook(ref common.ReferenceC
 					},
 					"executionPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ExecutionPolicy specifies when to execute a hook. Supported values are \"Always\", \"OnFailure\", \"OnSuccess\", \"OnFinalRetryFailure\". Default value: \"Always\".",
+							Description: "ExecutionPolicy specifies when to execute a hook. Supported values are \"Always\", \"OnFailure\", \"OnSuccess\", \"OnPartialSuccess\", \"OnFinalRetryFailure\". \"OnFailure\" also executes the hook when the session has partially succeeded as some targets have failed. Default value: \"Always\".",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -25093,7 +25773,7 @@ func schema_apimachinery_apis_stash_v1beta1_PostRestoreHook(ref common.Reference
 					},
 					"executionPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ExecutionPolicy specifies when to execute a hook. Supported values are \"Always\", \"OnFailure\", \"OnSuccess\". Default value: \"Always\".",
+							Description: "ExecutionPolicy specifies when to execute a hook. Supported values are \"Always\", \"OnFailure\", \"OnSuccess\", \"OnPartialSuccess\". \"OnFailure\" also executes the hook when the restore has partially succeeded as some members have failed. Default value: \"Always\".",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -25235,11 +25915,17 @@ func schema_apimachinery_apis_stash_v1beta1_RestoreBatchSpec(ref common.Referenc
 					},
 					"executionOrder": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ExecutionOrder indicate whether to restore the members in the sequential order as they appear in the members list. The default value is \"Parallel\" which means the members will be restored in parallel.",
+							Description: "ExecutionOrder indicate whether to restore the members in the sequential order as they appear in the members list. The default value is \"Parallel\" which means the members will be restored in parallel. If any member depends on the other members, the members are started one by one as their dependencies succeed, regardless of this field.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
+					"failurePolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailurePolicy specifies how to handle the failure of a member. By default, all the members are restored and the restore fails if any member fails.",
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.FailurePolicy"),
+						},
+					},
 					"hooks": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Hooks specifies the actions that Stash should take before or after restore. Cannot be updated.",
@@ -25252,11 +25938,32 @@ func schema_apimachinery_apis_stash_v1beta1_RestoreBatchSpec(ref common.Referenc
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
+					"mappings": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mappings specifies how the backed up targets are mapped to the restore destinations. When a mapping matches the ref of a target, the target ref identifies the backed up data and the data is restored into the destination specified by the mapping.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreMapping"),
+									},
+								},
+							},
+						},
+					},
+					"dryRun": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DryRun indicates that the restore should only be planned without restoring anything or executing any hooks. The plan is shown in the \"status.plan\" field.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "kmodules.xyz/client-go/api/v1.ObjectReference", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreHooks", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreTargetSpec"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "kmodules.xyz/client-go/api/v1.ObjectReference", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.FailurePolicy", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreHooks", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreMapping", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreTargetSpec"},
 	}
 }
 
@@ -25268,7 +25975,7 @@ func schema_apimachinery_apis_stash_v1beta1_RestoreBatchStatus(ref common.Refere
 				Properties: map[string]spec.Schema{
 					"phase": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Phase indicates the overall phase of the restore process for this RestoreBatch. Phase will be \"Succeeded\" only if phase of all members are \"Succeeded\". If the restore process fail for any of the members, Phase will be \"Failed\".",
+							Description: "Phase indicates the overall phase of the restore process for this RestoreBatch. Phase will be \"Succeeded\" only if phase of all members are \"Succeeded\". If the restore process fail for any of the members, Phase will be \"Failed\" unless the failure policy allows partial success. In that case, Phase will be \"PartiallySucceeded\".",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -25314,11 +26021,17 @@ func schema_apimachinery_apis_stash_v1beta1_RestoreBatchStatus(ref common.Refere
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
+					"plan": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Plan shows what the restore would do. It is set only for the dry-run.",
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestorePlan"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "kmodules.xyz/client-go/api/v1.Condition", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreMemberStatus"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "kmodules.xyz/client-go/api/v1.Condition", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreMemberStatus", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestorePlan"},
 	}
 }
 
@@ -25349,6 +26062,84 @@ func schema_apimachinery_apis_stash_v1beta1_RestoreHooks(ref common.ReferenceCal
 	}
 }
 
+func schema_apimachinery_apis_stash_v1beta1_RestoreMapping(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RestoreMapping maps the backed up data of a target to a different namespace, object or host. It is useful for restoring into a different namespace or cluster than the one that has been backed up.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"source": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Source identifies the backed up data. Empty fields match anything.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreMappingRef"),
+						},
+					},
+					"target": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Target specifies where the backed up data should be restored. Empty fields keep the value of the source.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreMappingRef"),
+						},
+					},
+					"pathRewrites": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PathRewrites specifies the locations where the backed up paths of the matching targets should be restored",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.PathRewrite"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"source"},
+			},
+		},
+		Dependencies: []string{
+			"stash.appscode.dev/apimachinery/apis/stash/v1beta1.PathRewrite", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreMappingRef"},
+	}
+}
+
+func schema_apimachinery_apis_stash_v1beta1_RestoreMappingRef(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RestoreMappingRef identifies a target and optionally one of its hosts",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespace is the namespace of the target",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the target",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"host": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Host is the name of the host of the target",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_apimachinery_apis_stash_v1beta1_RestoreMemberStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -25376,40 +26167,82 @@ func schema_apimachinery_apis_stash_v1beta1_RestoreMemberStatus(ref common.Refer
 							},
 						},
 					},
-					"totalHosts": {
+					"totalHosts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TotalHosts specifies total number of hosts that will be restored for this member.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase indicates restore phase of this member",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"stats": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Stats shows restore statistics of individual hosts for this member",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.HostRestoreStats"),
+									},
+								},
+							},
+						},
+					},
+					"deadline": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Deadline specifies the deadline of the restore of this member. It is set only if the member has its own timeOut. The member will be considered Failed if its restore does not complete within this deadline.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"retryLeft": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TotalHosts specifies total number of hosts that will be restored for this member.",
+							Description: "RetryLeft specifies number of retry attempts left for this member. It is set only if the member has its own retryConfig. Zero means the member has used all of its retry attempts.",
 							Type:        []string{"integer"},
 							Format:      "int32",
 						},
 					},
-					"phase": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Phase indicates restore phase of this member",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"stats": {
+				},
+				Required: []string{"ref"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "kmodules.xyz/client-go/api/v1.Condition", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.HostRestoreStats", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.TargetRef"},
+	}
+}
+
+func schema_apimachinery_apis_stash_v1beta1_RestorePlan(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RestorePlan shows the snapshots that would be restored for each target without restoring anything",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"targets": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Stats shows restore statistics of individual hosts for this member",
+							Description: "Targets shows the restore plan of the individual targets",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.HostRestoreStats"),
+										Ref:     ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.TargetRestorePlan"),
 									},
 								},
 							},
 						},
 					},
 				},
-				Required: []string{"ref"},
 			},
 		},
 		Dependencies: []string{
-			"kmodules.xyz/client-go/api/v1.Condition", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.HostRestoreStats", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.TargetRef"},
+			"stash.appscode.dev/apimachinery/apis/stash/v1beta1.TargetRestorePlan"},
 	}
 }
 
@@ -25551,6 +26384,32 @@ func schema_apimachinery_apis_stash_v1beta1_RestoreSessionSpec(ref common.Refere
 							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreHooks"),
 						},
 					},
+					"dependsOn": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DependsOn specifies the targets of the other members of the RestoreBatch that must complete their restore before this member starts. The members without any dependency are restored in parallel. If any member specifies its dependencies, the executionOrder of the RestoreBatch is ignored. It is only used for the members of a RestoreBatch.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.TargetRef"),
+									},
+								},
+							},
+						},
+					},
+					"timeOut": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeOut specifies the maximum duration of restore. RestoreSession will be considered Failed if restore does not complete within this time limit. By default, Stash don't set any timeout for restore. For a member of a RestoreBatch, it specifies the maximum duration of the restore of this member only.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"retryConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetryConfig specify a configuration for retry the restore of a member if it fails. By default, Stash does not retry any failed restore. Only the restore of this member is retried without re-running the restore of the other members. It is only used for the members of a RestoreBatch.",
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.RetryConfig"),
+						},
+					},
 					"driver": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Driver indicates the name of the agent to use to restore the target. Supported values are \"Restic\", \"VolumeSnapshotter\". Default value is \"Restic\".",
@@ -25579,17 +26438,32 @@ func schema_apimachinery_apis_stash_v1beta1_RestoreSessionSpec(ref common.Refere
 							},
 						},
 					},
-					"timeOut": {
+					"mappings": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TimeOut specifies the maximum duration of restore. RestoreSession will be considered Failed if restore does not complete within this time limit. By default, Stash don't set any timeout for restore.",
-							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+							Description: "Mappings specifies how the backed up targets are mapped to the restore destinations. When a mapping matches the ref of a target, the target ref identifies the backed up data and the data is restored into the destination specified by the mapping.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreMapping"),
+									},
+								},
+							},
+						},
+					},
+					"dryRun": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DryRun indicates that the restore should only be planned without restoring anything or executing any hooks. The plan is shown in the \"status.plan\" field.",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "kmodules.xyz/client-go/api/v1.ObjectReference", "kmodules.xyz/offshoot-api/api/v1.PersistentVolumeClaim", "kmodules.xyz/offshoot-api/api/v1.RuntimeSettings", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.EmptyDirSettings", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreHooks", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreTarget", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.Rule", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.TaskRef"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "kmodules.xyz/client-go/api/v1.ObjectReference", "kmodules.xyz/offshoot-api/api/v1.PersistentVolumeClaim", "kmodules.xyz/offshoot-api/api/v1.RuntimeSettings", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.EmptyDirSettings", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreHooks", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreMapping", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreTarget", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RetryConfig", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.Rule", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.TargetRef", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.TaskRef"},
 	}
 }
 
@@ -25654,11 +26528,17 @@ func schema_apimachinery_apis_stash_v1beta1_RestoreSessionStatus(ref common.Refe
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
+					"plan": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Plan shows what the restore would do. It is set only for the dry-run.",
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestorePlan"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "kmodules.xyz/client-go/api/v1.Condition", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.HostRestoreStats"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "kmodules.xyz/client-go/api/v1.Condition", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.HostRestoreStats", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestorePlan"},
 	}
 }
 
@@ -25799,11 +26679,37 @@ func schema_apimachinery_apis_stash_v1beta1_RestoreTargetSpec(ref common.Referen
 							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreHooks"),
 						},
 					},
+					"dependsOn": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DependsOn specifies the targets of the other members of the RestoreBatch that must complete their restore before this member starts. The members without any dependency are restored in parallel. If any member specifies its dependencies, the executionOrder of the RestoreBatch is ignored. It is only used for the members of a RestoreBatch.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.TargetRef"),
+									},
+								},
+							},
+						},
+					},
+					"timeOut": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeOut specifies the maximum duration of restore. RestoreSession will be considered Failed if restore does not complete within this time limit. By default, Stash don't set any timeout for restore. For a member of a RestoreBatch, it specifies the maximum duration of the restore of this member only.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"retryConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetryConfig specify a configuration for retry the restore of a member if it fails. By default, Stash does not retry any failed restore. Only the restore of this member is retried without re-running the restore of the other members. It is only used for the members of a RestoreBatch.",
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.RetryConfig"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kmodules.xyz/offshoot-api/api/v1.PersistentVolumeClaim", "kmodules.xyz/offshoot-api/api/v1.RuntimeSettings", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.EmptyDirSettings", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreHooks", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreTarget", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.TaskRef"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "kmodules.xyz/offshoot-api/api/v1.PersistentVolumeClaim", "kmodules.xyz/offshoot-api/api/v1.RuntimeSettings", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.EmptyDirSettings", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreHooks", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RestoreTarget", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.RetryConfig", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.TargetRef", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.TaskRef"},
 	}
 }
 
@@ -25922,9 +26828,165 @@ func schema_apimachinery_apis_stash_v1beta1_Rule(ref common.ReferenceCallback) c
 							},
 						},
 					},
+					"restoreTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RestoreTime selects the latest snapshot taken at or before this time for point-in-time restore. Don't specify if you have specified snapshots field.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"before": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Before selects only the snapshots taken before this time. Don't specify if you have specified snapshots or restoreTime field.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"after": {
+						SchemaProps: spec.SchemaProps{
+							Description: "After selects only the snapshots taken after this time. Don't specify if you have specified snapshots field.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"tags": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tags selects only the snapshots that have all of these tags. Don't specify if you have specified snapshots field.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"pathRewrites": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PathRewrites specifies the locations where the backed up paths should be restored. The paths that do not match any rewrite are restored in their original location.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.PathRewrite"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.PathRewrite"},
+	}
+}
+
+func schema_apimachinery_apis_stash_v1beta1_ScheduledPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ScheduledPolicy specifies a schedule of a BackupConfiguration along with the policies of the backups it triggers",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name identifies the schedule. It is recorded in the BackupSessions triggered by this schedule.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"schedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Schedule specifies the cron expression of the schedule",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"tag": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tag is added to the snapshots taken by this schedule. The retention policy of this schedule is applied only on the snapshots with this tag. Default value is the name of the schedule.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"repository": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Repository overrides the Repository of the BackupConfiguration for the backups triggered by this schedule",
+							Ref:         ref("kmodules.xyz/client-go/api/v1.ObjectReference"),
+						},
+					},
+					"retentionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetentionPolicy overrides the retention policy of the BackupConfiguration for the snapshots taken by this schedule",
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1alpha1.RetentionPolicy"),
+						},
+					},
+				},
+				Required: []string{"name", "schedule"},
+			},
+		},
+		Dependencies: []string{
+			"kmodules.xyz/client-go/api/v1.ObjectReference", "stash.appscode.dev/apimachinery/apis/stash/v1alpha1.RetentionPolicy"},
+	}
+}
+
+func schema_apimachinery_apis_stash_v1beta1_SnapshotRestorePlan(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"id": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ID indicates the ID of the snapshot",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"time": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Time indicates when the snapshot was taken",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"paths": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Paths indicates the backed up paths of the snapshot",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"destination": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Destination indicates where the snapshot would be restored",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"size": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Size indicates the size of the data that would be restored from this snapshot",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -26077,6 +27139,57 @@ func schema_apimachinery_apis_stash_v1beta1_TargetRef(ref common.ReferenceCallba
 	}
 }
 
+func schema_apimachinery_apis_stash_v1beta1_TargetRestorePlan(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"ref": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Ref refers to the restore target",
+							Default:     map[string]interface{}{},
+							Ref:         ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.TargetRef"),
+						},
+					},
+					"available": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Available indicates whether the target exists and can be restored",
+							Default:     false,
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason explains why the target is not available",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"hosts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hosts shows the restore plan of the individual hosts of the target",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("stash.appscode.dev/apimachinery/apis/stash/v1beta1.HostRestorePlan"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"available"},
+			},
+		},
+		Dependencies: []string{
+			"stash.appscode.dev/apimachinery/apis/stash/v1beta1.HostRestorePlan", "stash.appscode.dev/apimachinery/apis/stash/v1beta1.TargetRef"},
+	}
+}
+
 func schema_apimachinery_apis_stash_v1beta1_TargetStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
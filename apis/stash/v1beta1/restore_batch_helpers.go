@@ -36,6 +36,22 @@ func (b RestoreBatch) GetSpecHash() string {
 	return strconv.FormatUint(hash.Sum64(), 10)
 }
 
+// Default sets the default values of a RestoreBatch
+func (b *RestoreBatch) Default() {
+	if b.Spec.Driver == "" {
+		b.Spec.Driver = ResticSnapshotter
+	}
+	if b.Spec.ExecutionOrder == "" {
+		b.Spec.ExecutionOrder = Parallel
+	}
+	defaultRestoreHooks(b.Spec.Hooks)
+	for i := range b.Spec.Members {
+		defaultRestoreHooks(b.Spec.Members[i].Hooks)
+		defaultRetryConfig(b.Spec.Members[i].RetryConfig)
+	}
+	defaultFailurePolicy(b.Spec.FailurePolicy)
+}
+
 // OffshootLabels return labels consist of the labels provided by user to RestoreBatch crd and
 // stash specific generic labels. It overwrites the the user provided labels if it matched with stash specific generic labels.
 func (b RestoreBatch) OffshootLabels() map[string]string {
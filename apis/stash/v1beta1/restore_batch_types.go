@@ -60,9 +60,15 @@ type RestoreBatchSpec struct {
 	Members []RestoreTargetSpec `json:"members,omitempty"`
 	// ExecutionOrder indicate whether to restore the members in the sequential order as they appear in the members list.
 	// The default value is "Parallel" which means the members will be restored in parallel.
+	// If any member depends on the other members, the members are started one by one as their dependencies
+	// succeed, regardless of this field.
 	// +kubebuilder:default=Parallel
 	// +optional
 	ExecutionOrder ExecutionOrder `json:"executionOrder,omitempty"`
+	// FailurePolicy specifies how to handle the failure of a member.
+	// By default, all the members are restored and the restore fails if any member fails.
+	// +optional
+	FailurePolicy *FailurePolicy `json:"failurePolicy,omitempty"`
 	// Hooks specifies the actions that Stash should take before or after restore.
 	// Cannot be updated.
 	// +optional
@@ -71,11 +77,21 @@ type RestoreBatchSpec struct {
 	// if restore does not complete within this time limit. By default, Stash don't set any timeout for restore.
 	// +optional
 	TimeOut *metav1.Duration `json:"timeOut,omitempty"`
+	// Mappings specifies how the backed up targets are mapped to the restore destinations.
+	// When a mapping matches the ref of a target, the target ref identifies the backed up data
+	// and the data is restored into the destination specified by the mapping.
+	// +optional
+	Mappings []RestoreMapping `json:"mappings,omitempty"`
+	// DryRun indicates that the restore should only be planned without restoring anything or executing any hooks.
+	// The plan is shown in the "status.plan" field.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 type RestoreBatchStatus struct {
 	// Phase indicates the overall phase of the restore process for this RestoreBatch. Phase will be "Succeeded" only if
-	// phase of all members are "Succeeded". If the restore process fail for any of the members, Phase will be "Failed".
+	// phase of all members are "Succeeded". If the restore process fail for any of the members, Phase will be "Failed"
+	// unless the failure policy allows partial success. In that case, Phase will be "PartiallySucceeded".
 	// +optional
 	Phase RestorePhase `json:"phase,omitempty"`
 	// SessionDuration specify total time taken to complete restore of all the members.
@@ -91,9 +107,12 @@ type RestoreBatchStatus struct {
 	// considered Failed if restore does not complete within this deadline
 	// +optional
 	SessionDeadline *metav1.Time `json:"sessionDeadline,omitempty"`
+	// Plan shows what the restore would do. It is set only for the dry-run.
+	// +optional
+	Plan *RestorePlan `json:"plan,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=Pending;Succeeded;Running;Failed
+// +kubebuilder:validation:Enum=Pending;Succeeded;Running;Failed;Skipped
 type RestoreTargetPhase string
 
 const (
@@ -102,6 +121,7 @@ const (
 	TargetRestoreSucceeded    RestoreTargetPhase = "Succeeded"
 	TargetRestoreFailed       RestoreTargetPhase = "Failed"
 	TargetRestorePhaseUnknown RestoreTargetPhase = "Unknown"
+	TargetRestoreSkipped      RestoreTargetPhase = "Skipped"
 )
 
 type RestoreMemberStatus struct {
@@ -119,6 +139,14 @@ type RestoreMemberStatus struct {
 	// Stats shows restore statistics of individual hosts for this member
 	// +optional
 	Stats []HostRestoreStats `json:"stats,omitempty"`
+	// Deadline specifies the deadline of the restore of this member. It is set only if the member has its own timeOut.
+	// The member will be considered Failed if its restore does not complete within this deadline.
+	// +optional
+	Deadline *metav1.Time `json:"deadline,omitempty"`
+	// RetryLeft specifies number of retry attempts left for this member. It is set only if the member has its own retryConfig.
+	// Zero means the member has used all of its retry attempts.
+	// +optional
+	RetryLeft *int32 `json:"retryLeft,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
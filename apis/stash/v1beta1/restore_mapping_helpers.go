@@ -0,0 +1,96 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"path"
+	"strings"
+)
+
+// Matches checks whether the mapping applies to the given target. The host of the source is not considered here.
+func (m RestoreMapping) Matches(ref TargetRef) bool {
+	return (m.Source.Namespace == "" || m.Source.Namespace == ref.Namespace) &&
+		(m.Source.Name == "" || m.Source.Name == ref.Name)
+}
+
+// ApplyRestoreMappings returns a copy of the target where the matching mappings have been applied.
+// The namespace and the name of the target ref are replaced by the first matching mapping that specifies them.
+// The host mappings update the target hosts of the rules restoring the source host, and
+// the path rewrites are added to every rule.
+func ApplyRestoreMappings(target *RestoreTarget, mappings []RestoreMapping) *RestoreTarget {
+	if target == nil || len(mappings) == 0 {
+		return target
+	}
+	source := target.Ref
+	out := target.DeepCopy()
+	nsMapped, nameMapped := false, false
+	for _, m := range mappings {
+		if !m.Matches(source) {
+			continue
+		}
+		if m.Target.Namespace != "" && !nsMapped {
+			out.Ref.Namespace = m.Target.Namespace
+			nsMapped = true
+		}
+		if m.Target.Name != "" && !nameMapped {
+			out.Ref.Name = m.Target.Name
+			nameMapped = true
+		}
+		if m.Source.Host != "" && m.Target.Host != "" {
+			out.Rules = mapRuleHost(out.Rules, m.Source.Host, m.Target.Host)
+		}
+		if len(m.PathRewrites) > 0 {
+			if len(out.Rules) == 0 {
+				out.Rules = []Rule{{}}
+			}
+			for i := range out.Rules {
+				out.Rules[i].PathRewrites = append(out.Rules[i].PathRewrites, m.PathRewrites...)
+			}
+		}
+	}
+	return out
+}
+
+// mapRuleHost ensures that the data of the source host is restored into the target host
+func mapRuleHost(rules []Rule, sourceHost, targetHost string) []Rule {
+	for i := range rules {
+		if rules[i].SourceHost == sourceHost {
+			rules[i].TargetHosts = []string{targetHost}
+			return rules
+		}
+	}
+	return append(rules, Rule{SourceHost: sourceHost, TargetHosts: []string{targetHost}})
+}
+
+// RewritePath returns the location where the backed up path should be restored.
+// The rewrite with the longest matching From path is used. It returns false if no rewrite matches the path.
+func RewritePath(p string, rewrites []PathRewrite) (string, bool) {
+	var match *PathRewrite
+	for i := range rewrites {
+		from := path.Clean(rewrites[i].From)
+		if p != from && !strings.HasPrefix(p, strings.TrimSuffix(from, "/")+"/") {
+			continue
+		}
+		if match == nil || len(from) > len(path.Clean(match.From)) {
+			match = &rewrites[i]
+		}
+	}
+	if match == nil {
+		return p, false
+	}
+	return path.Join(match.To, strings.TrimPrefix(p, path.Clean(match.From))), true
+}
@@ -0,0 +1,102 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyRestoreMappings(t *testing.T) {
+	mappings := []RestoreMapping{
+		{
+			Source: RestoreMappingRef{Namespace: "prod"},
+			Target: RestoreMappingRef{Namespace: "dr"},
+		},
+		{
+			Source: RestoreMappingRef{Namespace: "prod", Name: "mysql", Host: "mysql-0"},
+			Target: RestoreMappingRef{Name: "mysql-drill", Host: "mysql-drill-0"},
+			PathRewrites: []PathRewrite{
+				{From: "/var/lib/mysql", To: "/restore/mysql"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		target RestoreTarget
+		want   RestoreTarget
+	}{
+		{
+			name:   "Namespace mapping",
+			target: RestoreTarget{Ref: TargetRef{Kind: "StatefulSet", Name: "redis", Namespace: "prod"}},
+			want:   RestoreTarget{Ref: TargetRef{Kind: "StatefulSet", Name: "redis", Namespace: "dr"}},
+		},
+		{
+			name:   "Namespace, name, host and path mapping",
+			target: RestoreTarget{Ref: TargetRef{Kind: "StatefulSet", Name: "mysql", Namespace: "prod"}},
+			want: RestoreTarget{
+				Ref: TargetRef{Kind: "StatefulSet", Name: "mysql-drill", Namespace: "dr"},
+				Rules: []Rule{
+					{
+						SourceHost:   "mysql-0",
+						TargetHosts:  []string{"mysql-drill-0"},
+						PathRewrites: []PathRewrite{{From: "/var/lib/mysql", To: "/restore/mysql"}},
+					},
+				},
+			},
+		},
+		{
+			name:   "No matching mapping",
+			target: RestoreTarget{Ref: TargetRef{Kind: "StatefulSet", Name: "mysql", Namespace: "demo"}},
+			want:   RestoreTarget{Ref: TargetRef{Kind: "StatefulSet", Name: "mysql", Namespace: "demo"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ApplyRestoreMappings(&tt.target, mappings); !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("ApplyRestoreMappings() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRewritePath(t *testing.T) {
+	rewrites := []PathRewrite{
+		{From: "/data", To: "/restore"},
+		{From: "/data/db", To: "/restore-db"},
+	}
+
+	tests := []struct {
+		path   string
+		want   string
+		wantOK bool
+	}{
+		{path: "/data", want: "/restore", wantOK: true},
+		{path: "/data/files", want: "/restore/files", wantOK: true},
+		{path: "/data/db/wal", want: "/restore-db/wal", wantOK: true},
+		{path: "/database", want: "/database", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, ok := RewritePath(tt.path, rewrites)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("RewritePath() = %s, %v, want %s, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
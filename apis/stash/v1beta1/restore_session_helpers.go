@@ -36,6 +36,15 @@ func (r RestoreSession) GetSpecHash() string {
 	return strconv.FormatUint(hash.Sum64(), 10)
 }
 
+// Default sets the default values of a RestoreSession. It also moves the deprecated fields into the appropriate fields.
+func (r *RestoreSession) Default() {
+	r.Migrate()
+	if r.Spec.Driver == "" {
+		r.Spec.Driver = ResticSnapshotter
+	}
+	defaultRestoreHooks(r.Spec.Hooks)
+}
+
 // OffshootLabels return labels consist of the labels provided by user to BackupConfiguration crd and
 // stash specific generic labels. It overwrites the the user provided labels if it matched with stash specific generic labels.
 func (r *RestoreSession) OffshootLabels() map[string]string {
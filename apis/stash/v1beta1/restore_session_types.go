@@ -62,10 +62,15 @@ type RestoreSessionSpec struct {
 	// +optional
 	// Deprecated. Use rules section inside `target`.
 	Rules []Rule `json:"rules,omitempty"`
-	// TimeOut specifies the maximum duration of restore. RestoreSession will be considered Failed
-	// if restore does not complete within this time limit. By default, Stash don't set any timeout for restore.
+	// Mappings specifies how the backed up targets are mapped to the restore destinations.
+	// When a mapping matches the ref of a target, the target ref identifies the backed up data
+	// and the data is restored into the destination specified by the mapping.
 	// +optional
-	TimeOut *metav1.Duration `json:"timeOut,omitempty"`
+	Mappings []RestoreMapping `json:"mappings,omitempty"`
+	// DryRun indicates that the restore should only be planned without restoring anything or executing any hooks.
+	// The plan is shown in the "status.plan" field.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 type RestoreTargetSpec struct {
@@ -90,6 +95,23 @@ type RestoreTargetSpec struct {
 	// Actions that Stash should take in response to restore sessions.
 	// +optional
 	Hooks *RestoreHooks `json:"hooks,omitempty"`
+	// DependsOn specifies the targets of the other members of the RestoreBatch that must complete their restore
+	// before this member starts. The members without any dependency are restored in parallel.
+	// If any member specifies its dependencies, the executionOrder of the RestoreBatch is ignored.
+	// It is only used for the members of a RestoreBatch.
+	// +optional
+	DependsOn []TargetRef `json:"dependsOn,omitempty"`
+	// TimeOut specifies the maximum duration of restore. RestoreSession will be considered Failed
+	// if restore does not complete within this time limit. By default, Stash don't set any timeout for restore.
+	// For a member of a RestoreBatch, it specifies the maximum duration of the restore of this member only.
+	// +optional
+	TimeOut *metav1.Duration `json:"timeOut,omitempty"`
+	// RetryConfig specify a configuration for retry the restore of a member if it fails.
+	// By default, Stash does not retry any failed restore. Only the restore of this member is retried
+	// without re-running the restore of the other members.
+	// It is only used for the members of a RestoreBatch.
+	// +optional
+	RetryConfig *RetryConfig `json:"retryConfig,omitempty"`
 }
 
 // Hooks describes actions that Stash should take in response to restore sessions. For the PostRestore
@@ -108,11 +130,12 @@ type RestoreHooks struct {
 type PostRestoreHook struct {
 	*prober.Handler `json:",inline"`
 	// ExecutionPolicy specifies when to execute a hook.
-	// Supported values are "Always", "OnFailure", "OnSuccess".
+	// Supported values are "Always", "OnFailure", "OnSuccess", "OnPartialSuccess".
+	// "OnFailure" also executes the hook when the restore has partially succeeded as some members have failed.
 	// Default value: "Always".
 	// +optional
 	// +kubebuilder:default=Always
-	// +kubebuilder:validation:Enum=Always;OnFailure;OnSuccess
+	// +kubebuilder:validation:Enum=Always;OnFailure;OnSuccess;OnPartialSuccess
 	ExecutionPolicy HookExecutionPolicy `json:"executionPolicy,omitempty"`
 }
 
@@ -124,16 +147,17 @@ type RestoreSessionList struct {
 	Items           []RestoreSession `json:"items,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=Pending;Running;Succeeded;Failed;Unknown;Invalid
+// +kubebuilder:validation:Enum=Pending;Running;Succeeded;PartiallySucceeded;Failed;Unknown;Invalid
 type RestorePhase string
 
 const (
-	RestorePending      RestorePhase = "Pending"
-	RestoreRunning      RestorePhase = "Running"
-	RestoreSucceeded    RestorePhase = "Succeeded"
-	RestoreFailed       RestorePhase = "Failed"
-	RestorePhaseUnknown RestorePhase = "Unknown"
-	RestorePhaseInvalid RestorePhase = "Invalid"
+	RestorePending            RestorePhase = "Pending"
+	RestoreRunning            RestorePhase = "Running"
+	RestoreSucceeded          RestorePhase = "Succeeded"
+	RestorePartiallySucceeded RestorePhase = "PartiallySucceeded"
+	RestoreFailed             RestorePhase = "Failed"
+	RestorePhaseUnknown       RestorePhase = "Unknown"
+	RestorePhaseInvalid       RestorePhase = "Invalid"
 )
 
 // +kubebuilder:validation:Enum=Succeeded;Failed;Running;Unknown
@@ -167,6 +191,64 @@ type RestoreSessionStatus struct {
 	// considered Failed if restore does not complete within this deadline
 	// +optional
 	SessionDeadline *metav1.Time `json:"sessionDeadline,omitempty"`
+	// Plan shows what the restore would do. It is set only for the dry-run.
+	// +optional
+	Plan *RestorePlan `json:"plan,omitempty"`
+}
+
+// RestorePlan shows the snapshots that would be restored for each target without restoring anything
+type RestorePlan struct {
+	// Targets shows the restore plan of the individual targets
+	// +optional
+	Targets []TargetRestorePlan `json:"targets,omitempty"`
+}
+
+type TargetRestorePlan struct {
+	// Ref refers to the restore target
+	Ref TargetRef `json:"ref,omitempty"`
+	// Available indicates whether the target exists and can be restored
+	Available bool `json:"available"`
+	// Reason explains why the target is not available
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Hosts shows the restore plan of the individual hosts of the target
+	// +optional
+	Hosts []HostRestorePlan `json:"hosts,omitempty"`
+}
+
+type HostRestorePlan struct {
+	// Hostname indicates the name of the host that would be restored
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+	// SourceHost indicates the name of the host whose backed up data would be restored
+	// +optional
+	SourceHost string `json:"sourceHost,omitempty"`
+	// Snapshots shows the snapshots that would be restored
+	// +optional
+	Snapshots []SnapshotRestorePlan `json:"snapshots,omitempty"`
+	// TotalSize indicates the total size of the data that would be restored
+	// +optional
+	TotalSize string `json:"totalSize,omitempty"`
+	// Error indicates why the restore of this host could not be planned
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+type SnapshotRestorePlan struct {
+	// ID indicates the ID of the snapshot
+	ID string `json:"id,omitempty"`
+	// Time indicates when the snapshot was taken
+	// +optional
+	Time *metav1.Time `json:"time,omitempty"`
+	// Paths indicates the backed up paths of the snapshot
+	// +optional
+	Paths []string `json:"paths,omitempty"`
+	// Destination indicates where the snapshot would be restored
+	// +optional
+	Destination string `json:"destination,omitempty"`
+	// Size indicates the size of the data that would be restored from this snapshot
+	// +optional
+	Size string `json:"size,omitempty"`
 }
 
 type HostRestoreStats struct {
@@ -212,6 +294,9 @@ const (
 
 	// PostRestoreHookExecutionSucceeded indicates whether the postRestore hook was executed successfully or not
 	PostRestoreHookExecutionSucceeded = "PostRestoreHookExecutionSucceeded"
+
+	// RestorePlanned indicates whether the restore plan has been generated for the dry-run
+	RestorePlanned = "RestorePlanned"
 )
 
 // ======================== Condition Reasons ===================
@@ -244,4 +329,7 @@ const (
 
 	PostRestoreTasksExecuted    = "PostRestoreTasksExecuted"
 	PostRestoreTasksNotExecuted = "PostRestoreTasksNotExecuted"
+
+	SuccessfullyGeneratedRestorePlan = "SuccessfullyGeneratedRestorePlan"
+	FailedToGenerateRestorePlan      = "FailedToGenerateRestorePlan"
 )
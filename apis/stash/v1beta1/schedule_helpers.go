@@ -0,0 +1,136 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// BackupSchedule is a cron schedule along with the time zone it is evaluated in
+type BackupSchedule struct {
+	// Name is the name of the scheduled policy. It is empty for the default schedule.
+	Name string
+	// Cron is the cron expression of the schedule
+	Cron string
+	// TimeZone is the IANA name of the time zone of the schedule.
+	// Empty means the time zone of the cluster (i.e. the local time zone of the Stash operator).
+	TimeZone string
+}
+
+// Parse parses the cron expression in the time zone of the schedule
+func (s BackupSchedule) Parse() (cron.Schedule, error) {
+	sched, err := cron.ParseStandard(s.Cron)
+	if err != nil {
+		return nil, err
+	}
+	if hasTimeZonePrefix(s.Cron) {
+		return sched, nil
+	}
+	if s.TimeZone == "" {
+		return localSchedule{Schedule: sched}, nil
+	}
+	loc, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	if spec, ok := sched.(*cron.SpecSchedule); ok {
+		spec.Location = loc
+	}
+	return sched, nil
+}
+
+// localSchedule evaluates a schedule in the local time zone. Otherwise, cron evaluates a schedule without
+// any time zone in the location of the given time.
+type localSchedule struct {
+	cron.Schedule
+}
+
+func (s localSchedule) Next(t time.Time) time.Time {
+	return s.Schedule.Next(t.In(time.Local))
+}
+
+// NextRunTimes returns the next n times after the given time when the schedule will be triggered.
+// It returns nil if the cron expression is empty.
+func (s BackupSchedule) NextRunTimes(after time.Time, n int) ([]time.Time, error) {
+	if s.Cron == "" || n <= 0 {
+		return nil, nil
+	}
+	sched, err := s.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", s.Cron, err)
+	}
+	times := make([]time.Time, 0, n)
+	for next := sched.Next(after); !next.IsZero() && len(times) < n; next = sched.Next(next) {
+		times = append(times, next)
+	}
+	return times, nil
+}
+
+// GetBackupSchedule returns the schedule of the BackupConfiguration along with its time zone
+func (s BackupConfigurationSpec) GetBackupSchedule() BackupSchedule {
+	return BackupSchedule{Cron: s.Schedule, TimeZone: s.TimeZone}
+}
+
+// GetSchedules returns the default schedule of the BackupConfiguration followed by its scheduled policies.
+// The default schedule is omitted if it is not specified.
+func (s BackupConfigurationSpec) GetSchedules() []BackupSchedule {
+	var schedules []BackupSchedule
+	if s.Schedule != "" {
+		schedules = append(schedules, s.GetBackupSchedule())
+	}
+	for _, p := range s.Schedules {
+		schedules = append(schedules, BackupSchedule{Name: p.Name, Cron: p.Schedule, TimeZone: s.TimeZone})
+	}
+	return schedules
+}
+
+// GetScheduledPolicy returns the scheduled policy with the given name. It returns nil if there is no such policy.
+func (s BackupConfigurationSpec) GetScheduledPolicy(name string) *ScheduledPolicy {
+	for i := range s.Schedules {
+		if s.Schedules[i].Name == name {
+			return &s.Schedules[i]
+		}
+	}
+	return nil
+}
+
+// GetTag returns the tag of the snapshots taken by the schedule
+func (p ScheduledPolicy) GetTag() string {
+	if p.Tag != "" {
+		return p.Tag
+	}
+	return p.Name
+}
+
+// GetBackupSchedule returns the schedule of the BackupBatch along with its time zone
+func (s BackupBatchSpec) GetBackupSchedule() BackupSchedule {
+	return BackupSchedule{Cron: s.Schedule, TimeZone: s.TimeZone}
+}
+
+// GetBackupSchedule returns the default schedule of the BackupBlueprint along with its time zone
+func (s BackupBlueprintSpec) GetBackupSchedule() BackupSchedule {
+	return BackupSchedule{Cron: s.Schedule, TimeZone: s.TimeZone}
+}
+
+// hasTimeZonePrefix checks whether the cron expression specifies its own time zone using "CRON_TZ=" or "TZ=" prefix
+func hasTimeZonePrefix(expr string) bool {
+	return strings.HasPrefix(expr, "CRON_TZ=") || strings.HasPrefix(expr, "TZ=")
+}
@@ -0,0 +1,90 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestBackupSchedule_NextRunTimes(t *testing.T) {
+	now := time.Date(2024, time.March, 9, 22, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		schedule BackupSchedule
+		n        int
+		want     []time.Time
+	}{
+		{
+			name:     "UTC",
+			schedule: BackupSchedule{Cron: "0 0 * * *", TimeZone: "UTC"},
+			n:        2,
+			want: []time.Time{
+				time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "Daylight saving time change",
+			schedule: BackupSchedule{Cron: "0 1 * * *", TimeZone: "America/New_York"},
+			n:        3,
+			want: []time.Time{
+				time.Date(2024, time.March, 10, 6, 0, 0, 0, time.UTC), // EST (UTC-5)
+				time.Date(2024, time.March, 11, 5, 0, 0, 0, time.UTC), // EDT (UTC-4)
+				time.Date(2024, time.March, 12, 5, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "Time zone in the schedule takes precedence",
+			schedule: BackupSchedule{Cron: "CRON_TZ=Asia/Dhaka 0 6 * * *", TimeZone: "America/New_York"},
+			n:        1,
+			want:     []time.Time{time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "Local time zone",
+			schedule: BackupSchedule{Cron: "0 0 * * *"},
+			n:        1,
+			want:     []time.Time{nextLocalMidnight(now)},
+		},
+		{
+			name:     "Empty schedule",
+			schedule: BackupSchedule{TimeZone: "Asia/Dhaka"},
+			n:        3,
+			want:     nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.schedule.NextRunTimes(now, tt.n)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if !slices.EqualFunc(got, tt.want, time.Time.Equal) {
+				t.Errorf("NextRunTimes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// nextLocalMidnight returns the first midnight after t in the local time zone
+func nextLocalMidnight(t time.Time) time.Time {
+	t = t.In(time.Local)
+	return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.Local)
+}
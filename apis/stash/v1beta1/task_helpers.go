@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	"slices"
+
 	"stash.appscode.dev/apimachinery/crds"
 
 	"kmodules.xyz/client-go/apiextensions"
@@ -25,3 +27,12 @@ import (
 func (Task) CustomResourceDefinition() *apiextensions.CustomResourceDefinition {
 	return crds.MustCustomResourceDefinition(SchemeGroupVersion.WithResource(ResourcePluralTask))
 }
+
+// Default removes the empty parameters (i.e. "params: [{}]" left by a template) from the steps of a Task
+func (t *Task) Default() {
+	for i := range t.Spec.Steps {
+		t.Spec.Steps[i].Params = slices.DeleteFunc(t.Spec.Steps[i].Params, func(p Param) bool {
+			return p == Param{}
+		})
+	}
+}
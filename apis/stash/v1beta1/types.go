@@ -135,6 +135,60 @@ type Rule struct {
 	// Supported only for "Restic" driver
 	// +optional
 	Include []string `json:"include,omitempty"`
+	// RestoreTime selects the latest snapshot taken at or before this time for point-in-time restore.
+	// Don't specify if you have specified snapshots field.
+	// +optional
+	RestoreTime *metav1.Time `json:"restoreTime,omitempty"`
+	// Before selects only the snapshots taken before this time.
+	// Don't specify if you have specified snapshots or restoreTime field.
+	// +optional
+	Before *metav1.Time `json:"before,omitempty"`
+	// After selects only the snapshots taken after this time.
+	// Don't specify if you have specified snapshots field.
+	// +optional
+	After *metav1.Time `json:"after,omitempty"`
+	// Tags selects only the snapshots that have all of these tags.
+	// Don't specify if you have specified snapshots field.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+	// PathRewrites specifies the locations where the backed up paths should be restored.
+	// The paths that do not match any rewrite are restored in their original location.
+	// +optional
+	PathRewrites []PathRewrite `json:"pathRewrites,omitempty"`
+}
+
+// PathRewrite restores the data backed up under the From path into the To path
+type PathRewrite struct {
+	// From is the absolute path of the backed up data
+	From string `json:"from"`
+	// To is the absolute path where the data should be restored
+	To string `json:"to"`
+}
+
+// RestoreMapping maps the backed up data of a target to a different namespace, object or host.
+// It is useful for restoring into a different namespace or cluster than the one that has been backed up.
+type RestoreMapping struct {
+	// Source identifies the backed up data. Empty fields match anything.
+	Source RestoreMappingRef `json:"source"`
+	// Target specifies where the backed up data should be restored. Empty fields keep the value of the source.
+	// +optional
+	Target RestoreMappingRef `json:"target,omitempty"`
+	// PathRewrites specifies the locations where the backed up paths of the matching targets should be restored
+	// +optional
+	PathRewrites []PathRewrite `json:"pathRewrites,omitempty"`
+}
+
+// RestoreMappingRef identifies a target and optionally one of its hosts
+type RestoreMappingRef struct {
+	// Namespace is the namespace of the target
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the target
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Host is the name of the host of the target
+	// +optional
+	Host string `json:"host,omitempty"`
 }
 
 type TargetRef struct {
@@ -162,3 +216,28 @@ type RetryConfig struct {
 	// +optional
 	Delay metav1.Duration `json:"delay,omitempty"`
 }
+
+// +kubebuilder:validation:Enum=FailFast;ContinueOnError;MinSuccessful
+type FailurePolicyType string
+
+const (
+	// FailFast stops the batch as soon as a member fails. The members that have not started yet are not processed.
+	FailFast FailurePolicyType = "FailFast"
+	// ContinueOnError processes all the members even if some of them fail.
+	ContinueOnError FailurePolicyType = "ContinueOnError"
+	// MinSuccessful processes all the members and considers the session PartiallySucceeded if at least
+	// MinSuccessful members have succeeded.
+	MinSuccessful FailurePolicyType = "MinSuccessful"
+)
+
+type FailurePolicy struct {
+	// Type specifies how to handle the failure of a member of a batch.
+	// Supported values are "FailFast", "ContinueOnError" and "MinSuccessful". Default value is "FailFast".
+	// +kubebuilder:default=FailFast
+	// +optional
+	Type FailurePolicyType `json:"type,omitempty"`
+	// MinSuccessful specifies the minimum number of members that must succeed for the session to be considered
+	// PartiallySucceeded. It is used only when the type is "MinSuccessful".
+	// +optional
+	MinSuccessful *int32 `json:"minSuccessful,omitempty"`
+}
@@ -26,6 +26,9 @@ const (
 	StashBackupComponent  = "stash-backup"
 	StashRestoreComponent = "stash-restore"
 	TargetKindEmpty       = "EmptyTarget"
+
+	DefaultBackupHistoryLimit int32 = 1
+	DefaultMaxRetry           int32 = 1
 )
 
 // TODO: complete
@@ -47,3 +50,27 @@ func EmptyTargetRef() TargetRef {
 		Name:       "na",
 	}
 }
+
+func defaultBackupHooks(hooks *BackupHooks) {
+	if hooks != nil && hooks.PostBackup != nil && hooks.PostBackup.ExecutionPolicy == "" {
+		hooks.PostBackup.ExecutionPolicy = ExecuteAlways
+	}
+}
+
+func defaultRestoreHooks(hooks *RestoreHooks) {
+	if hooks != nil && hooks.PostRestore != nil && hooks.PostRestore.ExecutionPolicy == "" {
+		hooks.PostRestore.ExecutionPolicy = ExecuteAlways
+	}
+}
+
+func defaultRetryConfig(rc *RetryConfig) {
+	if rc != nil && rc.MaxRetry == 0 {
+		rc.MaxRetry = DefaultMaxRetry
+	}
+}
+
+func defaultFailurePolicy(policy *FailurePolicy) {
+	if policy != nil && policy.Type == "" {
+		policy.Type = FailFast
+	}
+}
@@ -16,70 +16,951 @@ limitations under the License.
 
 package v1beta1
 
-import "fmt"
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"stash.appscode.dev/apimachinery/apis"
+	"stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+
+	"github.com/robfig/cron/v3"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	kmapi "kmodules.xyz/client-go/api/v1"
+)
 
 // TODO: complete
 func (r BackupSession) IsValid() error {
 	return nil
 }
 
-// TODO: complete
+// IsValid checks the sanity of the restore rules of a RestoreSession.
+// Use ValidateCreate to validate the whole specification.
 func (r RestoreSession) IsValid() error {
-	// ========== spec.Rules validation================
-	// We must ensure following:
-	// 1. There is at most one rule with empty targetHosts field.
-	// 2. No two rules with non-emtpy targetHosts matches for a host.
-	// 3. If snapshot field is specified in a rule then paths is not specified.
-
-	// ensure the there is at most one rule with source
-	var ruleIdx []int
-	for i, rule := range r.Spec.Rules {
-		if len(rule.TargetHosts) == 0 {
-			ruleIdx = append(ruleIdx, i)
+	return validateRules(r.Spec.Rules, field.NewPath("spec", "rules")).ToAggregate()
+}
+
+// ValidateCreate validates a BackupSession on creation
+func (r BackupSession) ValidateCreate() field.ErrorList {
+	fldPath := field.NewPath("spec")
+	spec := r.Spec
+
+	var allErrs field.ErrorList
+	if spec.Invoker.Kind != ResourceKindBackupBatch && len(spec.Targets) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("targets"), "targets can be specified only for BackupBatch"))
+	}
+	for i, target := range spec.Targets {
+		allErrs = append(allErrs, validateTargetRef(target, fldPath.Child("targets").Index(i))...)
+	}
+	for i, tag := range spec.Tags {
+		if tag == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("tags").Index(i), "tag must not be empty"))
 		}
 	}
-	if len(ruleIdx) > 1 {
-		return fmt.Errorf("\n\t"+
-			"Error: Invalid RestoreSession specification.\n\t"+
-			"Reason: %s.\n\t"+
-			"Hints: There can be at most one rule with empty targetHosts", multipleRuleWithEmptyTargetHostError(ruleIdx))
+	allErrs = append(allErrs, validateParams(spec.Params, fldPath.Child("params"))...)
+	if spec.IgnoreRestrictions && spec.Schedule != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("ignoreRestrictions"), "restrictions can be ignored only by an on-demand session"))
 	}
+	return allErrs
+}
 
-	// ensure that no two rules with non-emtpy targetHosts matches for a host
-	res := make(map[string]int)
-	for i, rule := range r.Spec.Rules {
-		for _, host := range rule.TargetHosts {
-			v, ok := res[host]
-			if ok {
-				return fmt.Errorf("\n\t"+
-					"Error: Invalid RestoreSession specification.\n\t"+
-					"Reason: Multiple rules (rule[%d] and rule[%d]) match for host %q.\n\t"+
-					"Hints: There could be only one matching rule for a host", v, i, host)
+// ValidateUpdate validates a BackupSession on update. The invoker and the overrides can not be changed.
+func (r BackupSession) ValidateUpdate(old *BackupSession) field.ErrorList {
+	fldPath := field.NewPath("spec")
+	allErrs := r.ValidateCreate()
+	allErrs = append(allErrs, validateImmutable(r.Spec.Invoker, old.Spec.Invoker, fldPath.Child("invoker"))...)
+	allErrs = append(allErrs, validateImmutable(r.Spec.Targets, old.Spec.Targets, fldPath.Child("targets"))...)
+	allErrs = append(allErrs, validateImmutable(r.Spec.Tags, old.Spec.Tags, fldPath.Child("tags"))...)
+	allErrs = append(allErrs, validateImmutable(r.Spec.SkipRetentionPolicy, old.Spec.SkipRetentionPolicy, fldPath.Child("skipRetentionPolicy"))...)
+	allErrs = append(allErrs, validateImmutable(r.Spec.Params, old.Spec.Params, fldPath.Child("params"))...)
+	allErrs = append(allErrs, validateImmutable(r.Spec.IgnoreRestrictions, old.Spec.IgnoreRestrictions, fldPath.Child("ignoreRestrictions"))...)
+	return allErrs
+}
+
+// ValidateCreate validates a BackupConfiguration on creation
+func (b BackupConfiguration) ValidateCreate() field.ErrorList {
+	fldPath := field.NewPath("spec")
+	spec := b.Spec
+
+	allErrs := validateDriver(spec.Driver, fldPath.Child("driver"))
+	allErrs = append(allErrs, validateBackupSchedule(spec.GetBackupSchedule(), fldPath)...)
+	allErrs = append(allErrs, validateRepositoryRef(spec.Driver, spec.Repository, fldPath.Child("repository"))...)
+	allErrs = append(allErrs, validateBackupTemplate(spec.Driver, spec.BackupConfigurationTemplateSpec, fldPath)...)
+	allErrs = append(allErrs, spec.RetentionPolicy.Validate(fldPath.Child("retentionPolicy"))...)
+	allErrs = append(allErrs, validateHistoryLimit(spec.BackupHistoryLimit, fldPath.Child("backupHistoryLimit"))...)
+	allErrs = append(allErrs, validateBackupWindow(spec.BackupWindow, fldPath.Child("backupWindow"))...)
+	allErrs = append(allErrs, validateBlackouts(spec.Blackouts, fldPath.Child("blackouts"))...)
+	allErrs = append(allErrs, validateConcurrencyPolicy(spec.ConcurrencyPolicy, fldPath.Child("concurrencyPolicy"))...)
+	allErrs = append(allErrs, validateTimeOut(spec.StartingDeadline, fldPath.Child("startingDeadline"))...)
+	allErrs = append(allErrs, validateScheduledPolicies(spec.Driver, spec.Schedules, spec.TimeZone, fldPath.Child("schedules"))...)
+	if len(spec.DependsOn) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("dependsOn"), "dependencies are only supported for the members of a BackupBatch"))
+	}
+	return allErrs
+}
+
+func validateScheduledPolicies(driver Snapshotter, policies []ScheduledPolicy, timeZone string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	names := sets.New[string]()
+	tags := sets.New[string]()
+	for i, p := range policies {
+		idxPath := fldPath.Index(i)
+		switch {
+		case p.Name == "":
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "name of the schedule must be specified"))
+		case names.Has(p.Name):
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), p.Name))
+		}
+		names.Insert(p.Name)
+		// the retention policy of a schedule is applied on the snapshots with its tag, so the tags must be unique
+		if tag := p.GetTag(); tag != "" && tags.Has(tag) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("tag"), tag))
+		} else {
+			tags.Insert(tag)
+		}
+		if p.Schedule == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("schedule"), "schedule must be specified"))
+		} else {
+			allErrs = append(allErrs, validateSchedule(p.Schedule, idxPath.Child("schedule"))...)
+			if timeZone != "" && hasTimeZonePrefix(p.Schedule) {
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("schedule"), "can not specify a time zone when the timeZone field is set"))
+			}
+		}
+		if p.Repository != nil {
+			if driver == VolumeSnapshotter {
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("repository"), "repository is not used by VolumeSnapshotter driver"))
 			} else {
-				res[host] = i
+				allErrs = append(allErrs, validateRepositoryRef(driver, *p.Repository, idxPath.Child("repository"))...)
 			}
 		}
+		if p.RetentionPolicy != nil {
+			allErrs = append(allErrs, p.RetentionPolicy.Validate(idxPath.Child("retentionPolicy"))...)
+		}
 	}
+	return allErrs
+}
 
-	// ensure that path is not specified in a rule if snapshot field is specified
-	for i, rule := range r.Spec.Rules {
-		if len(rule.Snapshots) != 0 && len(rule.Paths) != 0 {
-			return fmt.Errorf("\n\t"+
-				"Error: Invalid RestoreSession specification.\n\t"+
-				"Reason: Both 'snapshots' and 'paths' fileds are specified in rule[%d].\n\t"+
-				"Hints: A snpashot contains backup data of only one directory. So, you can't specify 'paths' if you specify snapshot field", i)
+// ValidateRepository validates the BackupConfiguration against the given Repository. The retention policies that are
+// applied on the Repository must keep the snapshots that are locked by the immutability policy of the Repository.
+func (b BackupConfiguration) ValidateRepository(repo *v1alpha1.Repository) field.ErrorList {
+	fldPath := field.NewPath("spec")
+	allErrs := validateRetentionImmutability(repo, b.Namespace, b.Spec.Repository, b.Spec.RetentionPolicy, fldPath.Child("retentionPolicy"))
+	for i, p := range b.Spec.Schedules {
+		if p.RetentionPolicy == nil {
+			continue
+		}
+		repoRef := b.Spec.Repository
+		if p.Repository != nil {
+			repoRef = *p.Repository
+		}
+		allErrs = append(allErrs, validateRetentionImmutability(repo, b.Namespace, repoRef, *p.RetentionPolicy, fldPath.Child("schedules").Index(i).Child("retentionPolicy"))...)
+	}
+	return allErrs
+}
+
+// validateRetentionImmutability validates the retention policy against the immutability policy of the Repository
+// if the retention policy is applied on that Repository
+func validateRetentionImmutability(repo *v1alpha1.Repository, namespace string, repoRef kmapi.ObjectReference, policy v1alpha1.RetentionPolicy, fldPath *field.Path) field.ErrorList {
+	if repoRef.Namespace == "" {
+		repoRef.Namespace = namespace
+	}
+	if !repo.IsImmutable() || repo.Name != repoRef.Name || repo.Namespace != repoRef.Namespace {
+		return nil
+	}
+	return policy.ValidateImmutability(repo.Spec.Immutability, fldPath)
+}
+
+// ValidateUpdate validates a BackupConfiguration on update. The driver and the target can not be changed.
+func (b BackupConfiguration) ValidateUpdate(old *BackupConfiguration) field.ErrorList {
+	fldPath := field.NewPath("spec")
+	allErrs := b.ValidateCreate()
+	allErrs = append(allErrs, validateImmutable(b.Spec.Driver, old.Spec.Driver, fldPath.Child("driver"))...)
+	if b.Spec.Target != nil && old.Spec.Target != nil {
+		allErrs = append(allErrs, validateImmutable(b.Spec.Target.Ref, old.Spec.Target.Ref, fldPath.Child("target", "ref"))...)
+	}
+	return allErrs
+}
+
+// ValidateCreate validates a BackupBatch on creation
+func (b BackupBatch) ValidateCreate() field.ErrorList {
+	fldPath := field.NewPath("spec")
+	spec := b.Spec
+
+	allErrs := validateDriver(spec.Driver, fldPath.Child("driver"))
+	allErrs = append(allErrs, validateBackupSchedule(spec.GetBackupSchedule(), fldPath)...)
+	allErrs = append(allErrs, validateRepositoryRef(spec.Driver, spec.Repository, fldPath.Child("repository"))...)
+	allErrs = append(allErrs, spec.RetentionPolicy.Validate(fldPath.Child("retentionPolicy"))...)
+	allErrs = append(allErrs, validateHistoryLimit(spec.BackupHistoryLimit, fldPath.Child("backupHistoryLimit"))...)
+	allErrs = append(allErrs, validateBackupHooks(spec.Hooks, fldPath.Child("hooks"))...)
+	allErrs = append(allErrs, validateExecutionOrder(spec.ExecutionOrder, fldPath.Child("executionOrder"))...)
+	allErrs = append(allErrs, validateTimeOut(spec.TimeOut, fldPath.Child("timeOut"))...)
+	allErrs = append(allErrs, validateRetryConfig(spec.RetryConfig, fldPath.Child("retryConfig"))...)
+	allErrs = append(allErrs, validateFailurePolicy(spec.FailurePolicy, len(spec.Members), fldPath.Child("failurePolicy"))...)
+	allErrs = append(allErrs, validateConcurrencyPolicy(spec.ConcurrencyPolicy, fldPath.Child("concurrencyPolicy"))...)
+	allErrs = append(allErrs, validateTimeOut(spec.StartingDeadline, fldPath.Child("startingDeadline"))...)
+
+	membersPath := fldPath.Child("members")
+	if len(spec.Members) == 0 {
+		allErrs = append(allErrs, field.Required(membersPath, "at least one member must be specified"))
+	}
+	targets := make(map[string]bool)
+	for i, member := range spec.Members {
+		allErrs = append(allErrs, validateBackupTemplate(spec.Driver, member, membersPath.Index(i))...)
+		if member.TimeOut != nil && spec.TimeOut != nil && member.TimeOut.Duration > spec.TimeOut.Duration {
+			allErrs = append(allErrs, field.Invalid(membersPath.Index(i).Child("timeOut"), member.TimeOut.Duration.String(),
+				fmt.Sprintf("must not exceed the timeOut of the BackupBatch (%s)", spec.TimeOut.Duration)))
+		}
+		if member.Target == nil {
+			continue
+		}
+		key := targetKey(member.Target.Ref, b.Namespace)
+		if targets[key] {
+			allErrs = append(allErrs, field.Duplicate(membersPath.Index(i).Child("target", "ref"), member.Target.Ref))
+		}
+		targets[key] = true
+	}
+
+	refs, dependsOn := spec.memberDependencies()
+	allErrs = append(allErrs, validateMemberDependencies(refs, dependsOn, b.Namespace, membersPath)...)
+	return allErrs
+}
+
+// ValidateRepository validates the BackupBatch against the given Repository. The retention policy must keep the
+// snapshots that are locked by the immutability policy of the Repository.
+func (b BackupBatch) ValidateRepository(repo *v1alpha1.Repository) field.ErrorList {
+	return validateRetentionImmutability(repo, b.Namespace, b.Spec.Repository, b.Spec.RetentionPolicy, field.NewPath("spec", "retentionPolicy"))
+}
+
+// validateMemberDependencies ensures that the dependencies of the members of a batch refer to the other members
+// and the members do not depend on each other in a cycle
+func validateMemberDependencies(refs []*TargetRef, dependsOn [][]TargetRef, namespace string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	members := sets.New[string]()
+	for _, ref := range refs {
+		if ref != nil {
+			members.Insert(targetKey(*ref, namespace))
+		}
+	}
+	for i, deps := range dependsOn {
+		for j, dep := range deps {
+			depPath := fldPath.Index(i).Child("dependsOn").Index(j)
+			key := targetKey(dep, namespace)
+			switch {
+			case refs[i] != nil && key == targetKey(*refs[i], namespace):
+				allErrs = append(allErrs, field.Invalid(depPath, dep, "a member can not depend on itself"))
+			case !members.Has(key):
+				allErrs = append(allErrs, field.NotFound(depPath, dep))
+			}
+		}
+	}
+
+	graph := dependencyGraph(refs, dependsOn, namespace)
+	// the self dependencies have been reported already
+	for i := range graph {
+		graph[i] = slices.DeleteFunc(graph[i], func(k int) bool { return k == i })
+	}
+	if cycle := findCycle(graph); cycle != nil {
+		names := make([]string, 0, len(cycle))
+		for _, k := range cycle {
+			names = append(names, refs[k].Kind+"/"+refs[k].Name)
+		}
+		allErrs = append(allErrs, field.Invalid(fldPath.Index(cycle[0]).Child("dependsOn"), strings.Join(names, " -> "),
+			"members must not depend on each other in a cycle"))
+	}
+	return allErrs
+}
+
+// ValidateUpdate validates a BackupBatch on update. The driver can not be changed.
+func (b BackupBatch) ValidateUpdate(old *BackupBatch) field.ErrorList {
+	allErrs := b.ValidateCreate()
+	allErrs = append(allErrs, validateImmutable(b.Spec.Driver, old.Spec.Driver, field.NewPath("spec", "driver"))...)
+	return allErrs
+}
+
+// ValidateCreate validates a BackupBlueprint on creation
+func (b BackupBlueprint) ValidateCreate() field.ErrorList {
+	fldPath := field.NewPath("spec")
+	spec := b.Spec
+
+	allErrs := spec.RepositorySpec.Validate(fldPath)
+	// a templated schedule is validated when the blueprint is rendered for a target
+	if !paramRef.MatchString(spec.Schedule) {
+		allErrs = append(allErrs, validateBackupSchedule(spec.GetBackupSchedule(), fldPath)...)
+	}
+	allErrs = append(allErrs, validateTaskRef(ResticSnapshotter, spec.Task, fldPath.Child("task"))...)
+	allErrs = append(allErrs, spec.RetentionPolicy.Validate(fldPath.Child("retentionPolicy"))...)
+	allErrs = append(allErrs, validateBackupHooks(spec.Hooks, fldPath.Child("hooks"))...)
+	allErrs = append(allErrs, validateHistoryLimit(spec.BackupHistoryLimit, fldPath.Child("backupHistoryLimit"))...)
+	allErrs = append(allErrs, validateTimeOut(spec.TimeOut, fldPath.Child("timeOut"))...)
+	allErrs = append(allErrs, validateRetryConfig(spec.RetryConfig, fldPath.Child("retryConfig"))...)
+	allErrs = append(allErrs, validateBlueprintParams(spec.Params, fldPath.Child("params"))...)
+	allErrs = append(allErrs, validateBlueprintSelector(spec.Selector, fldPath.Child("selector"))...)
+	return allErrs
+}
+
+func validateBlueprintSelector(sel *BlueprintSelector, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if sel == nil {
+		return allErrs
+	}
+	if sel.ObjectSelector == nil && len(sel.Kinds) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "at least one of objectSelector or kinds must be specified"))
+	}
+	for _, s := range []struct {
+		name     string
+		selector *metav1.LabelSelector
+	}{
+		{"namespaceSelector", sel.NamespaceSelector},
+		{"objectSelector", sel.ObjectSelector},
+	} {
+		if s.selector == nil {
+			continue
+		}
+		if _, err := metav1.LabelSelectorAsSelector(s.selector); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(s.name), s.selector, err.Error()))
 		}
 	}
+	kinds := sets.New[string]()
+	for i, kind := range sel.Kinds {
+		switch {
+		case kind == "":
+			allErrs = append(allErrs, field.Required(fldPath.Child("kinds").Index(i), "kind must not be empty"))
+		case kinds.Has(strings.ToLower(kind)):
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("kinds").Index(i), kind))
+		}
+		kinds.Insert(strings.ToLower(kind))
+	}
+	return allErrs
+}
+
+// ValidateUpdate validates a BackupBlueprint on update
+func (b BackupBlueprint) ValidateUpdate(_ *BackupBlueprint) field.ErrorList {
+	return b.ValidateCreate()
+}
+
+// ValidateCreate validates a RestoreSession on creation
+func (r RestoreSession) ValidateCreate() field.ErrorList {
+	fldPath := field.NewPath("spec")
+	spec := r.Spec
+
+	allErrs := validateDriver(spec.Driver, fldPath.Child("driver"))
+	allErrs = append(allErrs, validateRepositoryRef(spec.Driver, spec.Repository, fldPath.Child("repository"))...)
+	allErrs = append(allErrs, validateRestoreTemplate(spec.Driver, spec.RestoreTargetSpec, fldPath)...)
+	allErrs = append(allErrs, validateRules(spec.Rules, fldPath.Child("rules"))...)
+	allErrs = append(allErrs, validateRestoreMappings(spec.Mappings, fldPath.Child("mappings"))...)
+	allErrs = append(allErrs, validateMappedTarget(spec.Target, spec.Mappings, r.Namespace, fldPath.Child("target"))...)
+	if len(spec.DependsOn) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("dependsOn"), "dependencies are only supported for the members of a RestoreBatch"))
+	}
+	if spec.RetryConfig != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("retryConfig"), "retry is only supported for the members of a RestoreBatch"))
+	}
+	return allErrs
+}
+
+// ValidateUpdate validates a RestoreSession on update. The driver, the repository, the target and the rules can not be changed.
+func (r RestoreSession) ValidateUpdate(old *RestoreSession) field.ErrorList {
+	fldPath := field.NewPath("spec")
+	allErrs := r.ValidateCreate()
+	allErrs = append(allErrs, validateImmutable(r.Spec.Driver, old.Spec.Driver, fldPath.Child("driver"))...)
+	allErrs = append(allErrs, validateImmutable(r.Spec.Repository, old.Spec.Repository, fldPath.Child("repository"))...)
+	allErrs = append(allErrs, validateImmutable(r.Spec.Rules, old.Spec.Rules, fldPath.Child("rules"))...)
+	if r.Spec.Target != nil && old.Spec.Target != nil {
+		allErrs = append(allErrs, validateImmutable(r.Spec.Target.Ref, old.Spec.Target.Ref, fldPath.Child("target", "ref"))...)
+		allErrs = append(allErrs, validateImmutable(r.Spec.Target.Rules, old.Spec.Target.Rules, fldPath.Child("target", "rules"))...)
+	}
+	return allErrs
+}
+
+// ValidateCreate validates a RestoreBatch on creation
+func (r RestoreBatch) ValidateCreate() field.ErrorList {
+	fldPath := field.NewPath("spec")
+	spec := r.Spec
+
+	allErrs := validateDriver(spec.Driver, fldPath.Child("driver"))
+	allErrs = append(allErrs, validateRepositoryRef(spec.Driver, spec.Repository, fldPath.Child("repository"))...)
+	allErrs = append(allErrs, validateExecutionOrder(spec.ExecutionOrder, fldPath.Child("executionOrder"))...)
+	allErrs = append(allErrs, validateRestoreHooks(spec.Hooks, fldPath.Child("hooks"))...)
+	allErrs = append(allErrs, validateTimeOut(spec.TimeOut, fldPath.Child("timeOut"))...)
+	allErrs = append(allErrs, validateRestoreMappings(spec.Mappings, fldPath.Child("mappings"))...)
+	allErrs = append(allErrs, validateFailurePolicy(spec.FailurePolicy, len(spec.Members), fldPath.Child("failurePolicy"))...)
+
+	membersPath := fldPath.Child("members")
+	if len(spec.Members) == 0 {
+		allErrs = append(allErrs, field.Required(membersPath, "at least one member must be specified"))
+	}
+	targets := make(map[string]bool)
+	destinations := make(map[string]bool)
+	for i, member := range spec.Members {
+		allErrs = append(allErrs, validateRestoreTemplate(spec.Driver, member, membersPath.Index(i))...)
+		if member.TimeOut != nil && spec.TimeOut != nil && member.TimeOut.Duration > spec.TimeOut.Duration {
+			allErrs = append(allErrs, field.Invalid(membersPath.Index(i).Child("timeOut"), member.TimeOut.Duration.String(),
+				fmt.Sprintf("must not exceed the timeOut of the RestoreBatch (%s)", spec.TimeOut.Duration)))
+		}
+		if member.Target == nil {
+			continue
+		}
+		key := targetKey(member.Target.Ref, r.Namespace)
+		if targets[key] {
+			allErrs = append(allErrs, field.Duplicate(membersPath.Index(i).Child("target", "ref"), member.Target.Ref))
+		}
+		targets[key] = true
+
+		allErrs = append(allErrs, validateMappedTarget(member.Target, spec.Mappings, r.Namespace, membersPath.Index(i).Child("target"))...)
+		dest := targetKey(mappedTargetRef(member.Target, spec.Mappings, r.Namespace), r.Namespace)
+		if len(spec.Mappings) > 0 && destinations[dest] {
+			allErrs = append(allErrs, field.Invalid(membersPath.Index(i).Child("target", "ref"), member.Target.Ref, "another member is mapped to the same destination"))
+		}
+		destinations[dest] = true
+	}
+
+	refs, dependsOn := spec.memberDependencies()
+	allErrs = append(allErrs, validateMemberDependencies(refs, dependsOn, r.Namespace, membersPath)...)
+	return allErrs
+}
+
+// ValidateUpdate validates a RestoreBatch on update. The driver, the repository and the members can not be changed.
+func (r RestoreBatch) ValidateUpdate(old *RestoreBatch) field.ErrorList {
+	fldPath := field.NewPath("spec")
+	allErrs := r.ValidateCreate()
+	allErrs = append(allErrs, validateImmutable(r.Spec.Driver, old.Spec.Driver, fldPath.Child("driver"))...)
+	allErrs = append(allErrs, validateImmutable(r.Spec.Repository, old.Spec.Repository, fldPath.Child("repository"))...)
+	allErrs = append(allErrs, validateImmutable(r.Spec.Members, old.Spec.Members, fldPath.Child("members"))...)
+	return allErrs
+}
+
+// ValidateCreate validates a Task on creation
+func (t Task) ValidateCreate() field.ErrorList {
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("spec")
+
+	stepsPath := fldPath.Child("steps")
+	if len(t.Spec.Steps) == 0 {
+		allErrs = append(allErrs, field.Required(stepsPath, "at least one step must be specified"))
+	}
+	for i, step := range t.Spec.Steps {
+		if step.Name == "" {
+			allErrs = append(allErrs, field.Required(stepsPath.Index(i).Child("name"), "name of the Function must be specified"))
+		}
+		allErrs = append(allErrs, validateParams(step.Params, stepsPath.Index(i).Child("params"))...)
+	}
+
+	volumes := sets.New[string]()
+	for i, vol := range t.Spec.Volumes {
+		if volumes.Has(vol.Name) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("volumes").Index(i).Child("name"), vol.Name))
+		}
+		volumes.Insert(vol.Name)
+	}
+	return allErrs
+}
+
+// ValidateUpdate validates a Task on update
+func (t Task) ValidateUpdate(_ *Task) field.ErrorList {
+	return t.ValidateCreate()
+}
+
+// ValidateCreate validates a Function on creation
+func (f Function) ValidateCreate() field.ErrorList {
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("spec")
+
+	if f.Spec.Image == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("image"), "image must be specified"))
+	}
+	mountPaths := sets.New[string]()
+	for i, mnt := range f.Spec.VolumeMounts {
+		if mountPaths.Has(mnt.MountPath) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("volumeMounts").Index(i).Child("mountPath"), mnt.MountPath))
+		}
+		mountPaths.Insert(mnt.MountPath)
+	}
+	return allErrs
+}
+
+// ValidateUpdate validates a Function on update
+func (f Function) ValidateUpdate(_ *Function) field.ErrorList {
+	return f.ValidateCreate()
+}
+
+func validateBackupTemplate(driver Snapshotter, spec BackupConfigurationTemplateSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := validateTaskRef(driver, spec.Task, fldPath.Child("task"))
+	allErrs = append(allErrs, validateBackupTarget(driver, spec.Target, fldPath.Child("target"))...)
+	allErrs = append(allErrs, validateBackupHooks(spec.Hooks, fldPath.Child("hooks"))...)
+	allErrs = append(allErrs, validateTimeOut(spec.TimeOut, fldPath.Child("timeOut"))...)
+	allErrs = append(allErrs, validateRetryConfig(spec.RetryConfig, fldPath.Child("retryConfig"))...)
+	return allErrs
+}
+
+func validateRestoreTemplate(driver Snapshotter, spec RestoreTargetSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := validateTaskRef(driver, spec.Task, fldPath.Child("task"))
+	allErrs = append(allErrs, validateRestoreTarget(driver, spec.Target, fldPath.Child("target"))...)
+	allErrs = append(allErrs, validateRestoreHooks(spec.Hooks, fldPath.Child("hooks"))...)
+	allErrs = append(allErrs, validateTimeOut(spec.TimeOut, fldPath.Child("timeOut"))...)
+	allErrs = append(allErrs, validateRetryConfig(spec.RetryConfig, fldPath.Child("retryConfig"))...)
+	return allErrs
+}
+
+func validateDriver(driver Snapshotter, fldPath *field.Path) field.ErrorList {
+	switch driver {
+	case "", ResticSnapshotter, VolumeSnapshotter:
+		return nil
+	default:
+		return field.ErrorList{field.NotSupported(fldPath, driver, []Snapshotter{ResticSnapshotter, VolumeSnapshotter})}
+	}
+}
+
+func validateSchedule(schedule string, fldPath *field.Path) field.ErrorList {
+	if schedule == "" {
+		return nil
+	}
+	if _, err := cron.ParseStandard(schedule); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, schedule, fmt.Sprintf("invalid cron expression: %s", err))}
+	}
+	return nil
+}
+
+// validateBackupSchedule validates the schedule along with its time zone.
+// The time zone can not be specified both in the timeZone field and in the schedule using "CRON_TZ=" prefix.
+func validateBackupSchedule(schedule BackupSchedule, fldPath *field.Path) field.ErrorList {
+	allErrs := validateSchedule(schedule.Cron, fldPath.Child("schedule"))
+	allErrs = append(allErrs, validateTimeZone(schedule.TimeZone, fldPath.Child("timeZone"))...)
+	if schedule.TimeZone != "" && hasTimeZonePrefix(schedule.Cron) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("timeZone"), "can not be used when the schedule specifies a time zone"))
+	}
+	return allErrs
+}
+
+// validateRepositoryRef ensures that the Repository has been specified for the Restic driver.
+// VolumeSnapshotter driver does not use any Repository.
+func validateRepositoryRef(driver Snapshotter, repo kmapi.ObjectReference, fldPath *field.Path) field.ErrorList {
+	if driver == VolumeSnapshotter {
+		return nil
+	}
+	if repo.Name == "" {
+		return field.ErrorList{field.Required(fldPath.Child("name"), "repository must be specified for Restic driver")}
+	}
 	return nil
 }
 
-func multipleRuleWithEmptyTargetHostError(ruleIndexes []int) string {
-	ids := ""
-	for i, idx := range ruleIndexes {
-		ids += fmt.Sprintf("rule[%d]", idx)
-		if i < len(ruleIndexes)-1 {
-			ids += ", "
+func validateTaskRef(driver Snapshotter, task TaskRef, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if driver == VolumeSnapshotter && task.Name != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("name"), "task is not used by VolumeSnapshotter driver"))
+	}
+	allErrs = append(allErrs, validateParams(task.Params, fldPath.Child("params"))...)
+	return allErrs
+}
+
+func validateParams(params []Param, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	names := sets.New[string]()
+	for i, param := range params {
+		if param.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("name"), "name of the parameter must be specified"))
+			continue
+		}
+		if names.Has(param.Name) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), param.Name))
+		}
+		names.Insert(param.Name)
+	}
+	return allErrs
+}
+
+func validateTargetRef(ref TargetRef, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if ref.Kind == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("kind"), "kind of the target must be specified"))
+	}
+	if ref.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), "name of the target must be specified"))
+	}
+	if ref.APIVersion != "" {
+		if _, err := schema.ParseGroupVersion(ref.APIVersion); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("apiVersion"), ref.APIVersion, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+func validateBackupTarget(driver Snapshotter, target *BackupTarget, fldPath *field.Path) field.ErrorList {
+	if target == nil {
+		return field.ErrorList{field.Required(fldPath, "backup target must be specified")}
+	}
+	allErrs := validateTargetRef(target.Ref, fldPath.Child("ref"))
+	if target.Replicas != nil && *target.Replicas < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("replicas"), *target.Replicas, "must not be negative"))
+	}
+
+	if driver == VolumeSnapshotter {
+		if !volumeSnapshotterSupports(target.Ref.Kind) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ref", "kind"), target.Ref.Kind, "target kind is not supported by VolumeSnapshotter driver"))
+		}
+		if len(target.Paths) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("paths"), "paths are not supported by VolumeSnapshotter driver"))
+		}
+		if len(target.Exclude) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("exclude"), "exclude patterns are supported only by Restic driver"))
+		}
+	} else if target.VolumeSnapshotClassName != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("snapshotClassName"), "snapshotClassName is supported only by VolumeSnapshotter driver"))
+	}
+	return allErrs
+}
+
+func validateRestoreTarget(driver Snapshotter, target *RestoreTarget, fldPath *field.Path) field.ErrorList {
+	if target == nil {
+		return field.ErrorList{field.Required(fldPath, "restore target must be specified")}
+	}
+	allErrs := validateTargetRef(target.Ref, fldPath.Child("ref"))
+	if target.Replicas != nil && *target.Replicas < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("replicas"), *target.Replicas, "must not be negative"))
+	}
+	allErrs = append(allErrs, validateRules(target.Rules, fldPath.Child("rules"))...)
+
+	if driver == VolumeSnapshotter {
+		if len(target.VolumeClaimTemplates) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("volumeClaimTemplates"), "volumeClaimTemplates must be specified for VolumeSnapshotter driver"))
+		}
+		for i, rule := range target.Rules {
+			if len(rule.Include) > 0 || len(rule.Exclude) > 0 {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("rules").Index(i), "include and exclude patterns are supported only by Restic driver"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateRules ensures that at most one rule has empty targetHosts, no two rules match for the same host
+// and a rule does not specify both snapshots and paths.
+func validateRules(rules []Rule, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	emptyTargetHosts := -1
+	hosts := make(map[string]int)
+	for i, rule := range rules {
+		if len(rule.TargetHosts) == 0 {
+			if emptyTargetHosts >= 0 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("targetHosts"), rule.TargetHosts,
+					fmt.Sprintf("there can be at most one rule with empty targetHosts, rule[%d] already has empty targetHosts", emptyTargetHosts)))
+			} else {
+				emptyTargetHosts = i
+			}
+		}
+		for _, host := range rule.TargetHosts {
+			if j, ok := hosts[host]; ok {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("targetHosts"), host,
+					fmt.Sprintf("host is already matched by rule[%d]", j)))
+				continue
+			}
+			hosts[host] = i
+		}
+		if len(rule.Snapshots) > 0 && len(rule.Paths) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("paths"), "paths can not be specified along with snapshots"))
+		}
+		allErrs = append(allErrs, validateSnapshotSelectors(rule, fldPath.Index(i))...)
+		allErrs = append(allErrs, validatePathRewrites(rule.PathRewrites, fldPath.Index(i).Child("pathRewrites"))...)
+	}
+	return allErrs
+}
+
+// validateRestoreMappings ensures that every mapping specifies a destination and no two mappings have the same source
+func validateRestoreMappings(mappings []RestoreMapping, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	sources := make(map[RestoreMappingRef]int)
+	for i, m := range mappings {
+		idxPath := fldPath.Index(i)
+		if m.Source == (RestoreMappingRef{}) {
+			allErrs = append(allErrs, field.Required(idxPath.Child("source"), "at least one of namespace, name or host must be specified"))
+		}
+		if m.Target == (RestoreMappingRef{}) && len(m.PathRewrites) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("target"), "either target or pathRewrites must be specified"))
+		}
+		if m.Target.Name != "" && m.Source.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("source", "name"), "source name must be specified to map the name"))
+		}
+		if m.Target.Host != "" && m.Source.Host == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("source", "host"), "source host must be specified to map the host"))
+		}
+		if j, ok := sources[m.Source]; ok {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("source"), m.Source, fmt.Sprintf("source is already mapped by mappings[%d]", j)))
+		} else {
+			sources[m.Source] = i
+		}
+		allErrs = append(allErrs, validatePathRewrites(m.PathRewrites, idxPath.Child("pathRewrites"))...)
+	}
+	return allErrs
+}
+
+func validatePathRewrites(rewrites []PathRewrite, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	from := sets.New[string]()
+	for i, r := range rewrites {
+		idxPath := fldPath.Index(i)
+		if !path.IsAbs(r.From) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("from"), r.From, "must be an absolute path"))
+		} else if from.Has(path.Clean(r.From)) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("from"), r.From))
+		} else {
+			from.Insert(path.Clean(r.From))
+		}
+		if !path.IsAbs(r.To) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("to"), r.To, "must be an absolute path"))
+		}
+	}
+	return allErrs
+}
+
+// validateMappedTarget ensures that the rules of the target remain valid after applying the mappings.
+// The errors of the original rules are reported by validateRules, so they are not checked here.
+func validateMappedTarget(target *RestoreTarget, mappings []RestoreMapping, namespace string, fldPath *field.Path) field.ErrorList {
+	if target == nil || len(mappings) == 0 || len(validateRules(target.Rules, fldPath.Child("rules"))) > 0 {
+		return nil
+	}
+	t := target.DeepCopy()
+	if t.Ref.Namespace == "" {
+		t.Ref.Namespace = namespace
+	}
+	return validateRules(ApplyRestoreMappings(t, mappings).Rules, fldPath.Child("rules"))
+}
+
+// mappedTargetRef returns the destination of the target after applying the mappings
+func mappedTargetRef(target *RestoreTarget, mappings []RestoreMapping, namespace string) TargetRef {
+	t := target.DeepCopy()
+	if t.Ref.Namespace == "" {
+		t.Ref.Namespace = namespace
+	}
+	return ApplyRestoreMappings(t, mappings).Ref
+}
+
+// validateSnapshotSelectors ensures that the time and tag selectors of a rule are not conflicting
+func validateSnapshotSelectors(rule Rule, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(rule.Snapshots) > 0 {
+		selectors := []struct {
+			name string
+			set  bool
+		}{
+			{"restoreTime", rule.RestoreTime != nil},
+			{"before", rule.Before != nil},
+			{"after", rule.After != nil},
+			{"tags", len(rule.Tags) > 0},
+		}
+		for _, selector := range selectors {
+			if selector.set {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child(selector.name), selector.name+" can not be specified along with snapshots"))
+			}
+		}
+	}
+	if rule.RestoreTime != nil && rule.Before != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("before"), "before can not be specified along with restoreTime"))
+	}
+	upper := rule.Before
+	if rule.RestoreTime != nil {
+		upper = rule.RestoreTime
+	}
+	if upper != nil && rule.After != nil && !rule.After.Before(upper) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("after"), rule.After.String(), "must be earlier than restoreTime or before"))
+	}
+	for i, tag := range rule.Tags {
+		if tag == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("tags").Index(i), "tag must not be empty"))
+		}
+	}
+	return allErrs
+}
+
+func validateBackupHooks(hooks *BackupHooks, fldPath *field.Path) field.ErrorList {
+	if hooks == nil || hooks.PostBackup == nil {
+		return nil
+	}
+	switch hooks.PostBackup.ExecutionPolicy {
+	case "", ExecuteAlways, ExecuteOnFailure, ExecuteOnSuccess, ExecuteOnPartialSuccess, ExecuteOnRetryFailure:
+		return nil
+	default:
+		return field.ErrorList{field.NotSupported(fldPath.Child("postBackup", "executionPolicy"), hooks.PostBackup.ExecutionPolicy,
+			[]HookExecutionPolicy{ExecuteAlways, ExecuteOnFailure, ExecuteOnSuccess, ExecuteOnPartialSuccess, ExecuteOnRetryFailure})}
+	}
+}
+
+func validateRestoreHooks(hooks *RestoreHooks, fldPath *field.Path) field.ErrorList {
+	if hooks == nil || hooks.PostRestore == nil {
+		return nil
+	}
+	switch hooks.PostRestore.ExecutionPolicy {
+	case "", ExecuteAlways, ExecuteOnFailure, ExecuteOnSuccess, ExecuteOnPartialSuccess:
+		return nil
+	default:
+		return field.ErrorList{field.NotSupported(fldPath.Child("postRestore", "executionPolicy"), hooks.PostRestore.ExecutionPolicy,
+			[]HookExecutionPolicy{ExecuteAlways, ExecuteOnFailure, ExecuteOnSuccess, ExecuteOnPartialSuccess})}
+	}
+}
+
+func validateExecutionOrder(order ExecutionOrder, fldPath *field.Path) field.ErrorList {
+	switch order {
+	case "", Parallel, Sequential:
+		return nil
+	default:
+		return field.ErrorList{field.NotSupported(fldPath, order, []ExecutionOrder{Parallel, Sequential})}
+	}
+}
+
+func validateFailurePolicy(policy *FailurePolicy, totalMembers int, fldPath *field.Path) field.ErrorList {
+	if policy == nil {
+		return nil
+	}
+	switch policy.Type {
+	case "", FailFast, ContinueOnError:
+		if policy.MinSuccessful != nil {
+			return field.ErrorList{field.Forbidden(fldPath.Child("minSuccessful"), fmt.Sprintf("may only be set when the type is %q", MinSuccessful))}
+		}
+	case MinSuccessful:
+		switch {
+		case policy.MinSuccessful == nil:
+			return field.ErrorList{field.Required(fldPath.Child("minSuccessful"), fmt.Sprintf("must be set when the type is %q", MinSuccessful))}
+		case *policy.MinSuccessful < 1:
+			return field.ErrorList{field.Invalid(fldPath.Child("minSuccessful"), *policy.MinSuccessful, "must be positive")}
+		case int(*policy.MinSuccessful) > totalMembers:
+			return field.ErrorList{field.Invalid(fldPath.Child("minSuccessful"), *policy.MinSuccessful, fmt.Sprintf("must not exceed the number of members (%d)", totalMembers))}
+		}
+	default:
+		return field.ErrorList{field.NotSupported(fldPath.Child("type"), policy.Type, []FailurePolicyType{FailFast, ContinueOnError, MinSuccessful})}
+	}
+	return nil
+}
+
+func validateHistoryLimit(limit *int32, fldPath *field.Path) field.ErrorList {
+	if limit != nil && *limit < 0 {
+		return field.ErrorList{field.Invalid(fldPath, *limit, "must not be negative")}
+	}
+	return nil
+}
+
+func validateTimeOut(timeOut *metav1.Duration, fldPath *field.Path) field.ErrorList {
+	if timeOut != nil && timeOut.Duration <= 0 {
+		return field.ErrorList{field.Invalid(fldPath, timeOut.Duration.String(), "must be a positive duration")}
+	}
+	return nil
+}
+
+func validateConcurrencyPolicy(policy ConcurrencyPolicy, fldPath *field.Path) field.ErrorList {
+	switch policy {
+	case "", AllowConcurrent, ForbidConcurrent, ReplaceConcurrent, QueueConcurrent:
+		return nil
+	default:
+		return field.ErrorList{field.NotSupported(fldPath, policy, []ConcurrencyPolicy{AllowConcurrent, ForbidConcurrent, ReplaceConcurrent, QueueConcurrent})}
+	}
+}
+
+func validateRetryConfig(rc *RetryConfig, fldPath *field.Path) field.ErrorList {
+	if rc == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	if rc.MaxRetry < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxRetry"), rc.MaxRetry, "must not be negative"))
+	}
+	if rc.Delay.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("delay"), rc.Delay.Duration.String(), "must not be negative"))
+	}
+	return allErrs
+}
+
+func validateBackupWindow(window *BackupWindow, fldPath *field.Path) field.ErrorList {
+	if window == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	start, err := time.Parse(windowTimeFormat, window.Start)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("start"), window.Start, "must be in HH:MM format"))
+	}
+	end, err2 := time.Parse(windowTimeFormat, window.End)
+	if err2 != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("end"), window.End, "must be in HH:MM format"))
+	}
+	if err == nil && err2 == nil && start.Equal(end) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("end"), window.End, "must be different from start"))
+	}
+	allErrs = append(allErrs, validateTimeZone(window.TimeZone, fldPath.Child("timeZone"))...)
+	return allErrs
+}
+
+func validateBlackouts(blackouts []BlackoutPeriod, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, blackout := range blackouts {
+		idxPath := fldPath.Index(i)
+		fixed := blackout.Start != nil || blackout.End != nil
+		recurring := blackout.Schedule != "" || blackout.Duration != nil
+		switch {
+		case fixed && recurring:
+			allErrs = append(allErrs, field.Forbidden(idxPath, "specify either start and end, or schedule and duration"))
+		case fixed:
+			if blackout.Start == nil {
+				allErrs = append(allErrs, field.Required(idxPath.Child("start"), "start must be specified along with end"))
+			} else if blackout.End == nil {
+				allErrs = append(allErrs, field.Required(idxPath.Child("end"), "end must be specified along with start"))
+			} else if !blackout.End.After(blackout.Start.Time) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("end"), blackout.End, "must be after start"))
+			}
+		case recurring:
+			if blackout.Schedule == "" {
+				allErrs = append(allErrs, field.Required(idxPath.Child("schedule"), "schedule must be specified along with duration"))
+			}
+			allErrs = append(allErrs, validateSchedule(blackout.Schedule, idxPath.Child("schedule"))...)
+			if blackout.Duration == nil {
+				allErrs = append(allErrs, field.Required(idxPath.Child("duration"), "duration must be specified along with schedule"))
+			} else {
+				allErrs = append(allErrs, validateTimeOut(blackout.Duration, idxPath.Child("duration"))...)
+			}
+		default:
+			allErrs = append(allErrs, field.Required(idxPath, "either start and end, or schedule and duration must be specified"))
 		}
+		allErrs = append(allErrs, validateTimeZone(blackout.TimeZone, idxPath.Child("timeZone"))...)
+	}
+	return allErrs
+}
+
+func validateTimeZone(tz string, fldPath *field.Path) field.ErrorList {
+	if tz == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, tz, "unknown time zone")}
+	}
+	return nil
+}
+
+func validateImmutable(newVal, oldVal any, fldPath *field.Path) field.ErrorList {
+	if !equality.Semantic.DeepEqual(newVal, oldVal) {
+		return field.ErrorList{field.Invalid(fldPath, newVal, "field is immutable")}
+	}
+	return nil
+}
+
+// targetKey identifies a target irrespective of the version of its API. Empty namespace refers to the invoker namespace.
+func targetKey(ref TargetRef, namespace string) string {
+	group := ""
+	if gv, err := schema.ParseGroupVersion(ref.APIVersion); err == nil {
+		group = gv.Group
+	}
+	if ref.Namespace != "" {
+		namespace = ref.Namespace
+	}
+	return fmt.Sprintf("%s/%s/%s/%s", group, ref.Kind, namespace, ref.Name)
+}
+
+func volumeSnapshotterSupports(kind string) bool {
+	switch kind {
+	case apis.KindDeployment, apis.KindStatefulSet, apis.KindDaemonSet, apis.KindReplicaSet,
+		apis.KindReplicationController, apis.KindDeploymentConfig, apis.KindPersistentVolumeClaim:
+		return true
 	}
-	return fmt.Sprintf("%d rules found with empty targetHosts (Rules: %s)", len(ruleIndexes), ids)
+	return false
 }
@@ -0,0 +1,797 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+
+	"gomodules.xyz/pointer"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	kmapi "kmodules.xyz/client-go/api/v1"
+	store "kmodules.xyz/objectstore-api/api/v1"
+	prober "kmodules.xyz/prober/api/v1"
+)
+
+func TestBackupBatch_ValidateCreate(t *testing.T) {
+	member := func(apiVersion, name string) BackupConfigurationTemplateSpec {
+		return BackupConfigurationTemplateSpec{
+			Target: &BackupTarget{
+				Ref: TargetRef{
+					APIVersion: apiVersion,
+					Kind:       "Deployment",
+					Name:       name,
+				},
+			},
+		}
+	}
+	validSpec := func() BackupBatchSpec {
+		return BackupBatchSpec{
+			Schedule:   "*/5 * * * *",
+			Driver:     ResticSnapshotter,
+			Repository: kmapi.ObjectReference{Name: "repo"},
+			Members: []BackupConfigurationTemplateSpec{
+				member("apps/v1", "app-1"),
+				member("apps/v1", "app-2"),
+			},
+			RetentionPolicy: v1alpha1.RetentionPolicy{
+				Name:     "keep-last-5",
+				KeepLast: 5,
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(spec *BackupBatchSpec)
+		want   []string
+	}{
+		{
+			name:   "Valid BackupBatch",
+			mutate: func(spec *BackupBatchSpec) {},
+			want:   nil,
+		},
+		{
+			name: "Invalid cron expression",
+			mutate: func(spec *BackupBatchSpec) {
+				spec.Schedule = "*/5 * * *"
+			},
+			want: []string{"spec.schedule"},
+		},
+		{
+			name: "Same target referred with different API versions",
+			mutate: func(spec *BackupBatchSpec) {
+				spec.Members = append(spec.Members, member("apps/v1beta1", "app-1"))
+			},
+			want: []string{"spec.members[2].target.ref"},
+		},
+		{
+			name: "Negative retention rule",
+			mutate: func(spec *BackupBatchSpec) {
+				spec.RetentionPolicy.KeepDaily = -1
+			},
+			want: []string{"spec.retentionPolicy.keepDaily"},
+		},
+		{
+			name: "Missing repository for Restic driver",
+			mutate: func(spec *BackupBatchSpec) {
+				spec.Repository = kmapi.ObjectReference{}
+			},
+			want: []string{"spec.repository.name"},
+		},
+		{
+			name: "Exclude patterns with VolumeSnapshotter driver",
+			mutate: func(spec *BackupBatchSpec) {
+				spec.Driver = VolumeSnapshotter
+				spec.Members[0].Target.Exclude = []string{"*.tmp"}
+			},
+			want: []string{"spec.members[0].target.exclude"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := validSpec()
+			tt.mutate(&spec)
+			b := BackupBatch{Spec: spec}
+			if got := errorFields(b.ValidateCreate()); !slices.Equal(got, tt.want) {
+				t.Errorf("ValidateCreate() error fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func errorFields(errs field.ErrorList) []string {
+	var fields []string
+	for _, err := range errs {
+		fields = append(fields, err.Field)
+	}
+	return fields
+}
+
+func TestValidateScheduledPolicies(t *testing.T) {
+	policies := []ScheduledPolicy{
+		{Name: "hourly", Schedule: "0 * * * *"},
+		{Name: "weekly", Schedule: "0 0 * * 0", Tag: "offsite", Repository: &kmapi.ObjectReference{Name: "offsite-repo"}},
+		{Name: "hourly", Schedule: "30 * * * *", Tag: "hourly-2"},
+		{Name: "daily", Schedule: "CRON_TZ=Asia/Dhaka 0 0 * * *", Tag: "offsite"},
+		{Name: "monthly", Repository: &kmapi.ObjectReference{}, RetentionPolicy: &v1alpha1.RetentionPolicy{KeepMonthly: -1}},
+	}
+	want := []string{
+		"spec.schedules[2].name",
+		"spec.schedules[3].tag",
+		"spec.schedules[3].schedule",
+		"spec.schedules[4].schedule",
+		"spec.schedules[4].repository.name",
+		"spec.schedules[4].retentionPolicy.keepMonthly",
+	}
+	got := errorFields(validateScheduledPolicies(ResticSnapshotter, policies, "UTC", field.NewPath("spec", "schedules")))
+	if !slices.Equal(got, want) {
+		t.Errorf("validateScheduledPolicies() error fields = %v, want %v", got, want)
+	}
+}
+
+func TestBackupConfiguration_ValidateRepository(t *testing.T) {
+	repo := &v1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{Name: "local-repo", Namespace: "demo"},
+		Spec: v1alpha1.RepositorySpec{
+			Immutability: &v1alpha1.ImmutabilityPolicy{RetentionPeriod: metav1.Duration{Duration: 7 * 24 * time.Hour}},
+		},
+	}
+	bc := BackupConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "sample-backup", Namespace: "demo"},
+		Spec: BackupConfigurationSpec{
+			Repository:      kmapi.ObjectReference{Name: "local-repo"},
+			RetentionPolicy: v1alpha1.RetentionPolicy{KeepLast: 5},
+			Schedules: []ScheduledPolicy{
+				{Name: "daily", RetentionPolicy: &v1alpha1.RetentionPolicy{KeepWithin: &metav1.Duration{Duration: 24 * time.Hour}}},
+				{Name: "weekly", RetentionPolicy: &v1alpha1.RetentionPolicy{KeepWithin: &metav1.Duration{Duration: 30 * 24 * time.Hour}}},
+				{Name: "offsite", Repository: &kmapi.ObjectReference{Name: "offsite-repo"}, RetentionPolicy: &v1alpha1.RetentionPolicy{KeepLast: 1}},
+			},
+		},
+	}
+	want := []string{
+		"spec.retentionPolicy.keepWithin",
+		"spec.schedules[0].retentionPolicy.keepWithin",
+	}
+	if got := errorFields(bc.ValidateRepository(repo)); !slices.Equal(got, want) {
+		t.Errorf("ValidateRepository() error fields = %v, want %v", got, want)
+	}
+
+	repo.Spec.Immutability = nil
+	if got := errorFields(bc.ValidateRepository(repo)); len(got) != 0 {
+		t.Errorf("ValidateRepository() error fields = %v, want none for a mutable repository", got)
+	}
+}
+
+func TestValidateMemberDependencies(t *testing.T) {
+	ref := func(name string) *TargetRef {
+		return &TargetRef{APIVersion: "apps/v1", Kind: "Deployment", Name: name}
+	}
+	tests := []struct {
+		name      string
+		refs      []*TargetRef
+		dependsOn [][]TargetRef
+		want      []string
+	}{
+		{
+			name:      "Valid dependencies",
+			refs:      []*TargetRef{ref("app"), ref("db"), ref("cache")},
+			dependsOn: [][]TargetRef{{*ref("db"), *ref("cache")}, nil, nil},
+		},
+		{
+			name:      "Unknown and self dependency",
+			refs:      []*TargetRef{ref("app"), ref("db")},
+			dependsOn: [][]TargetRef{{*ref("app"), *ref("cache")}, nil},
+			want:      []string{"spec.members[0].dependsOn[0]", "spec.members[0].dependsOn[1]"},
+		},
+		{
+			name:      "Cycle",
+			refs:      []*TargetRef{ref("app"), ref("db"), ref("cache")},
+			dependsOn: [][]TargetRef{{*ref("db")}, {*ref("cache")}, {*ref("app")}},
+			want:      []string{"spec.members[0].dependsOn"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := errorFields(validateMemberDependencies(tt.refs, tt.dependsOn, "demo", field.NewPath("spec", "members")))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("validateMemberDependencies() error fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateFailurePolicy(t *testing.T) {
+	minSuccessful := func(n int32) *int32 { return &n }
+	tests := []struct {
+		name   string
+		policy *FailurePolicy
+		want   []string
+	}{
+		{
+			name: "Default policy",
+		},
+		{
+			name:   "Valid minimum successful members",
+			policy: &FailurePolicy{Type: MinSuccessful, MinSuccessful: minSuccessful(2)},
+		},
+		{
+			name:   "Unsupported type",
+			policy: &FailurePolicy{Type: "Ignore"},
+			want:   []string{"spec.failurePolicy.type"},
+		},
+		{
+			name:   "Minimum successful members for continue on error",
+			policy: &FailurePolicy{Type: ContinueOnError, MinSuccessful: minSuccessful(1)},
+			want:   []string{"spec.failurePolicy.minSuccessful"},
+		},
+		{
+			name:   "Missing minimum successful members",
+			policy: &FailurePolicy{Type: MinSuccessful},
+			want:   []string{"spec.failurePolicy.minSuccessful"},
+		},
+		{
+			name:   "Minimum successful members exceeds the members",
+			policy: &FailurePolicy{Type: MinSuccessful, MinSuccessful: minSuccessful(4)},
+			want:   []string{"spec.failurePolicy.minSuccessful"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := errorFields(validateFailurePolicy(tt.policy, 3, field.NewPath("spec", "failurePolicy")))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("validateFailurePolicy() error fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateBackupBatchMemberTimeOut(t *testing.T) {
+	member := func(timeOut time.Duration) BackupConfigurationTemplateSpec {
+		return BackupConfigurationTemplateSpec{TimeOut: &metav1.Duration{Duration: timeOut}}
+	}
+	bb := BackupBatch{
+		Spec: BackupBatchSpec{
+			TimeOut: &metav1.Duration{Duration: time.Hour},
+			Members: []BackupConfigurationTemplateSpec{member(30 * time.Minute), member(2 * time.Hour), member(-time.Minute)},
+		},
+	}
+	got := errorFields(bb.ValidateCreate())
+	for _, want := range []string{"spec.members[1].timeOut", "spec.members[2].timeOut"} {
+		if !slices.Contains(got, want) {
+			t.Errorf("ValidateCreate() error fields = %v, want %s", got, want)
+		}
+	}
+	if slices.Contains(got, "spec.members[0].timeOut") {
+		t.Errorf("ValidateCreate() error fields = %v, want no error for spec.members[0].timeOut", got)
+	}
+}
+
+func TestValidateRestoreMemberTimeOut(t *testing.T) {
+	member := func(timeOut time.Duration) RestoreTargetSpec {
+		return RestoreTargetSpec{TimeOut: &metav1.Duration{Duration: timeOut}, RetryConfig: &RetryConfig{MaxRetry: 1}}
+	}
+	rb := RestoreBatch{
+		Spec: RestoreBatchSpec{
+			TimeOut: &metav1.Duration{Duration: time.Hour},
+			Members: []RestoreTargetSpec{member(30 * time.Minute), member(2 * time.Hour), member(-time.Minute)},
+		},
+	}
+	got := errorFields(rb.ValidateCreate())
+	for _, want := range []string{"spec.members[1].timeOut", "spec.members[2].timeOut"} {
+		if !slices.Contains(got, want) {
+			t.Errorf("ValidateCreate() error fields = %v, want %s", got, want)
+		}
+	}
+	if slices.Contains(got, "spec.members[0].timeOut") || slices.Contains(got, "spec.members[0].retryConfig") {
+		t.Errorf("ValidateCreate() error fields = %v, want no error for spec.members[0]", got)
+	}
+
+	rs := RestoreSession{Spec: RestoreSessionSpec{RestoreTargetSpec: member(30 * time.Minute)}}
+	if got := errorFields(rs.ValidateCreate()); !slices.Contains(got, "spec.retryConfig") {
+		t.Errorf("ValidateCreate() error fields = %v, want spec.retryConfig for a RestoreSession", got)
+	}
+}
+
+func TestValidateUpdate(t *testing.T) {
+	deployment := func(name string) TargetRef {
+		return TargetRef{APIVersion: "apps/v1", Kind: "Deployment", Name: name}
+	}
+	backupSession := func() *BackupSession {
+		return &BackupSession{
+			Spec: BackupSessionSpec{
+				Invoker: BackupInvokerRef{APIGroup: SchemeGroupVersion.Group, Kind: ResourceKindBackupBatch, Name: "batch"},
+				Targets: []TargetRef{deployment("app")},
+				Tags:    []string{"manual"},
+			},
+		}
+	}
+	backupConfiguration := func() *BackupConfiguration {
+		return &BackupConfiguration{
+			Spec: BackupConfigurationSpec{
+				Schedule:   "*/5 * * * *",
+				Driver:     ResticSnapshotter,
+				Repository: kmapi.ObjectReference{Name: "repo"},
+				BackupConfigurationTemplateSpec: BackupConfigurationTemplateSpec{
+					Target: &BackupTarget{Ref: deployment("app")},
+				},
+				RetentionPolicy: v1alpha1.RetentionPolicy{Name: "keep-last-5", KeepLast: 5},
+			},
+		}
+	}
+	backupBatch := func() *BackupBatch {
+		return &BackupBatch{
+			Spec: BackupBatchSpec{
+				Schedule:   "*/5 * * * *",
+				Driver:     ResticSnapshotter,
+				Repository: kmapi.ObjectReference{Name: "repo"},
+				Members: []BackupConfigurationTemplateSpec{
+					{Target: &BackupTarget{Ref: deployment("app")}},
+				},
+				RetentionPolicy: v1alpha1.RetentionPolicy{Name: "keep-last-5", KeepLast: 5},
+			},
+		}
+	}
+	backupBlueprint := func() *BackupBlueprint {
+		return &BackupBlueprint{
+			Spec: BackupBlueprintSpec{
+				RepositorySpec: v1alpha1.RepositorySpec{
+					Backend: store.Backend{S3: &store.S3Spec{Bucket: "backup"}},
+				},
+				Schedule:        "*/5 * * * *",
+				RetentionPolicy: v1alpha1.RetentionPolicy{Name: "keep-last-5", KeepLast: 5},
+			},
+		}
+	}
+	restoreSession := func() *RestoreSession {
+		return &RestoreSession{
+			Spec: RestoreSessionSpec{
+				Driver:     ResticSnapshotter,
+				Repository: kmapi.ObjectReference{Name: "repo"},
+				RestoreTargetSpec: RestoreTargetSpec{
+					Target: &RestoreTarget{
+						Ref:   deployment("app"),
+						Rules: []Rule{{Snapshots: []string{"latest"}}},
+					},
+				},
+			},
+		}
+	}
+	restoreBatch := func() *RestoreBatch {
+		return &RestoreBatch{
+			Spec: RestoreBatchSpec{
+				Driver:     ResticSnapshotter,
+				Repository: kmapi.ObjectReference{Name: "repo"},
+				Members: []RestoreTargetSpec{
+					{Target: &RestoreTarget{Ref: deployment("app")}},
+				},
+			},
+		}
+	}
+	task := func() *Task {
+		return &Task{Spec: TaskSpec{Steps: []FunctionRef{{Name: "pvc-backup"}}}}
+	}
+	function := func() *Function {
+		return &Function{Spec: FunctionSpec{Image: "stashed/stash:latest"}}
+	}
+
+	tests := []struct {
+		name     string
+		validate func() field.ErrorList
+		want     []string
+	}{
+		{
+			name: "BackupSession without change",
+			validate: func() field.ErrorList {
+				return backupSession().ValidateUpdate(backupSession())
+			},
+		},
+		{
+			name: "BackupSession with changed invoker and tags",
+			validate: func() field.ErrorList {
+				bs := backupSession()
+				bs.Spec.Invoker.Name = "another-batch"
+				bs.Spec.Tags = []string{"manual", "pre-upgrade"}
+				return bs.ValidateUpdate(backupSession())
+			},
+			want: []string{"spec.invoker", "spec.tags"},
+		},
+		{
+			name: "BackupSession with changed targets, retention and params",
+			validate: func() field.ErrorList {
+				bs := backupSession()
+				bs.Spec.Targets = []TargetRef{deployment("db")}
+				bs.Spec.SkipRetentionPolicy = true
+				bs.Spec.Params = []Param{{Name: "args", Value: "--all-databases"}}
+				return bs.ValidateUpdate(backupSession())
+			},
+			want: []string{"spec.targets", "spec.skipRetentionPolicy", "spec.params"},
+		},
+		{
+			name: "BackupConfiguration with changed schedule",
+			validate: func() field.ErrorList {
+				bc := backupConfiguration()
+				bc.Spec.Schedule = "0 * * * *"
+				return bc.ValidateUpdate(backupConfiguration())
+			},
+		},
+		{
+			name: "BackupConfiguration with changed driver and target",
+			validate: func() field.ErrorList {
+				bc := backupConfiguration()
+				bc.Spec.Driver = VolumeSnapshotter
+				bc.Spec.Target.Ref = TargetRef{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "app"}
+				return bc.ValidateUpdate(backupConfiguration())
+			},
+			want: []string{"spec.driver", "spec.target.ref"},
+		},
+		{
+			name: "BackupConfiguration with invalid update",
+			validate: func() field.ErrorList {
+				bc := backupConfiguration()
+				bc.Spec.RetentionPolicy.KeepLast = -1
+				return bc.ValidateUpdate(backupConfiguration())
+			},
+			want: []string{"spec.retentionPolicy.keepLast"},
+		},
+		{
+			name: "BackupBatch with a new member",
+			validate: func() field.ErrorList {
+				bb := backupBatch()
+				bb.Spec.Members = append(bb.Spec.Members, BackupConfigurationTemplateSpec{Target: &BackupTarget{Ref: deployment("db")}})
+				return bb.ValidateUpdate(backupBatch())
+			},
+		},
+		{
+			name: "BackupBatch with changed driver",
+			validate: func() field.ErrorList {
+				bb := backupBatch()
+				bb.Spec.Driver = VolumeSnapshotter
+				return bb.ValidateUpdate(backupBatch())
+			},
+			want: []string{"spec.driver"},
+		},
+		{
+			name: "BackupBlueprint with changed schedule",
+			validate: func() field.ErrorList {
+				bb := backupBlueprint()
+				bb.Spec.Schedule = "0 * * * *"
+				return bb.ValidateUpdate(backupBlueprint())
+			},
+		},
+		{
+			name: "BackupBlueprint with invalid update",
+			validate: func() field.ErrorList {
+				bb := backupBlueprint()
+				bb.Spec.Schedule = "0 * * *"
+				return bb.ValidateUpdate(backupBlueprint())
+			},
+			want: []string{"spec.schedule"},
+		},
+		{
+			name: "RestoreSession with changed hooks",
+			validate: func() field.ErrorList {
+				rs := restoreSession()
+				rs.Spec.Hooks = &RestoreHooks{PostRestore: &PostRestoreHook{Handler: &prober.Handler{}, ExecutionPolicy: ExecuteOnFailure}}
+				return rs.ValidateUpdate(restoreSession())
+			},
+		},
+		{
+			name: "RestoreSession with changed driver and repository",
+			validate: func() field.ErrorList {
+				rs := restoreSession()
+				rs.Spec.Driver = VolumeSnapshotter
+				rs.Spec.Repository.Name = "another-repo"
+				return rs.ValidateUpdate(restoreSession())
+			},
+			want: []string{"spec.target.volumeClaimTemplates", "spec.driver", "spec.repository"},
+		},
+		{
+			name: "RestoreSession with changed target",
+			validate: func() field.ErrorList {
+				rs := restoreSession()
+				rs.Spec.Target.Ref = deployment("db")
+				rs.Spec.Target.Rules = []Rule{{Snapshots: []string{"s1"}}}
+				return rs.ValidateUpdate(restoreSession())
+			},
+			want: []string{"spec.target.ref", "spec.target.rules"},
+		},
+		{
+			name: "RestoreSession with changed deprecated rules",
+			validate: func() field.ErrorList {
+				rs := restoreSession()
+				rs.Spec.Rules = []Rule{{Snapshots: []string{"s1"}}}
+				return rs.ValidateUpdate(restoreSession())
+			},
+			want: []string{"spec.rules"},
+		},
+		{
+			name: "RestoreBatch with changed failure policy",
+			validate: func() field.ErrorList {
+				rb := restoreBatch()
+				rb.Spec.FailurePolicy = &FailurePolicy{Type: ContinueOnError}
+				return rb.ValidateUpdate(restoreBatch())
+			},
+		},
+		{
+			name: "RestoreBatch with changed repository and members",
+			validate: func() field.ErrorList {
+				rb := restoreBatch()
+				rb.Spec.Repository.Name = "another-repo"
+				rb.Spec.Members = append(rb.Spec.Members, RestoreTargetSpec{Target: &RestoreTarget{Ref: deployment("db")}})
+				return rb.ValidateUpdate(restoreBatch())
+			},
+			want: []string{"spec.repository", "spec.members"},
+		},
+		{
+			name: "Task with a new step",
+			validate: func() field.ErrorList {
+				tk := task()
+				tk.Spec.Steps = append(tk.Spec.Steps, FunctionRef{Name: "update-status"})
+				return tk.ValidateUpdate(task())
+			},
+		},
+		{
+			name: "Task with duplicate volumes",
+			validate: func() field.ErrorList {
+				tk := task()
+				tk.Spec.Volumes = []core.Volume{{Name: "data"}, {Name: "data"}}
+				return tk.ValidateUpdate(task())
+			},
+			want: []string{"spec.volumes[1].name"},
+		},
+		{
+			name: "Function with changed image",
+			validate: func() field.ErrorList {
+				fn := function()
+				fn.Spec.Image = "stashed/stash:v1"
+				return fn.ValidateUpdate(function())
+			},
+		},
+		{
+			name: "Function without image",
+			validate: func() field.ErrorList {
+				fn := function()
+				fn.Spec.Image = ""
+				return fn.ValidateUpdate(function())
+			},
+			want: []string{"spec.image"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorFields(tt.validate()); !slices.Equal(got, tt.want) {
+				t.Errorf("ValidateUpdate() error fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRestoreSession_IsValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []Rule
+		wantErr bool
+	}{
+		{
+			name:  "Valid rules",
+			rules: []Rule{{TargetHosts: []string{"host-0"}, Snapshots: []string{"s1"}}, {Paths: []string{"/data"}}},
+		},
+		{
+			name:    "Multiple rules with empty targetHosts",
+			rules:   []Rule{{Paths: []string{"/data"}}, {Paths: []string{"/config"}}},
+			wantErr: true,
+		},
+		{
+			name:    "Multiple rules matching the same host",
+			rules:   []Rule{{TargetHosts: []string{"host-0"}}, {TargetHosts: []string{"host-0", "host-1"}}},
+			wantErr: true,
+		},
+		{
+			name:    "Both snapshots and paths in a rule",
+			rules:   []Rule{{Snapshots: []string{"s1"}, Paths: []string{"/data"}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := RestoreSession{Spec: RestoreSessionSpec{Rules: tt.rules}}
+			if err := rs.IsValid(); (err != nil) != tt.wantErr {
+				t.Errorf("IsValid() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDefault(t *testing.T) {
+	postBackup := func(policy HookExecutionPolicy) *BackupHooks {
+		return &BackupHooks{PostBackup: &PostBackupHook{Handler: &prober.Handler{}, ExecutionPolicy: policy}}
+	}
+	postRestore := func(policy HookExecutionPolicy) *RestoreHooks {
+		return &RestoreHooks{PostRestore: &PostRestoreHook{Handler: &prober.Handler{}, ExecutionPolicy: policy}}
+	}
+
+	t.Run("BackupConfiguration", func(t *testing.T) {
+		bc := BackupConfiguration{
+			Spec: BackupConfigurationSpec{
+				BackupConfigurationTemplateSpec: BackupConfigurationTemplateSpec{
+					Hooks:       postBackup(""),
+					RetryConfig: &RetryConfig{},
+				},
+			},
+		}
+		bc.Default()
+		if bc.Spec.Driver != ResticSnapshotter {
+			t.Errorf("Default() driver = %q, want %q", bc.Spec.Driver, ResticSnapshotter)
+		}
+		if bc.Spec.BackupHistoryLimit == nil || *bc.Spec.BackupHistoryLimit != DefaultBackupHistoryLimit {
+			t.Errorf("Default() backupHistoryLimit = %v, want %d", bc.Spec.BackupHistoryLimit, DefaultBackupHistoryLimit)
+		}
+		if bc.Spec.ConcurrencyPolicy != ForbidConcurrent {
+			t.Errorf("Default() concurrencyPolicy = %q, want %q", bc.Spec.ConcurrencyPolicy, ForbidConcurrent)
+		}
+		if bc.Spec.Hooks.PostBackup.ExecutionPolicy != ExecuteAlways {
+			t.Errorf("Default() postBackup executionPolicy = %q, want %q", bc.Spec.Hooks.PostBackup.ExecutionPolicy, ExecuteAlways)
+		}
+		if bc.Spec.RetryConfig.MaxRetry != DefaultMaxRetry {
+			t.Errorf("Default() maxRetry = %d, want %d", bc.Spec.RetryConfig.MaxRetry, DefaultMaxRetry)
+		}
+	})
+
+	t.Run("BackupConfiguration keeps specified values", func(t *testing.T) {
+		bc := BackupConfiguration{
+			Spec: BackupConfigurationSpec{
+				Driver:             VolumeSnapshotter,
+				BackupHistoryLimit: pointer.Int32P(5),
+				ConcurrencyPolicy:  ReplaceConcurrent,
+				BackupConfigurationTemplateSpec: BackupConfigurationTemplateSpec{
+					Hooks: postBackup(ExecuteOnFailure),
+				},
+			},
+		}
+		bc.Default()
+		if bc.Spec.Driver != VolumeSnapshotter || *bc.Spec.BackupHistoryLimit != 5 ||
+			bc.Spec.ConcurrencyPolicy != ReplaceConcurrent || bc.Spec.Hooks.PostBackup.ExecutionPolicy != ExecuteOnFailure {
+			t.Errorf("Default() overrode the specified values: %+v", bc.Spec)
+		}
+	})
+
+	t.Run("BackupBatch", func(t *testing.T) {
+		bb := BackupBatch{
+			Spec: BackupBatchSpec{
+				Hooks: postBackup(""),
+				Members: []BackupConfigurationTemplateSpec{
+					{Hooks: postBackup(""), RetryConfig: &RetryConfig{}},
+				},
+				FailurePolicy: &FailurePolicy{},
+			},
+		}
+		bb.Default()
+		if bb.Spec.Driver != ResticSnapshotter {
+			t.Errorf("Default() driver = %q, want %q", bb.Spec.Driver, ResticSnapshotter)
+		}
+		if bb.Spec.ExecutionOrder != Parallel {
+			t.Errorf("Default() executionOrder = %q, want %q", bb.Spec.ExecutionOrder, Parallel)
+		}
+		if bb.Spec.ConcurrencyPolicy != ForbidConcurrent {
+			t.Errorf("Default() concurrencyPolicy = %q, want %q", bb.Spec.ConcurrencyPolicy, ForbidConcurrent)
+		}
+		if bb.Spec.BackupHistoryLimit == nil || *bb.Spec.BackupHistoryLimit != DefaultBackupHistoryLimit {
+			t.Errorf("Default() backupHistoryLimit = %v, want %d", bb.Spec.BackupHistoryLimit, DefaultBackupHistoryLimit)
+		}
+		if bb.Spec.Hooks.PostBackup.ExecutionPolicy != ExecuteAlways || bb.Spec.Members[0].Hooks.PostBackup.ExecutionPolicy != ExecuteAlways {
+			t.Errorf("Default() did not set the execution policy of the postBackup hooks")
+		}
+		if bb.Spec.Members[0].RetryConfig.MaxRetry != DefaultMaxRetry {
+			t.Errorf("Default() member maxRetry = %d, want %d", bb.Spec.Members[0].RetryConfig.MaxRetry, DefaultMaxRetry)
+		}
+		if bb.Spec.FailurePolicy.Type != FailFast {
+			t.Errorf("Default() failurePolicy type = %q, want %q", bb.Spec.FailurePolicy.Type, FailFast)
+		}
+	})
+
+	t.Run("BackupBlueprint", func(t *testing.T) {
+		bb := BackupBlueprint{
+			Spec: BackupBlueprintSpec{
+				Hooks:       postBackup(""),
+				RetryConfig: &RetryConfig{},
+			},
+		}
+		bb.Default()
+		if bb.Spec.UsagePolicy == nil {
+			t.Errorf("Default() did not set the usage policy of the Repository")
+		}
+		if bb.Spec.BackupHistoryLimit == nil || *bb.Spec.BackupHistoryLimit != DefaultBackupHistoryLimit {
+			t.Errorf("Default() backupHistoryLimit = %v, want %d", bb.Spec.BackupHistoryLimit, DefaultBackupHistoryLimit)
+		}
+		if bb.Spec.Hooks.PostBackup.ExecutionPolicy != ExecuteAlways {
+			t.Errorf("Default() postBackup executionPolicy = %q, want %q", bb.Spec.Hooks.PostBackup.ExecutionPolicy, ExecuteAlways)
+		}
+		if bb.Spec.RetryConfig.MaxRetry != DefaultMaxRetry {
+			t.Errorf("Default() maxRetry = %d, want %d", bb.Spec.RetryConfig.MaxRetry, DefaultMaxRetry)
+		}
+	})
+
+	t.Run("RestoreSession", func(t *testing.T) {
+		rules := []Rule{{Snapshots: []string{"latest"}}}
+		rs := RestoreSession{
+			Spec: RestoreSessionSpec{
+				Rules: rules,
+				RestoreTargetSpec: RestoreTargetSpec{
+					Target: &RestoreTarget{},
+					Hooks:  postRestore(""),
+				},
+			},
+		}
+		rs.Default()
+		if rs.Spec.Driver != ResticSnapshotter {
+			t.Errorf("Default() driver = %q, want %q", rs.Spec.Driver, ResticSnapshotter)
+		}
+		if rs.Spec.Rules != nil || len(rs.Spec.Target.Rules) != len(rules) {
+			t.Errorf("Default() did not move the deprecated rules into the target")
+		}
+		if rs.Spec.Hooks.PostRestore.ExecutionPolicy != ExecuteAlways {
+			t.Errorf("Default() postRestore executionPolicy = %q, want %q", rs.Spec.Hooks.PostRestore.ExecutionPolicy, ExecuteAlways)
+		}
+	})
+
+	t.Run("RestoreBatch", func(t *testing.T) {
+		rb := RestoreBatch{
+			Spec: RestoreBatchSpec{
+				Hooks:         postRestore(""),
+				Members:       []RestoreTargetSpec{{Hooks: postRestore(ExecuteOnSuccess)}},
+				FailurePolicy: &FailurePolicy{},
+			},
+		}
+		rb.Default()
+		if rb.Spec.Driver != ResticSnapshotter {
+			t.Errorf("Default() driver = %q, want %q", rb.Spec.Driver, ResticSnapshotter)
+		}
+		if rb.Spec.ExecutionOrder != Parallel {
+			t.Errorf("Default() executionOrder = %q, want %q", rb.Spec.ExecutionOrder, Parallel)
+		}
+		if rb.Spec.Hooks.PostRestore.ExecutionPolicy != ExecuteAlways {
+			t.Errorf("Default() postRestore executionPolicy = %q, want %q", rb.Spec.Hooks.PostRestore.ExecutionPolicy, ExecuteAlways)
+		}
+		if rb.Spec.Members[0].Hooks.PostRestore.ExecutionPolicy != ExecuteOnSuccess {
+			t.Errorf("Default() member postRestore executionPolicy = %q, want %q", rb.Spec.Members[0].Hooks.PostRestore.ExecutionPolicy, ExecuteOnSuccess)
+		}
+		if rb.Spec.FailurePolicy.Type != FailFast {
+			t.Errorf("Default() failurePolicy type = %q, want %q", rb.Spec.FailurePolicy.Type, FailFast)
+		}
+	})
+
+	t.Run("Task", func(t *testing.T) {
+		task := Task{
+			Spec: TaskSpec{
+				Steps: []FunctionRef{{Name: "postgres-backup", Params: []Param{{}, {Name: "args", Value: "--clean"}, {}}}},
+			},
+		}
+		task.Default()
+		if params := task.Spec.Steps[0].Params; len(params) != 1 || params[0].Name != "args" {
+			t.Errorf("Default() params = %v, want only the non-empty parameter", params)
+		}
+		if errs := task.ValidateCreate(); len(errs) != 0 {
+			t.Errorf("ValidateCreate() after Default() = %v, want no error", errs)
+		}
+	})
+}
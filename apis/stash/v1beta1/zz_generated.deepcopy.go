@@ -28,6 +28,7 @@ import (
 	apiv1 "kmodules.xyz/client-go/api/v1"
 	offshootapiapiv1 "kmodules.xyz/offshoot-api/api/v1"
 	proberapiv1 "kmodules.xyz/prober/api/v1"
+	v1alpha1 "stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -124,6 +125,16 @@ func (in *BackupBatchSpec) DeepCopyInto(out *BackupBatchSpec) {
 		*out = new(RetryConfig)
 		**out = **in
 	}
+	if in.FailurePolicy != nil {
+		in, out := &in.FailurePolicy, &out.FailurePolicy
+		*out = new(FailurePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartingDeadline != nil {
+		in, out := &in.StartingDeadline, &out.StartingDeadline
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -260,6 +271,18 @@ func (in *BackupBlueprintSpec) DeepCopyInto(out *BackupBlueprintSpec) {
 		*out = new(RetryConfig)
 		**out = **in
 	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]BlueprintParam, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(BlueprintSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -345,16 +368,30 @@ func (in *BackupConfigurationSpec) DeepCopyInto(out *BackupConfigurationSpec) {
 		*out = new(int32)
 		**out = **in
 	}
-	if in.TimeOut != nil {
-		in, out := &in.TimeOut, &out.TimeOut
-		*out = new(v1.Duration)
+	if in.BackupWindow != nil {
+		in, out := &in.BackupWindow, &out.BackupWindow
+		*out = new(BackupWindow)
 		**out = **in
 	}
-	if in.RetryConfig != nil {
-		in, out := &in.RetryConfig, &out.RetryConfig
-		*out = new(RetryConfig)
+	if in.Blackouts != nil {
+		in, out := &in.Blackouts, &out.Blackouts
+		*out = make([]BlackoutPeriod, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartingDeadline != nil {
+		in, out := &in.StartingDeadline, &out.StartingDeadline
+		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]ScheduledPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -412,6 +449,21 @@ func (in *BackupConfigurationTemplateSpec) DeepCopyInto(out *BackupConfiguration
 		*out = new(BackupHooks)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]TargetRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.TimeOut != nil {
+		in, out := &in.TimeOut, &out.TimeOut
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RetryConfig != nil {
+		in, out := &in.RetryConfig, &out.RetryConfig
+		*out = new(RetryConfig)
+		**out = **in
+	}
 	return
 }
 
@@ -467,12 +519,44 @@ func (in *BackupInvokerRef) DeepCopy() *BackupInvokerRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRestriction) DeepCopyInto(out *BackupRestriction) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRestriction.
+func (in *BackupRestriction) DeepCopy() *BackupRestriction {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRestriction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupSchedule) DeepCopyInto(out *BackupSchedule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSchedule.
+func (in *BackupSchedule) DeepCopy() *BackupSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupSession) DeepCopyInto(out *BackupSession) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -532,6 +616,26 @@ func (in *BackupSessionList) DeepCopyObject() runtime.Object {
 func (in *BackupSessionSpec) DeepCopyInto(out *BackupSessionSpec) {
 	*out = *in
 	out.Invoker = in.Invoker
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]TargetRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]Param, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailurePolicy != nil {
+		in, out := &in.FailurePolicy, &out.FailurePolicy
+		*out = new(FailurePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -665,6 +769,15 @@ func (in *BackupTargetStatus) DeepCopyInto(out *BackupTargetStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Deadline != nil {
+		in, out := &in.Deadline, &out.Deadline
+		*out = (*in).DeepCopy()
+	}
+	if in.RetryLeft != nil {
+		in, out := &in.RetryLeft, &out.RetryLeft
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -678,6 +791,127 @@ func (in *BackupTargetStatus) DeepCopy() *BackupTargetStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupWindow) DeepCopyInto(out *BackupWindow) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupWindow.
+func (in *BackupWindow) DeepCopy() *BackupWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackoutPeriod) DeepCopyInto(out *BlackoutPeriod) {
+	*out = *in
+	if in.Start != nil {
+		in, out := &in.Start, &out.Start
+		*out = (*in).DeepCopy()
+	}
+	if in.End != nil {
+		in, out := &in.End, &out.End
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackoutPeriod.
+func (in *BlackoutPeriod) DeepCopy() *BlackoutPeriod {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackoutPeriod)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlueprintParam) DeepCopyInto(out *BlueprintParam) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlueprintParam.
+func (in *BlueprintParam) DeepCopy() *BlueprintParam {
+	if in == nil {
+		return nil
+	}
+	out := new(BlueprintParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlueprintSelector) DeepCopyInto(out *BlueprintSelector) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObjectSelector != nil {
+		in, out := &in.ObjectSelector, &out.ObjectSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlueprintSelector.
+func (in *BlueprintSelector) DeepCopy() *BlueprintSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(BlueprintSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlueprintTarget) DeepCopyInto(out *BlueprintTarget) {
+	*out = *in
+	out.Ref = in.Ref
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlueprintTarget.
+func (in *BlueprintTarget) DeepCopy() *BlueprintTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(BlueprintTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EmptyDirSettings) DeepCopyInto(out *EmptyDirSettings) {
 	*out = *in
@@ -699,6 +933,27 @@ func (in *EmptyDirSettings) DeepCopy() *EmptyDirSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailurePolicy) DeepCopyInto(out *FailurePolicy) {
+	*out = *in
+	if in.MinSuccessful != nil {
+		in, out := &in.MinSuccessful, &out.MinSuccessful
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailurePolicy.
+func (in *FailurePolicy) DeepCopy() *FailurePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FailurePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FileStats) DeepCopyInto(out *FileStats) {
 	*out = *in
@@ -887,6 +1142,29 @@ func (in *HostBackupStats) DeepCopy() *HostBackupStats {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostRestorePlan) DeepCopyInto(out *HostRestorePlan) {
+	*out = *in
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = make([]SnapshotRestorePlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostRestorePlan.
+func (in *HostRestorePlan) DeepCopy() *HostRestorePlan {
+	if in == nil {
+		return nil
+	}
+	out := new(HostRestorePlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostRestoreStats) DeepCopyInto(out *HostRestoreStats) {
 	*out = *in
@@ -943,6 +1221,22 @@ func (in *Param) DeepCopy() *Param {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PathRewrite) DeepCopyInto(out *PathRewrite) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PathRewrite.
+func (in *PathRewrite) DeepCopy() *PathRewrite {
+	if in == nil {
+		return nil
+	}
+	out := new(PathRewrite)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostBackupHook) DeepCopyInto(out *PostBackupHook) {
 	*out = *in
@@ -1057,6 +1351,11 @@ func (in *RestoreBatchSpec) DeepCopyInto(out *RestoreBatchSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FailurePolicy != nil {
+		in, out := &in.FailurePolicy, &out.FailurePolicy
+		*out = new(FailurePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Hooks != nil {
 		in, out := &in.Hooks, &out.Hooks
 		*out = new(RestoreHooks)
@@ -1067,6 +1366,13 @@ func (in *RestoreBatchSpec) DeepCopyInto(out *RestoreBatchSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Mappings != nil {
+		in, out := &in.Mappings, &out.Mappings
+		*out = make([]RestoreMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1101,6 +1407,11 @@ func (in *RestoreBatchStatus) DeepCopyInto(out *RestoreBatchStatus) {
 		in, out := &in.SessionDeadline, &out.SessionDeadline
 		*out = (*in).DeepCopy()
 	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(RestorePlan)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1140,6 +1451,45 @@ func (in *RestoreHooks) DeepCopy() *RestoreHooks {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreMapping) DeepCopyInto(out *RestoreMapping) {
+	*out = *in
+	out.Source = in.Source
+	out.Target = in.Target
+	if in.PathRewrites != nil {
+		in, out := &in.PathRewrites, &out.PathRewrites
+		*out = make([]PathRewrite, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreMapping.
+func (in *RestoreMapping) DeepCopy() *RestoreMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreMappingRef) DeepCopyInto(out *RestoreMappingRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreMappingRef.
+func (in *RestoreMappingRef) DeepCopy() *RestoreMappingRef {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreMappingRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RestoreMemberStatus) DeepCopyInto(out *RestoreMemberStatus) {
 	*out = *in
@@ -1161,6 +1511,15 @@ func (in *RestoreMemberStatus) DeepCopyInto(out *RestoreMemberStatus) {
 		*out = make([]HostRestoreStats, len(*in))
 		copy(*out, *in)
 	}
+	if in.Deadline != nil {
+		in, out := &in.Deadline, &out.Deadline
+		*out = (*in).DeepCopy()
+	}
+	if in.RetryLeft != nil {
+		in, out := &in.RetryLeft, &out.RetryLeft
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -1174,6 +1533,29 @@ func (in *RestoreMemberStatus) DeepCopy() *RestoreMemberStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestorePlan) DeepCopyInto(out *RestorePlan) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]TargetRestorePlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestorePlan.
+func (in *RestorePlan) DeepCopy() *RestorePlan {
+	if in == nil {
+		return nil
+	}
+	out := new(RestorePlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RestoreSession) DeepCopyInto(out *RestoreSession) {
 	*out = *in
@@ -1247,10 +1629,12 @@ func (in *RestoreSessionSpec) DeepCopyInto(out *RestoreSessionSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.TimeOut != nil {
-		in, out := &in.TimeOut, &out.TimeOut
-		*out = new(v1.Duration)
-		**out = **in
+	if in.Mappings != nil {
+		in, out := &in.Mappings, &out.Mappings
+		*out = make([]RestoreMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -1289,6 +1673,11 @@ func (in *RestoreSessionStatus) DeepCopyInto(out *RestoreSessionStatus) {
 		in, out := &in.SessionDeadline, &out.SessionDeadline
 		*out = (*in).DeepCopy()
 	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(RestorePlan)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1371,6 +1760,21 @@ func (in *RestoreTargetSpec) DeepCopyInto(out *RestoreTargetSpec) {
 		*out = new(RestoreHooks)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]TargetRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.TimeOut != nil {
+		in, out := &in.TimeOut, &out.TimeOut
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RetryConfig != nil {
+		in, out := &in.RetryConfig, &out.RetryConfig
+		*out = new(RetryConfig)
+		**out = **in
+	}
 	return
 }
 
@@ -1429,6 +1833,28 @@ func (in *Rule) DeepCopyInto(out *Rule) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.RestoreTime != nil {
+		in, out := &in.RestoreTime, &out.RestoreTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Before != nil {
+		in, out := &in.Before, &out.Before
+		*out = (*in).DeepCopy()
+	}
+	if in.After != nil {
+		in, out := &in.After, &out.After
+		*out = (*in).DeepCopy()
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PathRewrites != nil {
+		in, out := &in.PathRewrites, &out.PathRewrites
+		*out = make([]PathRewrite, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1442,6 +1868,57 @@ func (in *Rule) DeepCopy() *Rule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledPolicy) DeepCopyInto(out *ScheduledPolicy) {
+	*out = *in
+	if in.Repository != nil {
+		in, out := &in.Repository, &out.Repository
+		*out = new(apiv1.ObjectReference)
+		**out = **in
+	}
+	if in.RetentionPolicy != nil {
+		in, out := &in.RetentionPolicy, &out.RetentionPolicy
+		*out = new(v1alpha1.RetentionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledPolicy.
+func (in *ScheduledPolicy) DeepCopy() *ScheduledPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotRestorePlan) DeepCopyInto(out *SnapshotRestorePlan) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRestorePlan.
+func (in *SnapshotRestorePlan) DeepCopy() *SnapshotRestorePlan {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotRestorePlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SnapshotStats) DeepCopyInto(out *SnapshotStats) {
 	*out = *in
@@ -1494,6 +1971,30 @@ func (in *TargetRef) DeepCopy() *TargetRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetRestorePlan) DeepCopyInto(out *TargetRestorePlan) {
+	*out = *in
+	out.Ref = in.Ref
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]HostRestorePlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetRestorePlan.
+func (in *TargetRestorePlan) DeepCopy() *TargetRestorePlan {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetRestorePlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TargetStatus) DeepCopyInto(out *TargetStatus) {
 	*out = *in
@@ -0,0 +1,42 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	api "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SetUpcomingBackupTime sets the next time after "now" when the BackupConfiguration will take a backup.
+// All the schedules are evaluated in the time zone of the BackupConfiguration and the backup window and the blackout periods are honored. It is cleared if the BackupConfiguration is paused.
+func (s *BackupOverviewSpec) SetUpcomingBackupTime(bc api.BackupConfigurationSpec, now time.Time) error {
+	s.UpcomingBackupTime = nil
+	if bc.Paused {
+		return nil
+	}
+	next, err := bc.NextBackupTime(now)
+	if err != nil {
+		return err
+	}
+	if next != nil {
+		s.UpcomingBackupTime = &metav1.Time{Time: *next}
+	}
+	return nil
+}
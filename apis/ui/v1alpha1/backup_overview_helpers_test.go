@@ -0,0 +1,82 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	api "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+
+	"gomodules.xyz/pointer"
+)
+
+func TestBackupOverviewSpec_SetUpcomingBackupTime(t *testing.T) {
+	now := time.Date(2024, time.March, 4, 10, 30, 0, 0, time.UTC)
+	at := func(day, hour int) time.Time {
+		return time.Date(2024, time.March, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name    string
+		spec    api.BackupConfigurationSpec
+		want    *time.Time
+		wantErr bool
+	}{
+		{
+			name: "Next scheduled time",
+			spec: api.BackupConfigurationSpec{Schedule: "0 * * * *", TimeZone: "UTC"},
+			want: pointer.TimeP(at(4, 11)),
+		},
+		{
+			name: "Next scheduled time inside the backup window",
+			spec: api.BackupConfigurationSpec{
+				Schedule:     "0 * * * *",
+				TimeZone:     "UTC",
+				BackupWindow: &api.BackupWindow{Start: "22:00", End: "04:00"},
+			},
+			want: pointer.TimeP(at(4, 22)),
+		},
+		{
+			name: "Paused BackupConfiguration",
+			spec: api.BackupConfigurationSpec{Schedule: "0 * * * *", Paused: true},
+		},
+		{
+			name: "Without schedule",
+			spec: api.BackupConfigurationSpec{},
+		},
+		{
+			name:    "Invalid time zone",
+			spec:    api.BackupConfigurationSpec{Schedule: "0 * * * *", TimeZone: "Mars/Olympus_Mons"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &BackupOverviewSpec{}
+			err := s.SetUpcomingBackupTime(tt.spec, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetUpcomingBackupTime() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			got := s.UpcomingBackupTime
+			if (got == nil) != (tt.want == nil) || (got != nil && !got.Time.Equal(*tt.want)) {
+				t.Errorf("SetUpcomingBackupTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
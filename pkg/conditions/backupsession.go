@@ -18,12 +18,17 @@ package conditions
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
+	"stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
 	"stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	cs "stash.appscode.dev/apimachinery/client/clientset/versioned"
 	"stash.appscode.dev/apimachinery/pkg/invoker"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kmapi "kmodules.xyz/client-go/api/v1"
+	cutil "kmodules.xyz/client-go/conditions"
 )
 
 func SetBackendRepositoryInitializedConditionToFalse(session *invoker.BackupSessionHandler, err error) error {
@@ -336,12 +341,131 @@ func SetRepositoryMetricsPushedConditionToTrue(session *invoker.BackupSessionHan
 }
 
 func SetBackupSkippedConditionToTrue(session *invoker.BackupSessionHandler, msg string) error {
+	return SetBackupSkippedConditionToTrueWithReason(session, v1beta1.SkippedTakingNewBackup, msg)
+}
+
+// SkipBackupIfRestricted marks the session skipped if the invoker does not allow a backup to start at the time
+// the session was created (i.e. outside of the backup window or during a blackout period). It returns true if the session has been skipped.
+// An on-demand session that ignores the restrictions is never skipped.
+func SkipBackupIfRestricted(inv invoker.BackupInvoker, session *invoker.BackupSessionHandler) (bool, error) {
+	if session.GetBackupSession().Spec.IgnoreRestrictions {
+		return false, nil
+	}
+	restriction, err := inv.GetBackupRestriction(session.GetObjectMeta().CreationTimestamp.Time)
+	if err != nil || restriction == nil {
+		return false, err
+	}
+	return true, SetBackupSkippedConditionToTrueWithReason(session, restriction.Reason, restriction.Message)
+}
+
+// SkipBackupIfRepositoryQuotaExceeded skips or fails the session according to the quota action of the Repository when
+// the Repository has exceeded its quota. It returns true if the session has been skipped or failed.
+func SkipBackupIfRepositoryQuotaExceeded(repo *v1alpha1.Repository, session *invoker.BackupSessionHandler) (bool, error) {
+	if !repo.QuotaExceeded() {
+		return false, nil
+	}
+	_, cond := cutil.GetCondition(repo.Status.Conditions, v1alpha1.RepositoryQuotaExceeded)
+	switch repo.GetQuotaExceededAction() {
+	case v1alpha1.QuotaExceededActionSkip:
+		return true, SetBackupSkippedConditionToTrueWithReason(session, v1beta1.SkippedRepositoryQuotaExceeded,
+			fmt.Sprintf("Skipped taking new backup. Reason: %s", cond.Message))
+	case v1alpha1.QuotaExceededActionFail:
+		return true, session.UpdateStatus(&v1beta1.BackupSessionStatus{
+			Conditions: []kmapi.Condition{
+				{
+					Type:               v1beta1.RepositoryQuotaExceeded,
+					Status:             metav1.ConditionTrue,
+					Reason:             v1beta1.FailedDueToRepositoryQuotaExceeded,
+					Message:            fmt.Sprintf("Failed to take backup. Reason: %s", cond.Message),
+					LastTransitionTime: metav1.Now(),
+				},
+			},
+		})
+	default:
+		return false, nil
+	}
+}
+
+// SkipBackupTargetIfDependencyFailed marks the target skipped if any member it depends on has failed or has been skipped,
+// as the target will never start. It returns true if the target has been skipped.
+func SkipBackupTargetIfDependencyFailed(inv invoker.BackupInvoker, session *invoker.BackupSessionHandler, target v1beta1.TargetRef) (bool, error) {
+	deps := inv.FailedDependencies(target, session.GetTargetStatus())
+	if len(deps) == 0 {
+		return false, nil
+	}
+	return true, SetBackupTargetSkippedConditionToTrue(session, target, v1beta1.SkippedDueToDependencyFailure,
+		fmt.Sprintf("Skipped taking backup as the dependencies %s did not succeed.", targetNames(deps)))
+}
+
+// SetBackupTargetSkippedConditionToTrue marks the target skipped for the given reason (i.e. SkippedDueToDependencyFailure)
+func SetBackupTargetSkippedConditionToTrue(session *invoker.BackupSessionHandler, target v1beta1.TargetRef, reason, msg string) error {
+	return session.UpdateStatus(&v1beta1.BackupSessionStatus{
+		Targets: []v1beta1.BackupTargetStatus{
+			{
+				Ref: target,
+				Conditions: []kmapi.Condition{
+					{
+						Type:               v1beta1.TargetSkipped,
+						Status:             metav1.ConditionTrue,
+						Reason:             reason,
+						Message:            msg,
+						LastTransitionTime: metav1.Now(),
+					},
+				},
+			},
+		},
+	})
+}
+
+// FailBackupTargetIfDeadlineExceeded marks the backup of a target failed if it has not completed within the deadline of the target.
+// It returns true if the deadline has been exceeded.
+func FailBackupTargetIfDeadlineExceeded(session *invoker.BackupSessionHandler, info invoker.BackupTargetInfo) (bool, error) {
+	if info.Target == nil || info.TimeOut == nil {
+		return false, nil
+	}
+	for _, status := range session.GetTargetStatus() {
+		if invoker.TargetMatched(status.Ref, info.Target.Ref) && invoker.BackupTargetDeadlineExceeded(status, time.Now()) {
+			return true, SetBackupTargetDeadlineExceededConditionToTrue(session, info.Target.Ref, *info.TimeOut)
+		}
+	}
+	return false, nil
+}
+
+func SetBackupTargetDeadlineExceededConditionToTrue(session *invoker.BackupSessionHandler, target v1beta1.TargetRef, timeOut metav1.Duration) error {
+	return session.UpdateStatus(&v1beta1.BackupSessionStatus{
+		Targets: []v1beta1.BackupTargetStatus{
+			{
+				Ref: target,
+				Conditions: []kmapi.Condition{
+					{
+						Type:               v1beta1.DeadlineExceeded,
+						Status:             metav1.ConditionTrue,
+						Reason:             v1beta1.FailedToCompleteWithinDeadline,
+						Message:            fmt.Sprintf("Failed to complete backup of %s/%s within %s.", target.Kind, target.Name, timeOut),
+						LastTransitionTime: metav1.Now(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func targetNames(refs []v1beta1.TargetRef) string {
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		names = append(names, ref.Kind+"/"+ref.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// SetBackupSkippedConditionToTrueWithReason marks the session skipped for the given reason (i.e. SkippedOutsideBackupWindow)
+func SetBackupSkippedConditionToTrueWithReason(session *invoker.BackupSessionHandler, reason, msg string) error {
 	return session.UpdateStatus(&v1beta1.BackupSessionStatus{
 		Conditions: []kmapi.Condition{
 			{
 				Type:               v1beta1.BackupSkipped,
 				Status:             metav1.ConditionTrue,
-				Reason:             v1beta1.SkippedTakingNewBackup,
+				Reason:             reason,
 				Message:            msg,
 				LastTransitionTime: metav1.Now(),
 			},
@@ -405,6 +529,29 @@ func SetBackupHistoryCleanedConditionToTrue(session *invoker.BackupSessionHandle
 	})
 }
 
+// SetBackupReplacedConditionToTrue marks the BackupSessions that must be stopped according to the "Replace" concurrency
+// policy as failed. The new BackupSession should start only after this function returns successfully.
+func SetBackupReplacedConditionToTrue(stashClient cs.Interface, decision invoker.ConcurrencyDecision, session *v1beta1.BackupSession) error {
+	for i := range decision.Replace {
+		replaced := invoker.NewBackupSessionHandler(stashClient, &decision.Replace[i])
+		err := replaced.UpdateStatus(&v1beta1.BackupSessionStatus{
+			Conditions: []kmapi.Condition{
+				{
+					Type:               v1beta1.BackupReplaced,
+					Status:             metav1.ConditionTrue,
+					Reason:             v1beta1.FailedDueToReplacement,
+					Message:            fmt.Sprintf("Stopped the backup as it has been replaced by BackupSession %s.", session.Name),
+					LastTransitionTime: metav1.Now(),
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func SetBackupDeadlineExceededConditionToTrue(session *invoker.BackupSessionHandler, timeOut metav1.Duration) error {
 	return session.UpdateStatus(&v1beta1.BackupSessionStatus{
 		Conditions: []kmapi.Condition{
@@ -0,0 +1,235 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	"stash.appscode.dev/apimachinery/client/clientset/versioned/fake"
+	"stash.appscode.dev/apimachinery/pkg/invoker"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cutil "kmodules.xyz/client-go/conditions"
+)
+
+func TestSkipBackupIfRestricted(t *testing.T) {
+	created := time.Date(2024, time.March, 4, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name               string
+		spec               v1beta1.BackupConfigurationSpec
+		ignoreRestrictions bool
+		wantSkip           bool
+		wantReason         string
+	}{
+		{
+			name: "No restriction",
+			spec: v1beta1.BackupConfigurationSpec{Schedule: "*/30 * * * *", TimeZone: "UTC"},
+		},
+		{
+			name: "Inside the backup window",
+			spec: v1beta1.BackupConfigurationSpec{
+				Schedule:     "*/30 * * * *",
+				TimeZone:     "UTC",
+				BackupWindow: &v1beta1.BackupWindow{Start: "10:00", End: "12:00"},
+			},
+		},
+		{
+			name: "Outside of the backup window",
+			spec: v1beta1.BackupConfigurationSpec{
+				Schedule:     "*/30 * * * *",
+				TimeZone:     "UTC",
+				BackupWindow: &v1beta1.BackupWindow{Start: "22:00", End: "04:00"},
+			},
+			wantSkip:   true,
+			wantReason: v1beta1.SkippedOutsideBackupWindow,
+		},
+		{
+			name: "During a blackout period",
+			spec: v1beta1.BackupConfigurationSpec{
+				Schedule: "*/30 * * * *",
+				Blackouts: []v1beta1.BlackoutPeriod{
+					{
+						Name:  "maintenance",
+						Start: &metav1.Time{Time: created.Add(-time.Hour)},
+						End:   &metav1.Time{Time: created.Add(time.Hour)},
+					},
+				},
+			},
+			wantSkip:   true,
+			wantReason: v1beta1.SkippedDuringBlackout,
+		},
+		{
+			name: "On-demand session ignoring the restrictions",
+			spec: v1beta1.BackupConfigurationSpec{
+				Schedule: "*/30 * * * *",
+				Blackouts: []v1beta1.BlackoutPeriod{
+					{
+						Start: &metav1.Time{Time: created.Add(-time.Hour)},
+						End:   &metav1.Time{Time: created.Add(time.Hour)},
+					},
+				},
+			},
+			ignoreRestrictions: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &v1beta1.BackupSession{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-session", Namespace: "demo", CreationTimestamp: metav1.Time{Time: created}},
+				Spec:       v1beta1.BackupSessionSpec{IgnoreRestrictions: tt.ignoreRestrictions},
+			}
+			stashClient := fake.NewSimpleClientset(session)
+			bc := &v1beta1.BackupConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-backup", Namespace: "demo"},
+				Spec:       tt.spec,
+			}
+			handler := invoker.NewBackupSessionHandler(stashClient, session)
+
+			skipped, err := SkipBackupIfRestricted(invoker.NewBackupConfigurationInvoker(stashClient, bc), handler)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if skipped != tt.wantSkip {
+				t.Errorf("SkipBackupIfRestricted() = %v, want %v", skipped, tt.wantSkip)
+				return
+			}
+			_, cond := cutil.GetCondition(handler.GetStatus().Conditions, v1beta1.BackupSkipped)
+			if !tt.wantSkip {
+				if cond != nil {
+					t.Errorf("SkipBackupIfRestricted() set condition %+v, want no BackupSkipped condition", cond)
+				}
+				return
+			}
+			if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != tt.wantReason {
+				t.Errorf("SkipBackupIfRestricted() set condition %+v, want BackupSkipped with reason %q", cond, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestSetBackupReplacedConditionToTrue(t *testing.T) {
+	running := &v1beta1.BackupSession{
+		ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "demo"},
+		Status:     v1beta1.BackupSessionStatus{Phase: v1beta1.BackupSessionRunning},
+	}
+	current := &v1beta1.BackupSession{
+		ObjectMeta: metav1.ObjectMeta{Name: "current", Namespace: "demo"},
+	}
+	stashClient := fake.NewSimpleClientset(running, current)
+
+	decision := invoker.ConcurrencyDecision{Action: invoker.ConcurrencyActionRun, Replace: []v1beta1.BackupSession{*running}}
+	if err := SetBackupReplacedConditionToTrue(stashClient, decision, current); err != nil {
+		t.Error(err)
+		return
+	}
+	replaced, err := stashClient.StashV1beta1().BackupSessions("demo").Get(context.TODO(), running.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_, cond := cutil.GetCondition(replaced.Status.Conditions, v1beta1.BackupReplaced)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != v1beta1.FailedDueToReplacement {
+		t.Errorf("SetBackupReplacedConditionToTrue() set condition %+v, want BackupReplaced with reason %q", cond, v1beta1.FailedDueToReplacement)
+		return
+	}
+	if replaced.Status.Phase != v1beta1.BackupSessionFailed {
+		t.Errorf("SetBackupReplacedConditionToTrue() phase = %s, want %s", replaced.Status.Phase, v1beta1.BackupSessionFailed)
+	}
+}
+
+func TestSkipBackupTargetIfDependencyFailed(t *testing.T) {
+	ref := func(name string) v1beta1.TargetRef {
+		return v1beta1.TargetRef{APIVersion: "apps/v1", Kind: "StatefulSet", Name: name, Namespace: "demo"}
+	}
+	member := func(target v1beta1.TargetRef, dependsOn ...v1beta1.TargetRef) v1beta1.BackupConfigurationTemplateSpec {
+		return v1beta1.BackupConfigurationTemplateSpec{Target: &v1beta1.BackupTarget{Ref: target}, DependsOn: dependsOn}
+	}
+	bb := &v1beta1.BackupBatch{
+		ObjectMeta: metav1.ObjectMeta{Name: "sample-backup", Namespace: "demo"},
+		Spec: v1beta1.BackupBatchSpec{
+			Members: []v1beta1.BackupConfigurationTemplateSpec{member(ref("db")), member(ref("app"), ref("db")), member(ref("cache"))},
+		},
+	}
+	session := &v1beta1.BackupSession{
+		ObjectMeta: metav1.ObjectMeta{Name: "sample-backup-1", Namespace: "demo"},
+		Status: v1beta1.BackupSessionStatus{
+			Targets: []v1beta1.BackupTargetStatus{{Ref: ref("db"), Phase: v1beta1.TargetBackupFailed}},
+		},
+	}
+	stashClient := fake.NewSimpleClientset(bb, session)
+	inv := invoker.NewBackupBatchInvoker(stashClient, bb)
+	handler := invoker.NewBackupSessionHandler(stashClient, session)
+
+	if skipped, err := SkipBackupTargetIfDependencyFailed(inv, handler, ref("cache")); err != nil || skipped {
+		t.Errorf("SkipBackupTargetIfDependencyFailed() = %v, %v for an independent member, want false", skipped, err)
+		return
+	}
+	if skipped, err := SkipBackupTargetIfDependencyFailed(inv, handler, ref("app")); err != nil || !skipped {
+		t.Errorf("SkipBackupTargetIfDependencyFailed() = %v, %v for a member with a failed dependency, want true", skipped, err)
+		return
+	}
+	updated, err := stashClient.StashV1beta1().BackupSessions("demo").Get(context.TODO(), session.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, target := range updated.Status.Targets {
+		if invoker.TargetMatched(target.Ref, ref("app")) {
+			if target.Phase != v1beta1.TargetBackupSkipped {
+				t.Errorf("phase of the dependent member = %s, want %s", target.Phase, v1beta1.TargetBackupSkipped)
+			}
+			return
+		}
+	}
+	t.Errorf("status of the dependent member has not been set")
+}
+
+func TestFailBackupTargetIfDeadlineExceeded(t *testing.T) {
+	db := v1beta1.TargetRef{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "db", Namespace: "demo"}
+	deadline := metav1.NewTime(time.Now().Add(-time.Minute))
+	session := &v1beta1.BackupSession{
+		ObjectMeta: metav1.ObjectMeta{Name: "sample-backup-1", Namespace: "demo"},
+		Status: v1beta1.BackupSessionStatus{
+			Targets: []v1beta1.BackupTargetStatus{{Ref: db, Phase: v1beta1.TargetBackupRunning, Deadline: &deadline}},
+		},
+	}
+	stashClient := fake.NewSimpleClientset(session)
+	handler := invoker.NewBackupSessionHandler(stashClient, session)
+
+	if failed, err := FailBackupTargetIfDeadlineExceeded(handler, invoker.BackupTargetInfo{Target: &v1beta1.BackupTarget{Ref: db}}); err != nil || failed {
+		t.Errorf("FailBackupTargetIfDeadlineExceeded() = %v, %v for a target without its own timeout, want false", failed, err)
+		return
+	}
+	info := invoker.BackupTargetInfo{Target: &v1beta1.BackupTarget{Ref: db}, TimeOut: &metav1.Duration{Duration: time.Hour}}
+	if failed, err := FailBackupTargetIfDeadlineExceeded(handler, info); err != nil || !failed {
+		t.Errorf("FailBackupTargetIfDeadlineExceeded() = %v, %v after the deadline, want true", failed, err)
+		return
+	}
+	updated, err := stashClient.StashV1beta1().BackupSessions("demo").Get(context.TODO(), session.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if phase := updated.Status.Targets[0].Phase; phase != v1beta1.TargetBackupFailed {
+		t.Errorf("phase of the target = %s, want %s", phase, v1beta1.TargetBackupFailed)
+	}
+}
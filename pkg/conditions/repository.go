@@ -17,12 +17,17 @@ limitations under the License.
 package conditions
 
 import (
+	"context"
 	"fmt"
 
+	"stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
 	"stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	cs "stash.appscode.dev/apimachinery/client/clientset/versioned"
+	v1alpha1_util "stash.appscode.dev/apimachinery/client/clientset/versioned/typed/stash/v1alpha1/util"
 	"stash.appscode.dev/apimachinery/pkg/invoker"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	kmapi "kmodules.xyz/client-go/api/v1"
 )
 
@@ -249,3 +254,142 @@ func SetBackendSecretFoundConditionToTrue(i any, secretName string) error {
 		return fmt.Errorf("unable to set %s condition. Reason: invoker type unknown", v1beta1.BackendSecretFound)
 	}
 }
+
+func SetBackendReachableConditionToTrue(stashClient cs.Interface, repo *v1alpha1.Repository) error {
+	return setRepositoryCondition(stashClient, repo, kmapi.Condition{
+		Type:               v1alpha1.RepositoryBackendReachable,
+		Status:             metav1.ConditionTrue,
+		Reason:             v1alpha1.BackendReachable,
+		Message:            "Successfully connected to the backend.",
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+func SetBackendReachableConditionToFalse(stashClient cs.Interface, repo *v1alpha1.Repository, err error) error {
+	return setRepositoryCondition(stashClient, repo, kmapi.Condition{
+		Type:               v1alpha1.RepositoryBackendReachable,
+		Status:             metav1.ConditionFalse,
+		Reason:             v1alpha1.BackendUnreachable,
+		Message:            fmt.Sprintf("Failed to connect to the backend. Reason: %v", err.Error()),
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+func SetInitializedConditionToTrue(stashClient cs.Interface, repo *v1alpha1.Repository) error {
+	return setRepositoryCondition(stashClient, repo, kmapi.Condition{
+		Type:               v1alpha1.RepositoryInitialized,
+		Status:             metav1.ConditionTrue,
+		Reason:             v1alpha1.RepositoryInitializationSucceeded,
+		Message:            "Repository has been initialized in the backend.",
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+func SetInitializedConditionToFalse(stashClient cs.Interface, repo *v1alpha1.Repository, err error) error {
+	return setRepositoryCondition(stashClient, repo, kmapi.Condition{
+		Type:               v1alpha1.RepositoryInitialized,
+		Status:             metav1.ConditionFalse,
+		Reason:             v1alpha1.RepositoryInitializationFailed,
+		Message:            fmt.Sprintf("Failed to initialize the repository in the backend. Reason: %v", err.Error()),
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+func SetIntegrityVerifiedConditionToTrue(stashClient cs.Interface, repo *v1alpha1.Repository) error {
+	return setRepositoryCondition(stashClient, repo, kmapi.Condition{
+		Type:               v1alpha1.RepositoryIntegrityVerified,
+		Status:             metav1.ConditionTrue,
+		Reason:             v1alpha1.IntegrityCheckPassed,
+		Message:            "Repository has passed the integrity check.",
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+func SetIntegrityVerifiedConditionToFalse(stashClient cs.Interface, repo *v1alpha1.Repository, err error) error {
+	return setRepositoryCondition(stashClient, repo, kmapi.Condition{
+		Type:               v1alpha1.RepositoryIntegrityVerified,
+		Status:             metav1.ConditionFalse,
+		Reason:             v1alpha1.IntegrityCheckFailed,
+		Message:            fmt.Sprintf("Repository has failed the integrity check. Reason: %v", err.Error()),
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+func SetLockedConditionToTrue(stashClient cs.Interface, repo *v1alpha1.Repository, lockCount int) error {
+	return setRepositoryCondition(stashClient, repo, kmapi.Condition{
+		Type:               v1alpha1.RepositoryLocked,
+		Status:             metav1.ConditionTrue,
+		Reason:             v1alpha1.RepositoryLockFound,
+		Message:            fmt.Sprintf("Found %d lock(s) in the repository.", lockCount),
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+func SetLockedConditionToFalse(stashClient cs.Interface, repo *v1alpha1.Repository) error {
+	return setRepositoryCondition(stashClient, repo, kmapi.Condition{
+		Type:               v1alpha1.RepositoryLocked,
+		Status:             metav1.ConditionFalse,
+		Reason:             v1alpha1.RepositoryLockNotFound,
+		Message:            "No lock found in the repository.",
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+func SetQuotaExceededConditionToTrue(stashClient cs.Interface, repo *v1alpha1.Repository, reason, msg string) error {
+	return setRepositoryCondition(stashClient, repo, kmapi.Condition{
+		Type:               v1alpha1.RepositoryQuotaExceeded,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            msg,
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+func SetQuotaExceededConditionToFalse(stashClient cs.Interface, repo *v1alpha1.Repository) error {
+	return setRepositoryCondition(stashClient, repo, kmapi.Condition{
+		Type:               v1alpha1.RepositoryQuotaExceeded,
+		Status:             metav1.ConditionFalse,
+		Reason:             v1alpha1.RepositoryWithinQuota,
+		Message:            "Repository usage is within the quota.",
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+func SetReplicatedConditionToTrue(stashClient cs.Interface, repo *v1alpha1.Repository) error {
+	return setRepositoryCondition(stashClient, repo, kmapi.Condition{
+		Type:               v1alpha1.RepositoryReplicated,
+		Status:             metav1.ConditionTrue,
+		Reason:             v1alpha1.ReplicationSucceeded,
+		Message:            "Successfully replicated the repository.",
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+func SetReplicatedConditionToFalse(stashClient cs.Interface, repo *v1alpha1.Repository, err error) error {
+	return setRepositoryCondition(stashClient, repo, kmapi.Condition{
+		Type:               v1alpha1.RepositoryReplicated,
+		Status:             metav1.ConditionFalse,
+		Reason:             v1alpha1.ReplicationFailed,
+		Message:            fmt.Sprintf("Failed to replicate the repository. Reason: %v", err.Error()),
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+// setRepositoryCondition sets the condition into the Repository status and recalculates the phase of the Repository
+func setRepositoryCondition(stashClient cs.Interface, repo *v1alpha1.Repository, newCondition kmapi.Condition) error {
+	updated, err := v1alpha1_util.UpdateRepositoryStatus(
+		context.TODO(),
+		stashClient.StashV1alpha1(),
+		repo.ObjectMeta,
+		func(in *v1alpha1.RepositoryStatus) (types.UID, *v1alpha1.RepositoryStatus) {
+			in.SetCondition(newCondition)
+			return repo.UID, in
+		},
+		metav1.UpdateOptions{},
+	)
+	if err != nil {
+		return err
+	}
+	repo.Status = updated.Status
+	return nil
+}
@@ -18,6 +18,7 @@ package conditions
 
 import (
 	"fmt"
+	"time"
 
 	"stash.appscode.dev/apimachinery/apis/stash/v1beta1"
 	"stash.appscode.dev/apimachinery/pkg/invoker"
@@ -269,3 +270,69 @@ func SetRestoreDeadlineExceededConditionToTrue(invoker invoker.RestoreInvoker, t
 		LastTransitionTime: metav1.Now(),
 	})
 }
+
+// FailRestoreTargetIfDeadlineExceeded marks the restore of a member failed if it has not completed within the deadline of the member.
+// It returns true if the deadline has been exceeded.
+func FailRestoreTargetIfDeadlineExceeded(inv invoker.RestoreInvoker, info invoker.RestoreTargetInfo) (bool, error) {
+	if info.Target == nil || info.TimeOut == nil {
+		return false, nil
+	}
+	for _, status := range inv.GetStatus().TargetStatus {
+		if invoker.TargetMatched(status.Ref, info.Target.Ref) && invoker.RestoreMemberDeadlineExceeded(status, time.Now()) {
+			return true, SetRestoreTargetDeadlineExceededConditionToTrue(inv, &info.Target.Ref, *info.TimeOut)
+		}
+	}
+	return false, nil
+}
+
+func SetRestoreTargetDeadlineExceededConditionToTrue(inv invoker.RestoreInvoker, tref *v1beta1.TargetRef, timeOut metav1.Duration) error {
+	return inv.SetCondition(tref, kmapi.Condition{
+		Type:               v1beta1.DeadlineExceeded,
+		Status:             metav1.ConditionTrue,
+		Reason:             v1beta1.FailedToCompleteWithinDeadline,
+		Message:            fmt.Sprintf("Failed to complete restore of %s/%s within %s.", tref.Kind, tref.Name, timeOut),
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+func SetRestorePlannedConditionToTrue(inv invoker.RestoreInvoker, tref *v1beta1.TargetRef) error {
+	return inv.SetCondition(tref, kmapi.Condition{
+		Type:               v1beta1.RestorePlanned,
+		Status:             metav1.ConditionTrue,
+		Reason:             v1beta1.SuccessfullyGeneratedRestorePlan,
+		Message:            "Successfully generated restore plan.",
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+func SetRestorePlannedConditionToFalse(inv invoker.RestoreInvoker, tref *v1beta1.TargetRef, err error) error {
+	return inv.SetCondition(tref, kmapi.Condition{
+		Type:               v1beta1.RestorePlanned,
+		Status:             metav1.ConditionFalse,
+		Reason:             v1beta1.FailedToGenerateRestorePlan,
+		Message:            fmt.Sprintf("Failed to generate restore plan. Reason: %v", err.Error()),
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+// SkipRestoreTargetIfDependencyFailed marks the target skipped if any member it depends on has failed or has been skipped,
+// as the target will never start. It returns true if the target has been skipped.
+func SkipRestoreTargetIfDependencyFailed(inv invoker.RestoreInvoker, tref v1beta1.TargetRef) (bool, error) {
+	deps := inv.FailedDependencies(tref, inv.GetStatus().TargetStatus)
+	if len(deps) == 0 {
+		return false, nil
+	}
+	return true, SetRestoreTargetSkippedConditionToTrue(inv, &tref, v1beta1.SkippedDueToDependencyFailure,
+		fmt.Sprintf("Skipped restoring as the dependencies %s did not succeed.", targetNames(deps)))
+}
+
+// SetRestoreTargetSkippedConditionToTrue marks the target skipped for the given reason (i.e. SkippedDueToDependencyFailure)
+func SetRestoreTargetSkippedConditionToTrue(inv invoker.RestoreInvoker, tref *v1beta1.TargetRef, reason, msg string) error {
+	return inv.SetCondition(tref, kmapi.Condition{
+		Type:               v1beta1.TargetSkipped,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            msg,
+		LastTransitionTime: metav1.Now(),
+	})
+}
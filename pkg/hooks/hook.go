@@ -168,12 +168,17 @@ func IsAllowedByExecutionPolicy(executionPolicy v1beta1.HookExecutionPolicy, sum
 	if summary == nil {
 		return false
 	}
-	if executionPolicy == v1beta1.ExecuteOnFailure && getTargetPhase(summary) != string(v1beta1.TargetBackupFailed) {
+	// A partially succeeded session has failed targets. So, the hooks that are executed on failure are executed too.
+	if executionPolicy == v1beta1.ExecuteOnFailure && getTargetPhase(summary) != string(v1beta1.TargetBackupFailed) &&
+		getTargetPhase(summary) != string(v1beta1.BackupSessionPartiallySucceeded) {
 		return false
 	}
 	if executionPolicy == v1beta1.ExecuteOnSuccess && getTargetPhase(summary) != string(v1beta1.TargetBackupSucceeded) {
 		return false
 	}
+	if executionPolicy == v1beta1.ExecuteOnPartialSuccess && getTargetPhase(summary) != string(v1beta1.BackupSessionPartiallySucceeded) {
+		return false
+	}
 	if executionPolicy == v1beta1.ExecuteOnRetryFailure && (getTargetPhase(summary) != string(v1beta1.TargetBackupFailed) || summary.RetryLeft != 0) {
 		return false
 	}
@@ -215,3 +215,55 @@ func conditionalHookTemplate(msg string) *prober.Handler {
 		},
 	}
 }
+
+func TestIsAllowedByExecutionPolicy(t *testing.T) {
+	summary := func(phase v1beta1.BackupSessionPhase) *v1beta1.Summary {
+		s := &v1beta1.Summary{}
+		s.Status.Phase = string(phase)
+		return s
+	}
+	tests := []struct {
+		name    string
+		policy  v1beta1.HookExecutionPolicy
+		summary *v1beta1.Summary
+		want    bool
+	}{
+		{
+			name:    "OnFailure hook on partial success",
+			policy:  v1beta1.ExecuteOnFailure,
+			summary: summary(v1beta1.BackupSessionPartiallySucceeded),
+			want:    true,
+		},
+		{
+			name:    "OnSuccess hook on partial success",
+			policy:  v1beta1.ExecuteOnSuccess,
+			summary: summary(v1beta1.BackupSessionPartiallySucceeded),
+			want:    false,
+		},
+		{
+			name:    "OnPartialSuccess hook on partial success",
+			policy:  v1beta1.ExecuteOnPartialSuccess,
+			summary: summary(v1beta1.BackupSessionPartiallySucceeded),
+			want:    true,
+		},
+		{
+			name:    "OnPartialSuccess hook on failure",
+			policy:  v1beta1.ExecuteOnPartialSuccess,
+			summary: summary(v1beta1.BackupSessionFailed),
+			want:    false,
+		},
+		{
+			name:    "OnFinalRetryFailure hook on partial success",
+			policy:  v1beta1.ExecuteOnRetryFailure,
+			summary: summary(v1beta1.BackupSessionPartiallySucceeded),
+			want:    false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := IsAllowedByExecutionPolicy(test.policy, test.summary); got != test.want {
+				t.Errorf("IsAllowedByExecutionPolicy() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -35,6 +35,7 @@ type BackupInvoker interface {
 	MetadataHandler
 	ConditionHandler
 	SessionHandler
+	ConcurrencyHandler
 	BackupExecutionOrderHandler
 	BackupTargetHandler
 	RepositoryGetter
@@ -47,19 +48,40 @@ type BackupInvoker interface {
 }
 
 type SessionHandler interface {
+	// NewSession returns a new BackupSession of the invoker for the default schedule
 	NewSession() *v1beta1.BackupSession
+	// NewScheduledSession returns a new BackupSession of the invoker. schedule is the name of the scheduled policy
+	// that has triggered the session. It is empty for the default schedule.
+	NewScheduledSession(schedule string) *v1beta1.BackupSession
+}
+
+type ConcurrencyHandler interface {
+	GetConcurrencyPolicy() v1beta1.ConcurrencyPolicy
+	GetStartingDeadline() *metav1.Duration
 }
 
 type BackupExecutionOrderHandler interface {
 	GetExecutionOrder() v1beta1.ExecutionOrder
 	NextInOrder(curTarget v1beta1.TargetRef, targetStatus []v1beta1.BackupTargetStatus) bool
+	// FailedDependencies returns the members the target depends on that have failed or have been skipped.
+	// The target will never start. So, it should be skipped if there is any.
+	FailedDependencies(curTarget v1beta1.TargetRef, targetStatus []v1beta1.BackupTargetStatus) []v1beta1.TargetRef
+	// GetFailurePolicy returns how to handle the failure of a member. It returns nil for a single target invoker.
+	GetFailurePolicy() *v1beta1.FailurePolicy
 }
 
 type BackupTargetHandler interface {
 	GetTargetInfo() []BackupTargetInfo
 	GetRuntimeSettings() ofst.RuntimeSettings
 	GetSchedule() string
+	// GetBackupSchedule returns the schedule of the invoker along with the time zone it should be evaluated in
+	GetBackupSchedule() v1beta1.BackupSchedule
+	// GetBackupRestriction returns the restriction (i.e. backup window, blackout) that prevents a backup from starting
+	// at the given time. It returns nil if the backup is allowed.
+	GetBackupRestriction(t time.Time) (*v1beta1.BackupRestriction, error)
 	GetRetentionPolicy() v1alpha1.RetentionPolicy
+	// GetScheduledPolicies returns the additional schedules of the invoker along with their policies
+	GetScheduledPolicies() []v1beta1.ScheduledPolicy
 	IsPaused() bool
 	GetBackupHistoryLimit() *int32
 	GetGlobalHooks() *v1beta1.BackupHooks
@@ -77,6 +99,10 @@ type BackupTargetInfo struct {
 	TempDir               v1beta1.EmptyDirSettings
 	InterimVolumeTemplate *ofst.PersistentVolumeClaim
 	Hooks                 *v1beta1.BackupHooks
+	// TimeOut and RetryConfig are set only if the target has its own timeout and retry configuration
+	// (i.e. a member of a BackupBatch). Otherwise, the timeout and the retry configuration of the invoker apply.
+	TimeOut     *metav1.Duration
+	RetryConfig *v1beta1.RetryConfig
 }
 
 func NewBackupInvoker(stashClient cs.Interface, kind, name, namespace string) (BackupInvoker, error) {
@@ -170,7 +196,26 @@ func TargetBackupCompleted(ref v1beta1.TargetRef, targetStatus []v1beta1.BackupT
 	for i := range targetStatus {
 		if TargetMatched(ref, targetStatus[i].Ref) {
 			return targetStatus[i].Phase == v1beta1.TargetBackupSucceeded ||
-				targetStatus[i].Phase == v1beta1.TargetBackupFailed
+				targetStatus[i].Phase == v1beta1.TargetBackupSkipped ||
+				(targetStatus[i].Phase == v1beta1.TargetBackupFailed && !BackupTargetRetryable(targetStatus[i]))
+		}
+	}
+	return false
+}
+
+func targetBackupSucceeded(ref v1beta1.TargetRef, targetStatus []v1beta1.BackupTargetStatus) bool {
+	for i := range targetStatus {
+		if TargetMatched(ref, targetStatus[i].Ref) {
+			return targetStatus[i].Phase == v1beta1.TargetBackupSucceeded
+		}
+	}
+	return false
+}
+
+func targetBackupFailed(targetStatus []v1beta1.BackupTargetStatus) bool {
+	for i := range targetStatus {
+		if targetStatus[i].Phase == v1beta1.TargetBackupFailed && !BackupTargetRetryable(targetStatus[i]) {
+			return true
 		}
 	}
 	return false
@@ -225,9 +270,14 @@ func getTargetBackupSummary(stashClient cs.Interface, target v1beta1.TargetRef,
 	}
 	summary.Status.Duration = time.Since(backupSession.CreationTimestamp.Time).Round(time.Second).String()
 	summary.RetryLeft = backupSession.Spec.RetryLeft
+	targetFailed := false
 	if target.Name != "" {
 		for _, t := range backupSession.Status.Targets {
 			if TargetMatched(target, t.Ref) {
+				if t.RetryLeft != nil {
+					// the target has its own retry configuration
+					summary.RetryLeft = *t.RetryLeft
+				}
 				failureFound, reason := checkBackupFailureInTargetStatus(t)
 				if failureFound {
 					summary.Status.Phase = string(v1beta1.BackupSessionFailed)
@@ -237,14 +287,24 @@ func getTargetBackupSummary(stashClient cs.Interface, target v1beta1.TargetRef,
 			}
 		}
 	} else {
+		succeeded := 0
 		for _, t := range backupSession.Status.Targets {
 			failureFound, reason := checkBackupFailureInTargetStatus(t)
-			if failureFound {
-				summary.Status.Phase = string(v1beta1.BackupSessionFailed)
+			if !failureFound {
+				if t.Phase == v1beta1.TargetBackupSucceeded {
+					succeeded++
+				}
+				continue
+			}
+			targetFailed = true
+			if summary.Status.Error == "" {
 				summary.Status.Error = reason
-				return summary
 			}
 		}
+		if targetFailed && !backupSession.Spec.FailurePolicy.AllowsPartialSuccess(succeeded) {
+			summary.Status.Phase = string(v1beta1.BackupSessionFailed)
+			return summary
+		}
 	}
 
 	failureFound, reason := checkFailureInConditions(backupSession.Status.Conditions)
@@ -254,6 +314,11 @@ func getTargetBackupSummary(stashClient cs.Interface, target v1beta1.TargetRef,
 		return summary
 	}
 
+	if targetFailed {
+		// some targets have failed but the failure policy allows partial success
+		summary.Status.Phase = string(v1beta1.BackupSessionPartiallySucceeded)
+		return summary
+	}
 	summary.Status.Phase = string(v1beta1.RestoreSucceeded)
 	return summary
 }
@@ -279,3 +344,31 @@ func checkBackupFailureInHostStatus(status []v1beta1.HostBackupStats) (bool, str
 	}
 	return false, ""
 }
+
+// dependenciesSucceeded returns true if all the members the cur-th member depends on have succeeded.
+// It returns true for an unknown member (i.e. negative index) so that it does not get stuck.
+func dependenciesSucceeded(graph [][]int, cur int, succeeded func(i int) bool) bool {
+	if cur < 0 || cur >= len(graph) {
+		return true
+	}
+	for _, dep := range graph[cur] {
+		if !succeeded(dep) {
+			return false
+		}
+	}
+	return true
+}
+
+// failedDependencies returns the indexes of the members the cur-th member depends on that have failed
+func failedDependencies(graph [][]int, cur int, failed func(i int) bool) []int {
+	if cur < 0 || cur >= len(graph) {
+		return nil
+	}
+	var deps []int
+	for _, dep := range graph[cur] {
+		if failed(dep) {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
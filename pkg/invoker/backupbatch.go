@@ -19,6 +19,7 @@ package invoker
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"stash.appscode.dev/apimachinery/apis"
@@ -45,6 +46,7 @@ import (
 type BackupBatchInvoker struct {
 	backupBatch *v1beta1.BackupBatch
 	stashClient cs.Interface
+	session     *v1beta1.BackupSession
 }
 
 func NewBackupBatchInvoker(stashClient cs.Interface, backupBatch *v1beta1.BackupBatch) BackupInvoker {
@@ -154,6 +156,11 @@ func (inv *BackupBatchInvoker) IsConditionTrue(target *v1beta1.TargetRef, condit
 }
 
 func (inv *BackupBatchInvoker) GetTargetInfo() []BackupTargetInfo {
+	return sessionTargetInfo(inv.session, inv.memberTargetInfo())
+}
+
+// memberTargetInfo returns the target info of all the members irrespective of the session the invoker is scoped to
+func (inv *BackupBatchInvoker) memberTargetInfo() []BackupTargetInfo {
 	var targetInfo []BackupTargetInfo
 	for _, member := range inv.backupBatch.Spec.Members {
 		targetInfo = append(targetInfo, BackupTargetInfo{
@@ -163,6 +170,8 @@ func (inv *BackupBatchInvoker) GetTargetInfo() []BackupTargetInfo {
 			TempDir:               member.TempDir,
 			InterimVolumeTemplate: member.InterimVolumeTemplate,
 			Hooks:                 member.Hooks,
+			TimeOut:               member.TimeOut,
+			RetryConfig:           member.RetryConfig,
 		})
 	}
 	return targetInfo
@@ -215,6 +224,23 @@ func (inv *BackupBatchInvoker) GetSchedule() string {
 	return inv.backupBatch.Spec.Schedule
 }
 
+func (inv *BackupBatchInvoker) GetBackupSchedule() v1beta1.BackupSchedule {
+	return inv.backupBatch.Spec.GetBackupSchedule()
+}
+
+// GetBackupRestriction always returns nil as BackupBatch does not support backup window or blackout periods
+func (inv *BackupBatchInvoker) GetBackupRestriction(_ time.Time) (*v1beta1.BackupRestriction, error) {
+	return nil, nil
+}
+
+func (inv *BackupBatchInvoker) GetConcurrencyPolicy() v1beta1.ConcurrencyPolicy {
+	return inv.backupBatch.Spec.ConcurrencyPolicy
+}
+
+func (inv *BackupBatchInvoker) GetStartingDeadline() *metav1.Duration {
+	return inv.backupBatch.Spec.StartingDeadline
+}
+
 func (inv *BackupBatchInvoker) IsPaused() bool {
 	return inv.backupBatch.Spec.Paused
 }
@@ -228,12 +254,37 @@ func (inv *BackupBatchInvoker) GetGlobalHooks() *v1beta1.BackupHooks {
 }
 
 func (inv *BackupBatchInvoker) GetExecutionOrder() v1beta1.ExecutionOrder {
+	// NextInOrder must be consulted before starting each member when the members depend on each other
+	if v1beta1.HasDependencies(inv.backupBatch.MemberDependencies()) {
+		return v1beta1.Sequential
+	}
 	return inv.backupBatch.Spec.ExecutionOrder
 }
 
 func (inv *BackupBatchInvoker) NextInOrder(curTarget v1beta1.TargetRef, targetStatus []v1beta1.BackupTargetStatus) bool {
-	for _, t := range inv.GetTargetInfo() {
-		if t.Target != nil {
+	policy := inv.GetFailurePolicy()
+	if inv.session != nil {
+		// the session keeps the policy it was created with
+		policy = inv.session.Spec.FailurePolicy
+	}
+	if policy.StopOnFailure() && targetBackupFailed(targetStatus) {
+		// Don't start any more members. The session will be considered Failed once the running members complete.
+		return false
+	}
+	// The members that are not selected by the session are not backed up. So, they must not block the others.
+	targetInfo := inv.memberTargetInfo()
+	if graph := inv.backupBatch.MemberDependencies(); v1beta1.HasDependencies(graph) {
+		cur := slices.IndexFunc(targetInfo, func(t BackupTargetInfo) bool {
+			return t.Target != nil && TargetMatched(t.Target.Ref, curTarget)
+		})
+		return dependenciesSucceeded(graph, cur, func(i int) bool {
+			return targetInfo[i].Target == nil ||
+				!sessionIncludesTarget(inv.session, targetInfo[i].Target.Ref) ||
+				targetBackupSucceeded(targetInfo[i].Target.Ref, targetStatus)
+		})
+	}
+	for _, t := range targetInfo {
+		if t.Target != nil && sessionIncludesTarget(inv.session, t.Target.Ref) {
 			if TargetMatched(t.Target.Ref, curTarget) {
 				return true
 			}
@@ -246,6 +297,27 @@ func (inv *BackupBatchInvoker) NextInOrder(curTarget v1beta1.TargetRef, targetSt
 	return true
 }
 
+func (inv *BackupBatchInvoker) FailedDependencies(curTarget v1beta1.TargetRef, targetStatus []v1beta1.BackupTargetStatus) []v1beta1.TargetRef {
+	targetInfo := inv.memberTargetInfo()
+	cur := slices.IndexFunc(targetInfo, func(t BackupTargetInfo) bool {
+		return t.Target != nil && TargetMatched(t.Target.Ref, curTarget)
+	})
+	var refs []v1beta1.TargetRef
+	for _, i := range failedDependencies(inv.backupBatch.MemberDependencies(), cur, func(i int) bool {
+		return targetInfo[i].Target != nil &&
+			sessionIncludesTarget(inv.session, targetInfo[i].Target.Ref) &&
+			TargetBackupCompleted(targetInfo[i].Target.Ref, targetStatus) &&
+			!targetBackupSucceeded(targetInfo[i].Target.Ref, targetStatus)
+	}) {
+		refs = append(refs, targetInfo[i].Target.Ref)
+	}
+	return refs
+}
+
+func (inv *BackupBatchInvoker) GetFailurePolicy() *v1beta1.FailurePolicy {
+	return inv.backupBatch.Spec.FailurePolicy
+}
+
 func (inv *BackupBatchInvoker) GetHash() string {
 	return inv.backupBatch.GetSpecHash()
 }
@@ -266,6 +338,10 @@ func (inv *BackupBatchInvoker) GetRetentionPolicy() v1alpha1.RetentionPolicy {
 	return inv.backupBatch.Spec.RetentionPolicy
 }
 
+func (inv *BackupBatchInvoker) GetScheduledPolicies() []v1beta1.ScheduledPolicy {
+	return nil
+}
+
 func (inv *BackupBatchInvoker) GetPhase() v1beta1.BackupInvokerPhase {
 	return inv.backupBatch.Status.Phase
 }
@@ -299,6 +375,10 @@ func (inv *BackupBatchInvoker) GetRetryConfig() *v1beta1.RetryConfig {
 }
 
 func (inv *BackupBatchInvoker) NewSession() *v1beta1.BackupSession {
+	return inv.NewScheduledSession("")
+}
+
+func (inv *BackupBatchInvoker) NewScheduledSession(schedule string) *v1beta1.BackupSession {
 	retryLimit := int32(0)
 	if inv.backupBatch.Spec.RetryConfig != nil {
 		retryLimit = inv.backupBatch.Spec.RetryConfig.MaxRetry
@@ -317,7 +397,9 @@ func (inv *BackupBatchInvoker) NewSession() *v1beta1.BackupSession {
 				Kind:     v1beta1.ResourceKindBackupBatch,
 				Name:     inv.backupBatch.Name,
 			},
-			RetryLeft: retryLimit,
+			RetryLeft:     retryLimit,
+			Schedule:      schedule,
+			FailurePolicy: inv.backupBatch.Spec.FailurePolicy.DeepCopy(),
 		},
 	}
 
@@ -45,6 +45,7 @@ import (
 type BackupConfigurationInvoker struct {
 	backupConfig *v1beta1.BackupConfiguration
 	stashClient  cs.Interface
+	session      *v1beta1.BackupSession
 }
 
 func NewBackupConfigurationInvoker(stashClient cs.Interface, backupConfig *v1beta1.BackupConfiguration) BackupInvoker {
@@ -140,7 +141,7 @@ func (inv *BackupConfigurationInvoker) IsConditionTrue(target *v1beta1.TargetRef
 }
 
 func (inv *BackupConfigurationInvoker) GetTargetInfo() []BackupTargetInfo {
-	return []BackupTargetInfo{
+	return sessionTargetInfo(inv.session, []BackupTargetInfo{
 		{
 			Task:                  inv.backupConfig.Spec.Task,
 			Target:                getBackupTarget(inv.backupConfig.Spec.Target.DeepCopy(), inv.backupConfig.Namespace),
@@ -149,7 +150,7 @@ func (inv *BackupConfigurationInvoker) GetTargetInfo() []BackupTargetInfo {
 			InterimVolumeTemplate: inv.backupConfig.Spec.InterimVolumeTemplate,
 			Hooks:                 inv.backupConfig.Spec.Hooks,
 		},
-	}
+	})
 }
 
 func (inv *BackupConfigurationInvoker) GetDriver() v1beta1.Snapshotter {
@@ -187,6 +188,25 @@ func (inv *BackupConfigurationInvoker) GetSchedule() string {
 	return inv.backupConfig.Spec.Schedule
 }
 
+func (inv *BackupConfigurationInvoker) GetBackupSchedule() v1beta1.BackupSchedule {
+	return inv.backupConfig.Spec.GetBackupSchedule()
+}
+
+func (inv *BackupConfigurationInvoker) GetBackupRestriction(t time.Time) (*v1beta1.BackupRestriction, error) {
+	return inv.backupConfig.Spec.GetBackupRestriction(t)
+}
+
+func (inv *BackupConfigurationInvoker) GetConcurrencyPolicy() v1beta1.ConcurrencyPolicy {
+	if inv.backupConfig.Spec.ConcurrencyPolicy == "" {
+		return v1beta1.ForbidConcurrent
+	}
+	return inv.backupConfig.Spec.ConcurrencyPolicy
+}
+
+func (inv *BackupConfigurationInvoker) GetStartingDeadline() *metav1.Duration {
+	return inv.backupConfig.Spec.StartingDeadline
+}
+
 func (inv *BackupConfigurationInvoker) IsPaused() bool {
 	return inv.backupConfig.Spec.Paused
 }
@@ -218,6 +238,15 @@ func (inv *BackupConfigurationInvoker) NextInOrder(curTarget v1beta1.TargetRef,
 	return true
 }
 
+// FailedDependencies always returns nil as the target of a BackupConfiguration does not depend on anything
+func (inv *BackupConfigurationInvoker) FailedDependencies(_ v1beta1.TargetRef, _ []v1beta1.BackupTargetStatus) []v1beta1.TargetRef {
+	return nil
+}
+
+func (inv *BackupConfigurationInvoker) GetFailurePolicy() *v1beta1.FailurePolicy {
+	return nil
+}
+
 func (inv *BackupConfigurationInvoker) GetHash() string {
 	return inv.backupConfig.GetSpecHash()
 }
@@ -234,6 +263,10 @@ func (inv *BackupConfigurationInvoker) GetRetentionPolicy() v1alpha1.RetentionPo
 	return inv.backupConfig.Spec.RetentionPolicy
 }
 
+func (inv *BackupConfigurationInvoker) GetScheduledPolicies() []v1beta1.ScheduledPolicy {
+	return inv.backupConfig.Spec.Schedules
+}
+
 func (inv *BackupConfigurationInvoker) GetPhase() v1beta1.BackupInvokerPhase {
 	return inv.backupConfig.Status.Phase
 }
@@ -287,6 +320,10 @@ func (inv *BackupConfigurationInvoker) GetRetryConfig() *v1beta1.RetryConfig {
 }
 
 func (inv *BackupConfigurationInvoker) NewSession() *v1beta1.BackupSession {
+	return inv.NewScheduledSession("")
+}
+
+func (inv *BackupConfigurationInvoker) NewScheduledSession(schedule string) *v1beta1.BackupSession {
 	retryLimit := int32(0)
 	if inv.backupConfig.Spec.RetryConfig != nil {
 		retryLimit = inv.backupConfig.Spec.RetryConfig.MaxRetry
@@ -306,8 +343,13 @@ func (inv *BackupConfigurationInvoker) NewSession() *v1beta1.BackupSession {
 				Name:     inv.backupConfig.Name,
 			},
 			RetryLeft: retryLimit,
+			Schedule:  schedule,
 		},
 	}
+	// tag the snapshots so that the retention policy of the schedule is applied only on them
+	if policy := inv.backupConfig.Spec.GetScheduledPolicy(schedule); policy != nil {
+		session.Spec.Tags = []string{policy.GetTag()}
+	}
 
 	return session
 }
@@ -18,6 +18,7 @@ package invoker
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"stash.appscode.dev/apimachinery/apis/stash/v1beta1"
@@ -56,8 +57,12 @@ func (h *BackupSessionHandler) UpdateStatus(status *v1beta1.BackupSessionStatus)
 					in.Targets = upsertBackupMembersStatus(in.Targets, status.Targets[i])
 				}
 			}
+			if h.backupSession.Spec.FailurePolicy.StopOnFailure() && backupStoppedOnFailure(in.Targets) {
+				// the pending targets will never start
+				in.Targets = skipPendingBackupTargets(in.Targets)
+			}
 
-			in.Phase = calculateBackupSessionPhase(in)
+			in.Phase = calculateBackupSessionPhase(in, h.backupSession.Spec.FailurePolicy)
 			if IsBackupCompleted(in.Phase) && in.SessionDuration == "" {
 				in.SessionDuration = time.Since(h.backupSession.ObjectMeta.CreationTimestamp.Time).Round(time.Second).String()
 			}
@@ -124,6 +129,7 @@ func (h *BackupSessionHandler) GetBackupSession() *v1beta1.BackupSession {
 
 func IsBackupCompleted(phase v1beta1.BackupSessionPhase) bool {
 	return phase == v1beta1.BackupSessionSucceeded ||
+		phase == v1beta1.BackupSessionPartiallySucceeded ||
 		phase == v1beta1.BackupSessionFailed ||
 		phase == v1beta1.BackupSessionSkipped ||
 		phase == v1beta1.BackupSessionUnknown
@@ -131,7 +137,10 @@ func IsBackupCompleted(phase v1beta1.BackupSessionPhase) bool {
 
 func BackupCompletedForAllTargets(status []v1beta1.BackupTargetStatus, totalTargets int) bool {
 	for _, t := range status {
-		if t.Phase == v1beta1.TargetBackupFailed || t.Phase == v1beta1.TargetBackupSucceeded {
+		if BackupTargetRetryable(t) {
+			return false
+		}
+		if t.Phase == v1beta1.TargetBackupFailed || t.Phase == v1beta1.TargetBackupSucceeded || t.Phase == v1beta1.TargetBackupSkipped {
 			continue
 		}
 		if t.TotalHosts == nil || !backupCompletedForAllHosts(t.Stats, *t.TotalHosts) {
@@ -191,6 +200,14 @@ func upsertBackupTargetStatus(cur, new v1beta1.BackupTargetStatus) v1beta1.Backu
 		cur.PostBackupActions = upsertArray(cur.PostBackupActions, new.PostBackupActions)
 	}
 
+	// The deadline and the retry attempts are set together when the backup of the target starts.
+	// Afterwards, they are only changed by RetryTarget. A target that has used all of its retry
+	// attempts keeps zero retry attempts. So, it is never initialized again.
+	if cur.Deadline == nil && cur.RetryLeft == nil {
+		cur.Deadline = new.Deadline
+		cur.RetryLeft = new.RetryLeft
+	}
+
 	cur.Phase = calculateBackupTargetPhase(cur)
 	return cur
 }
@@ -208,9 +225,14 @@ func upsertBackupHostStatus(cur, new []v1beta1.HostBackupStats) []v1beta1.HostBa
 }
 
 func calculateBackupTargetPhase(status v1beta1.BackupTargetStatus) v1beta1.TargetPhase {
+	if cutil.IsConditionTrue(status.Conditions, v1beta1.TargetSkipped) {
+		return v1beta1.TargetBackupSkipped
+	}
+
 	if cutil.IsConditionFalse(status.Conditions, v1beta1.BackupExecutorEnsured) ||
 		cutil.IsConditionFalse(status.Conditions, v1beta1.PreBackupHookExecutionSucceeded) ||
 		cutil.IsConditionTrue(status.Conditions, v1beta1.BackupDisrupted) ||
+		cutil.IsConditionTrue(status.Conditions, v1beta1.DeadlineExceeded) ||
 		cutil.IsConditionFalse(status.Conditions, v1beta1.PostBackupHookExecutionSucceeded) {
 		return v1beta1.TargetBackupFailed
 	}
@@ -240,7 +262,7 @@ func calculateBackupTargetPhase(status v1beta1.BackupTargetStatus) v1beta1.Targe
 	return v1beta1.TargetBackupRunning
 }
 
-func calculateBackupSessionPhase(status *v1beta1.BackupSessionStatus) v1beta1.BackupSessionPhase {
+func calculateBackupSessionPhase(status *v1beta1.BackupSessionStatus, policy *v1beta1.FailurePolicy) v1beta1.BackupSessionPhase {
 	if cutil.IsConditionFalse(status.Conditions, v1beta1.MetricsPushed) {
 		return v1beta1.BackupSessionFailed
 	}
@@ -249,8 +271,14 @@ func calculateBackupSessionPhase(status *v1beta1.BackupSessionStatus) v1beta1.Ba
 		return v1beta1.BackupSessionSkipped
 	}
 
+	// a replaced session is stopped immediately. So, it will not push any metrics.
+	if cutil.IsConditionTrue(status.Conditions, v1beta1.BackupReplaced) {
+		return v1beta1.BackupSessionFailed
+	}
+
 	if cutil.IsConditionTrue(status.Conditions, v1beta1.MetricsPushed) &&
 		(cutil.IsConditionTrue(status.Conditions, v1beta1.DeadlineExceeded) ||
+			cutil.IsConditionTrue(status.Conditions, v1beta1.RepositoryQuotaExceeded) ||
 			cutil.IsConditionFalse(status.Conditions, v1beta1.BackupHistoryCleaned) ||
 			cutil.IsConditionFalse(status.Conditions, v1beta1.GlobalPreBackupHookSucceeded) ||
 			cutil.IsConditionFalse(status.Conditions, v1beta1.GlobalPostBackupHookSucceeded)) {
@@ -263,30 +291,82 @@ func calculateBackupSessionPhase(status *v1beta1.BackupSessionStatus) v1beta1.Ba
 
 	failedTargetCount := 0
 	successfulTargetCount := 0
+	runningTargetCount := 0
 
 	for _, t := range status.Targets {
-		switch t.Phase {
-		case v1beta1.TargetBackupFailed:
+		switch {
+		case BackupTargetRetryable(t):
+			// the backup of the target will be retried
+			runningTargetCount++
+		case t.Phase == v1beta1.TargetBackupFailed, t.Phase == v1beta1.TargetBackupSkipped:
+			// a skipped target has not been backed up. So, it is considered as failed.
 			failedTargetCount++
-		case v1beta1.TargetBackupSucceeded:
+		case t.Phase == v1beta1.TargetBackupSucceeded:
 			successfulTargetCount++
+		case t.Phase == v1beta1.TargetBackupRunning:
+			runningTargetCount++
 		}
 	}
 	completedTargets := successfulTargetCount + failedTargetCount
+	// the pending targets will never start once a target fails if the failure policy stops on failure
+	stoppedOnFailure := failedTargetCount > 0 && runningTargetCount == 0 && policy.StopOnFailure()
 
-	if completedTargets == len(status.Targets) && cutil.IsConditionTrue(status.Conditions, v1beta1.MetricsPushed) { // Pushing metrics is the last step.
-		if failedTargetCount > 0 ||
-			cutil.IsConditionFalse(status.Conditions, v1beta1.RetentionPolicyApplied) ||
+	if (completedTargets == len(status.Targets) || stoppedOnFailure) && cutil.IsConditionTrue(status.Conditions, v1beta1.MetricsPushed) { // Pushing metrics is the last step.
+		if cutil.IsConditionFalse(status.Conditions, v1beta1.RetentionPolicyApplied) ||
 			cutil.IsConditionFalse(status.Conditions, v1beta1.RepositoryMetricsPushed) ||
 			cutil.IsConditionFalse(status.Conditions, v1beta1.RepositoryIntegrityVerified) {
 			return v1beta1.BackupSessionFailed
 		}
+		if failedTargetCount > 0 {
+			if !stoppedOnFailure && policy.AllowsPartialSuccess(successfulTargetCount) {
+				return v1beta1.BackupSessionPartiallySucceeded
+			}
+			return v1beta1.BackupSessionFailed
+		}
 		return v1beta1.BackupSessionSucceeded
 	}
 
 	return v1beta1.BackupSessionRunning
 }
 
+// BackupSessionPhaseAfterMetrics returns the phase the session will have once its metrics have been pushed.
+// Pushing metrics is the last step of a session, so it is used to decide the metrics of the session itself.
+func BackupSessionPhaseAfterMetrics(session *v1beta1.BackupSession) v1beta1.BackupSessionPhase {
+	status := session.Status.DeepCopy()
+	status.Conditions = cutil.SetCondition(status.Conditions, kmapi.Condition{
+		Type:   v1beta1.MetricsPushed,
+		Status: metav1.ConditionTrue,
+	})
+	return calculateBackupSessionPhase(status, session.Spec.FailurePolicy)
+}
+
+// backupStoppedOnFailure returns true if a target has failed and no other target is running anymore
+func backupStoppedOnFailure(targets []v1beta1.BackupTargetStatus) bool {
+	for _, t := range targets {
+		if t.Phase == v1beta1.TargetBackupRunning || BackupTargetRetryable(t) {
+			return false
+		}
+	}
+	return targetBackupFailed(targets)
+}
+
+func skipPendingBackupTargets(targets []v1beta1.BackupTargetStatus) []v1beta1.BackupTargetStatus {
+	for i := range targets {
+		if targets[i].Phase != v1beta1.TargetBackupPending && targets[i].Phase != "" {
+			continue
+		}
+		targets[i].Conditions = cutil.SetCondition(targets[i].Conditions, kmapi.Condition{
+			Type:               v1beta1.TargetSkipped,
+			Status:             metav1.ConditionTrue,
+			Reason:             v1beta1.SkippedDueToFailurePolicy,
+			Message:            "Skipped taking backup as another target has failed.",
+			LastTransitionTime: metav1.Now(),
+		})
+		targets[i].Phase = calculateBackupTargetPhase(targets[i])
+	}
+	return targets
+}
+
 func backupHostEntryIndex(entries []v1beta1.HostBackupStats, target v1beta1.HostBackupStats) (int, bool) {
 	for i := range entries {
 		if entries[i].Hostname == target.Hostname {
@@ -315,3 +395,69 @@ func isAllTargetBackupPending(status []v1beta1.BackupTargetStatus) bool {
 	}
 	return true
 }
+
+// OnDemandBackupOptions specifies the overrides of an on-demand BackupSession
+type OnDemandBackupOptions struct {
+	// Targets specifies the members of a BackupBatch to backup. All the members are backed up if it is empty.
+	Targets []v1beta1.TargetRef
+	// Tags specifies the additional tags to add to the snapshots
+	Tags []string
+	// SkipRetentionPolicy skips applying the retention policy after the backup
+	SkipRetentionPolicy bool
+	// Params overrides the parameters of the backup Task
+	Params []v1beta1.Param
+	// Reason specifies why the backup has been triggered
+	Reason string
+	// Requestor specifies who has triggered the backup
+	Requestor string
+	// IgnoreRestrictions starts the backup even outside of the backup window or during a blackout period
+	IgnoreRestrictions bool
+}
+
+// NewOnDemandSession returns a new BackupSession for the invoker with the given overrides.
+// It returns error if any of the targets does not belong to the invoker.
+func NewOnDemandSession(inv BackupInvoker, opt OnDemandBackupOptions) (*v1beta1.BackupSession, error) {
+	for _, target := range opt.Targets {
+		if target.Namespace == "" {
+			target.Namespace = inv.GetObjectMeta().Namespace
+		}
+		if !invokerHasTarget(inv, target) {
+			return nil, fmt.Errorf("target %s %s does not belong to %s %s", target.Kind, target.Name, inv.GetTypeMeta().Kind, inv.GetObjectMeta().Name)
+		}
+	}
+
+	session := inv.NewSession()
+	if ownerRef := inv.GetOwnerRef(); ownerRef != nil {
+		session.OwnerReferences = append(session.OwnerReferences, *ownerRef)
+	}
+	session.Spec.Targets = opt.Targets
+	session.Spec.Tags = opt.Tags
+	session.Spec.SkipRetentionPolicy = opt.SkipRetentionPolicy
+	session.Spec.Params = opt.Params
+	session.Spec.Reason = opt.Reason
+	session.Spec.Requestor = opt.Requestor
+	session.Spec.IgnoreRestrictions = opt.IgnoreRestrictions
+
+	if err := session.ValidateCreate().ToAggregate(); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// CreateOnDemandSession creates a new BackupSession for the invoker with the given overrides
+func CreateOnDemandSession(stashClient cs.Interface, inv BackupInvoker, opt OnDemandBackupOptions) (*v1beta1.BackupSession, error) {
+	session, err := NewOnDemandSession(inv, opt)
+	if err != nil {
+		return nil, err
+	}
+	return stashClient.StashV1beta1().BackupSessions(session.Namespace).Create(context.TODO(), session, metav1.CreateOptions{})
+}
+
+func invokerHasTarget(inv BackupInvoker, ref v1beta1.TargetRef) bool {
+	for _, t := range inv.GetTargetInfo() {
+		if t.Target != nil && TargetMatched(t.Target.Ref, ref) {
+			return true
+		}
+	}
+	return false
+}
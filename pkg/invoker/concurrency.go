@@ -0,0 +1,103 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package invoker
+
+import (
+	"fmt"
+	"time"
+
+	"stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+)
+
+// ConcurrencyAction is the action to take for a new BackupSession according to the concurrency policy of its invoker
+type ConcurrencyAction string
+
+const (
+	// ConcurrencyActionRun indicates that the BackupSession can start now
+	ConcurrencyActionRun ConcurrencyAction = "Run"
+	// ConcurrencyActionSkip indicates that the BackupSession must be skipped
+	ConcurrencyActionSkip ConcurrencyAction = "Skip"
+	// ConcurrencyActionWait indicates that the BackupSession must wait for the previous BackupSessions to complete
+	ConcurrencyActionWait ConcurrencyAction = "Wait"
+)
+
+// ConcurrencyDecision describes how a new BackupSession should be handled
+type ConcurrencyDecision struct {
+	Action ConcurrencyAction
+	// Reason is the reason of the BackupSkipped condition when the BackupSession is skipped
+	Reason string
+	// Message is a human-readable explanation of the decision
+	Message string
+	// Replace lists the BackupSessions that must be stopped before the new BackupSession starts.
+	// It is set only for the "Replace" concurrency policy. Use conditions.SetBackupReplacedConditionToTrue to stop them.
+	Replace []v1beta1.BackupSession
+}
+
+// DecideConcurrency decides whether the given BackupSession can start at "now" based on the other BackupSessions of the invoker.
+// The BackupSessions of the other invokers and the given BackupSession itself are ignored from the list.
+func DecideConcurrency(inv BackupInvoker, session *v1beta1.BackupSession, sessions []v1beta1.BackupSession, now time.Time) ConcurrencyDecision {
+	if deadline := inv.GetStartingDeadline(); deadline != nil && now.Sub(session.CreationTimestamp.Time) > deadline.Duration {
+		return ConcurrencyDecision{
+			Action:  ConcurrencyActionSkip,
+			Reason:  v1beta1.SkippedMissedStartingDeadline,
+			Message: fmt.Sprintf("Skipped taking backup as it could not start within the starting deadline %s.", deadline.Duration),
+		}
+	}
+
+	var running, queued []v1beta1.BackupSession
+	for _, s := range sessions {
+		if s.Name == session.Name || s.Spec.Invoker != session.Spec.Invoker || IsBackupCompleted(s.Status.Phase) {
+			continue
+		}
+		if s.Status.Phase == v1beta1.BackupSessionRunning {
+			running = append(running, s)
+		} else if s.CreationTimestamp.Before(&session.CreationTimestamp) {
+			queued = append(queued, s)
+		}
+	}
+
+	switch inv.GetConcurrencyPolicy() {
+	case v1beta1.AllowConcurrent:
+		return ConcurrencyDecision{Action: ConcurrencyActionRun}
+	case v1beta1.ReplaceConcurrent:
+		if len(running)+len(queued) > 0 {
+			return ConcurrencyDecision{
+				Action:  ConcurrencyActionRun,
+				Message: fmt.Sprintf("Replacing %d previous BackupSession(s).", len(running)+len(queued)),
+				Replace: append(running, queued...),
+			}
+		}
+		return ConcurrencyDecision{Action: ConcurrencyActionRun}
+	case v1beta1.QueueConcurrent:
+		if len(running)+len(queued) > 0 {
+			return ConcurrencyDecision{
+				Action:  ConcurrencyActionWait,
+				Message: fmt.Sprintf("Waiting for %d previous BackupSession(s) to complete.", len(running)+len(queued)),
+			}
+		}
+		return ConcurrencyDecision{Action: ConcurrencyActionRun}
+	default:
+		if len(running) > 0 {
+			return ConcurrencyDecision{
+				Action:  ConcurrencyActionSkip,
+				Reason:  v1beta1.SkippedConcurrentBackupRunning,
+				Message: fmt.Sprintf("Skipped taking new backup as BackupSession %s is still running.", running[0].Name),
+			}
+		}
+		return ConcurrencyDecision{Action: ConcurrencyActionRun}
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package invoker
+
+import (
+	"testing"
+	"time"
+
+	"stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDecideConcurrency(t *testing.T) {
+	now := time.Date(2024, time.March, 4, 10, 0, 0, 0, time.UTC)
+	invokerRef := v1beta1.BackupInvokerRef{
+		APIGroup: v1beta1.SchemeGroupVersion.Group,
+		Kind:     v1beta1.ResourceKindBackupConfiguration,
+		Name:     "sample-backup",
+	}
+	newSession := func(name string, created time.Time, phase v1beta1.BackupSessionPhase) v1beta1.BackupSession {
+		return v1beta1.BackupSession{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.Time{Time: created}},
+			Spec:       v1beta1.BackupSessionSpec{Invoker: invokerRef},
+			Status:     v1beta1.BackupSessionStatus{Phase: phase},
+		}
+	}
+	current := newSession("current", now.Add(-time.Minute), v1beta1.BackupSessionPending)
+	running := newSession("running", now.Add(-time.Hour), v1beta1.BackupSessionRunning)
+	succeeded := newSession("succeeded", now.Add(-2*time.Hour), v1beta1.BackupSessionSucceeded)
+
+	tests := []struct {
+		name       string
+		policy     v1beta1.ConcurrencyPolicy
+		deadline   *metav1.Duration
+		sessions   []v1beta1.BackupSession
+		wantAction ConcurrencyAction
+		wantReason string
+		wantNum    int
+	}{
+		{
+			name:       "Forbid without any running session",
+			sessions:   []v1beta1.BackupSession{current, succeeded},
+			wantAction: ConcurrencyActionRun,
+		},
+		{
+			name:       "Forbid with a running session",
+			sessions:   []v1beta1.BackupSession{current, running},
+			wantAction: ConcurrencyActionSkip,
+			wantReason: v1beta1.SkippedConcurrentBackupRunning,
+		},
+		{
+			name:       "Allow with a running session",
+			policy:     v1beta1.AllowConcurrent,
+			sessions:   []v1beta1.BackupSession{current, running},
+			wantAction: ConcurrencyActionRun,
+		},
+		{
+			name:       "Replace a running session",
+			policy:     v1beta1.ReplaceConcurrent,
+			sessions:   []v1beta1.BackupSession{current, running, succeeded},
+			wantAction: ConcurrencyActionRun,
+			wantNum:    1,
+		},
+		{
+			name:       "Queue behind a running session",
+			policy:     v1beta1.QueueConcurrent,
+			sessions:   []v1beta1.BackupSession{current, running},
+			wantAction: ConcurrencyActionWait,
+		},
+		{
+			name:       "Missed starting deadline",
+			policy:     v1beta1.QueueConcurrent,
+			deadline:   &metav1.Duration{Duration: 30 * time.Second},
+			sessions:   []v1beta1.BackupSession{current},
+			wantAction: ConcurrencyActionSkip,
+			wantReason: v1beta1.SkippedMissedStartingDeadline,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv := NewBackupConfigurationInvoker(nil, &v1beta1.BackupConfiguration{
+				Spec: v1beta1.BackupConfigurationSpec{
+					ConcurrencyPolicy: tt.policy,
+					StartingDeadline:  tt.deadline,
+				},
+			})
+			got := DecideConcurrency(inv, &current, tt.sessions, now)
+			if got.Action != tt.wantAction || got.Reason != tt.wantReason || len(got.Replace) != tt.wantNum {
+				t.Errorf("DecideConcurrency() = %+v, want action %s, reason %q and %d replaced session(s)", got, tt.wantAction, tt.wantReason, tt.wantNum)
+			}
+		})
+	}
+}
+
+func TestDecideConcurrencyForBackupBatch(t *testing.T) {
+	now := time.Date(2024, time.March, 4, 10, 0, 0, 0, time.UTC)
+	invokerRef := v1beta1.BackupInvokerRef{
+		APIGroup: v1beta1.SchemeGroupVersion.Group,
+		Kind:     v1beta1.ResourceKindBackupBatch,
+		Name:     "sample-batch",
+	}
+	current := v1beta1.BackupSession{
+		ObjectMeta: metav1.ObjectMeta{Name: "current", CreationTimestamp: metav1.Time{Time: now.Add(-time.Minute)}},
+		Spec:       v1beta1.BackupSessionSpec{Invoker: invokerRef},
+	}
+	running := v1beta1.BackupSession{
+		ObjectMeta: metav1.ObjectMeta{Name: "running", CreationTimestamp: metav1.Time{Time: now.Add(-time.Hour)}},
+		Spec:       v1beta1.BackupSessionSpec{Invoker: invokerRef},
+		Status:     v1beta1.BackupSessionStatus{Phase: v1beta1.BackupSessionRunning},
+	}
+
+	bb := &v1beta1.BackupBatch{Spec: v1beta1.BackupBatchSpec{ConcurrencyPolicy: v1beta1.ReplaceConcurrent}}
+	got := DecideConcurrency(NewBackupBatchInvoker(nil, bb), &current, []v1beta1.BackupSession{current, running}, now)
+	if got.Action != ConcurrencyActionRun || len(got.Replace) != 1 || got.Replace[0].Name != running.Name {
+		t.Errorf("DecideConcurrency() = %+v, want to replace BackupSession %s", got, running.Name)
+	}
+
+	bb = &v1beta1.BackupBatch{}
+	bb.Default()
+	got = DecideConcurrency(NewBackupBatchInvoker(nil, bb), &current, []v1beta1.BackupSession{current, running}, now)
+	if got.Action != ConcurrencyActionSkip || got.Reason != v1beta1.SkippedConcurrentBackupRunning {
+		t.Errorf("DecideConcurrency() = %+v, want to skip with the default policy", got)
+	}
+}
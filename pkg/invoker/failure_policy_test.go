@@ -0,0 +1,261 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package invoker
+
+import (
+	"testing"
+
+	"stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+
+	"gomodules.xyz/pointer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kmapi "kmodules.xyz/client-go/api/v1"
+)
+
+func TestCalculateBackupSessionPhase(t *testing.T) {
+	metricsPushed := []kmapi.Condition{{Type: v1beta1.MetricsPushed, Status: metav1.ConditionTrue}}
+	targets := func(phases ...v1beta1.TargetPhase) []v1beta1.BackupTargetStatus {
+		var status []v1beta1.BackupTargetStatus
+		for i, phase := range phases {
+			status = append(status, v1beta1.BackupTargetStatus{
+				Ref:   v1beta1.TargetRef{APIVersion: "apps/v1", Kind: "StatefulSet", Name: string(rune('a' + i))},
+				Phase: phase,
+			})
+		}
+		return status
+	}
+	failFast := &v1beta1.FailurePolicy{Type: v1beta1.FailFast}
+	continueOnError := &v1beta1.FailurePolicy{Type: v1beta1.ContinueOnError}
+	minSuccessful := &v1beta1.FailurePolicy{Type: v1beta1.MinSuccessful, MinSuccessful: pointer.Int32P(2)}
+
+	tests := []struct {
+		name    string
+		targets []v1beta1.BackupTargetStatus
+		policy  *v1beta1.FailurePolicy
+		want    v1beta1.BackupSessionPhase
+	}{
+		{
+			name:    "all targets succeeded",
+			targets: targets(v1beta1.TargetBackupSucceeded, v1beta1.TargetBackupSucceeded),
+			policy:  continueOnError,
+			want:    v1beta1.BackupSessionSucceeded,
+		},
+		{
+			name:    "default policy fails on a failed target",
+			targets: targets(v1beta1.TargetBackupSucceeded, v1beta1.TargetBackupFailed),
+			want:    v1beta1.BackupSessionFailed,
+		},
+		{
+			name:    "default policy waits for the pending targets after a failure",
+			targets: targets(v1beta1.TargetBackupFailed, v1beta1.TargetBackupPending),
+			want:    v1beta1.BackupSessionRunning,
+		},
+		{
+			name:    "default policy does not partially succeed",
+			targets: targets(v1beta1.TargetBackupFailed, v1beta1.TargetBackupSkipped, v1beta1.TargetBackupSucceeded),
+			want:    v1beta1.BackupSessionFailed,
+		},
+		{
+			name:    "fail fast does not wait for the pending targets after a failure",
+			targets: targets(v1beta1.TargetBackupFailed, v1beta1.TargetBackupPending),
+			policy:  failFast,
+			want:    v1beta1.BackupSessionFailed,
+		},
+		{
+			name:    "fail fast waits for the running targets after a failure",
+			targets: targets(v1beta1.TargetBackupFailed, v1beta1.TargetBackupRunning),
+			policy:  failFast,
+			want:    v1beta1.BackupSessionRunning,
+		},
+		{
+			name:    "continue on error waits for the pending targets",
+			targets: targets(v1beta1.TargetBackupFailed, v1beta1.TargetBackupPending),
+			policy:  continueOnError,
+			want:    v1beta1.BackupSessionRunning,
+		},
+		{
+			name:    "continue on error partially succeeded",
+			targets: targets(v1beta1.TargetBackupFailed, v1beta1.TargetBackupSucceeded),
+			policy:  continueOnError,
+			want:    v1beta1.BackupSessionPartiallySucceeded,
+		},
+		{
+			name:    "continue on error with no successful target",
+			targets: targets(v1beta1.TargetBackupFailed, v1beta1.TargetBackupFailed),
+			policy:  continueOnError,
+			want:    v1beta1.BackupSessionFailed,
+		},
+		{
+			name:    "minimum successful targets satisfied",
+			targets: targets(v1beta1.TargetBackupSucceeded, v1beta1.TargetBackupFailed, v1beta1.TargetBackupSucceeded),
+			policy:  minSuccessful,
+			want:    v1beta1.BackupSessionPartiallySucceeded,
+		},
+		{
+			name:    "minimum successful targets not satisfied",
+			targets: targets(v1beta1.TargetBackupSucceeded, v1beta1.TargetBackupFailed, v1beta1.TargetBackupFailed),
+			policy:  minSuccessful,
+			want:    v1beta1.BackupSessionFailed,
+		},
+		{
+			name:    "skipped target is not considered successful",
+			targets: targets(v1beta1.TargetBackupSucceeded, v1beta1.TargetBackupFailed, v1beta1.TargetBackupSkipped),
+			policy:  minSuccessful,
+			want:    v1beta1.BackupSessionFailed,
+		},
+		{
+			name:    "continue on error completes with a skipped target",
+			targets: targets(v1beta1.TargetBackupFailed, v1beta1.TargetBackupSkipped, v1beta1.TargetBackupSucceeded),
+			policy:  continueOnError,
+			want:    v1beta1.BackupSessionPartiallySucceeded,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status := &v1beta1.BackupSessionStatus{Conditions: metricsPushed, Targets: test.targets}
+			if got := calculateBackupSessionPhase(status, test.policy); got != test.want {
+				t.Errorf("calculateBackupSessionPhase() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCalculateRestoreBatchPhase(t *testing.T) {
+	conditions := []kmapi.Condition{
+		{Type: v1beta1.RepositoryFound, Status: metav1.ConditionTrue},
+		{Type: v1beta1.BackendSecretFound, Status: metav1.ConditionTrue},
+	}
+	metricsPushed := append([]kmapi.Condition{{Type: v1beta1.MetricsPushed, Status: metav1.ConditionTrue}}, conditions...)
+	members := []v1beta1.RestoreMemberStatus{
+		{Ref: v1beta1.TargetRef{Kind: "StatefulSet", Name: "db"}, Phase: v1beta1.TargetRestoreFailed},
+		{Ref: v1beta1.TargetRef{Kind: "Deployment", Name: "app"}, Phase: v1beta1.TargetRestoreSucceeded},
+	}
+	continueOnError := &v1beta1.FailurePolicy{Type: v1beta1.ContinueOnError}
+
+	tests := []struct {
+		name       string
+		conditions []kmapi.Condition
+		policy     *v1beta1.FailurePolicy
+		want       v1beta1.RestorePhase
+	}{
+		{
+			name:       "default policy",
+			conditions: conditions,
+			want:       v1beta1.RestoreFailed,
+		},
+		{
+			name:       "partial success waits for the metrics",
+			conditions: conditions,
+			policy:     continueOnError,
+			want:       v1beta1.RestoreRunning,
+		},
+		{
+			name:       "partial success",
+			conditions: metricsPushed,
+			policy:     continueOnError,
+			want:       v1beta1.RestorePartiallySucceeded,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status := &v1beta1.RestoreBatchStatus{Conditions: test.conditions, Members: members}
+			if got := calculateRestoreBatchPhase(status, len(members), test.policy); got != test.want {
+				t.Errorf("calculateRestoreBatchPhase() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSkipPendingTargetsOnFailure(t *testing.T) {
+	targets := []v1beta1.BackupTargetStatus{
+		{Ref: v1beta1.TargetRef{Kind: "StatefulSet", Name: "db"}, Phase: v1beta1.TargetBackupFailed},
+		{Ref: v1beta1.TargetRef{Kind: "Deployment", Name: "app"}, Phase: v1beta1.TargetBackupPending},
+	}
+	if !backupStoppedOnFailure(targets) {
+		t.Errorf("backupStoppedOnFailure() = false, want true")
+	}
+	targets = skipPendingBackupTargets(targets)
+	if targets[0].Phase != v1beta1.TargetBackupFailed || targets[1].Phase != v1beta1.TargetBackupSkipped {
+		t.Errorf("skipPendingBackupTargets() phases = [%v %v], want [Failed Skipped]", targets[0].Phase, targets[1].Phase)
+	}
+
+	running := []v1beta1.BackupTargetStatus{
+		{Ref: v1beta1.TargetRef{Kind: "StatefulSet", Name: "db"}, Phase: v1beta1.TargetBackupFailed},
+		{Ref: v1beta1.TargetRef{Kind: "Deployment", Name: "app"}, Phase: v1beta1.TargetBackupRunning},
+	}
+	if backupStoppedOnFailure(running) {
+		t.Errorf("backupStoppedOnFailure() = true, want false while a target is running")
+	}
+
+	members := []v1beta1.RestoreMemberStatus{
+		{Ref: v1beta1.TargetRef{Kind: "StatefulSet", Name: "db"}, Phase: v1beta1.TargetRestoreFailed},
+		{Ref: v1beta1.TargetRef{Kind: "Deployment", Name: "app"}},
+	}
+	if !restoreStoppedOnFailure(members) {
+		t.Errorf("restoreStoppedOnFailure() = false, want true")
+	}
+	members = skipPendingRestoreMembers(members)
+	if members[0].Phase != v1beta1.TargetRestoreFailed || members[1].Phase != v1beta1.TargetRestoreSkipped {
+		t.Errorf("skipPendingRestoreMembers() phases = [%v %v], want [Failed Skipped]", members[0].Phase, members[1].Phase)
+	}
+}
+
+func TestPhaseAfterMetrics(t *testing.T) {
+	continueOnError := &v1beta1.FailurePolicy{Type: v1beta1.ContinueOnError}
+	session := &v1beta1.BackupSession{
+		Spec: v1beta1.BackupSessionSpec{FailurePolicy: continueOnError},
+		Status: v1beta1.BackupSessionStatus{
+			Targets: []v1beta1.BackupTargetStatus{
+				{Ref: v1beta1.TargetRef{Kind: "StatefulSet", Name: "db"}, Phase: v1beta1.TargetBackupFailed},
+				{Ref: v1beta1.TargetRef{Kind: "Deployment", Name: "app"}, Phase: v1beta1.TargetBackupSucceeded},
+			},
+		},
+	}
+	if got := BackupSessionPhaseAfterMetrics(session); got != v1beta1.BackupSessionPartiallySucceeded {
+		t.Errorf("BackupSessionPhaseAfterMetrics() = %v, want %v", got, v1beta1.BackupSessionPartiallySucceeded)
+	}
+	if session.Status.Conditions != nil {
+		t.Errorf("BackupSessionPhaseAfterMetrics() must not modify the session")
+	}
+	session.Spec.FailurePolicy = nil
+	if got := BackupSessionPhaseAfterMetrics(session); got != v1beta1.BackupSessionFailed {
+		t.Errorf("BackupSessionPhaseAfterMetrics() = %v, want %v for the default policy", got, v1beta1.BackupSessionFailed)
+	}
+
+	member := func(name string) v1beta1.RestoreTargetSpec {
+		return v1beta1.RestoreTargetSpec{Target: &v1beta1.RestoreTarget{Ref: v1beta1.TargetRef{APIVersion: "apps/v1", Kind: "StatefulSet", Name: name}}}
+	}
+	rb := &v1beta1.RestoreBatch{
+		Spec: v1beta1.RestoreBatchSpec{
+			Members:       []v1beta1.RestoreTargetSpec{member("db"), member("app")},
+			FailurePolicy: continueOnError,
+		},
+		Status: v1beta1.RestoreBatchStatus{
+			Conditions: []kmapi.Condition{
+				{Type: v1beta1.RepositoryFound, Status: metav1.ConditionTrue},
+				{Type: v1beta1.BackendSecretFound, Status: metav1.ConditionTrue},
+			},
+			Members: []v1beta1.RestoreMemberStatus{
+				{Ref: v1beta1.TargetRef{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "db"}, Phase: v1beta1.TargetRestoreFailed},
+				{Ref: v1beta1.TargetRef{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "app"}, Phase: v1beta1.TargetRestoreSucceeded},
+			},
+		},
+	}
+	if got := RestorePhaseAfterMetrics(NewRestoreBatchInvoker(nil, nil, rb)); got != v1beta1.RestorePartiallySucceeded {
+		t.Errorf("RestorePhaseAfterMetrics() = %v, want %v", got, v1beta1.RestorePartiallySucceeded)
+	}
+}
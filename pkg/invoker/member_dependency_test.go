@@ -0,0 +1,159 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package invoker
+
+import (
+	"testing"
+
+	"stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRestoreBatchNextInOrder(t *testing.T) {
+	ref := func(kind, name string) v1beta1.TargetRef {
+		return v1beta1.TargetRef{APIVersion: "apps/v1", Kind: kind, Name: name, Namespace: "demo"}
+	}
+	member := func(target v1beta1.TargetRef, dependsOn ...v1beta1.TargetRef) v1beta1.RestoreTargetSpec {
+		return v1beta1.RestoreTargetSpec{Target: &v1beta1.RestoreTarget{Ref: target}, DependsOn: dependsOn}
+	}
+	database := ref("StatefulSet", "database")
+	app := ref("Deployment", "app")
+	sessionCache := ref("StatefulSet", "session-cache")
+	queryCache := ref("StatefulSet", "query-cache")
+
+	// restore database before app, but restore both caches in parallel
+	rb := &v1beta1.RestoreBatch{
+		ObjectMeta: metav1.ObjectMeta{Name: "sample-restore", Namespace: "demo"},
+		Spec: v1beta1.RestoreBatchSpec{
+			ExecutionOrder: v1beta1.Parallel,
+			Members: []v1beta1.RestoreTargetSpec{
+				member(app, database),
+				member(database),
+				member(sessionCache),
+				member(queryCache),
+			},
+		},
+	}
+	inv := NewRestoreBatchInvoker(nil, nil, rb)
+	if inv.GetExecutionOrder() != v1beta1.Sequential {
+		t.Errorf("members with dependencies must be consulted before starting, found execution order %s", inv.GetExecutionOrder())
+		return
+	}
+
+	status := func(target v1beta1.TargetRef, phase v1beta1.RestoreTargetPhase) v1beta1.RestoreMemberStatus {
+		return v1beta1.RestoreMemberStatus{Ref: target, Phase: phase}
+	}
+	tests := []struct {
+		name   string
+		target v1beta1.TargetRef
+		status []v1beta1.RestoreMemberStatus
+		want   bool
+	}{
+		{
+			name:   "independent member starts immediately",
+			target: queryCache,
+			want:   true,
+		},
+		{
+			name:   "dependent member waits for its dependency",
+			target: app,
+			status: []v1beta1.RestoreMemberStatus{status(database, v1beta1.TargetRestoreRunning)},
+			want:   false,
+		},
+		{
+			name:   "dependent member starts after its dependency completes",
+			target: app,
+			status: []v1beta1.RestoreMemberStatus{status(database, v1beta1.TargetRestoreSucceeded)},
+			want:   true,
+		},
+		{
+			name:   "dependent member does not start after its dependency fails",
+			target: app,
+			status: []v1beta1.RestoreMemberStatus{status(database, v1beta1.TargetRestoreFailed)},
+			want:   false,
+		},
+		{
+			name:   "member listed after a running member",
+			target: sessionCache,
+			status: []v1beta1.RestoreMemberStatus{status(database, v1beta1.TargetRestoreRunning)},
+			want:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := inv.NextInOrder(test.target, test.status); got != test.want {
+				t.Errorf("NextInOrder() = %v, want %v", got, test.want)
+			}
+		})
+	}
+
+	// the dependent member must be skipped once its dependency fails
+	failed := inv.FailedDependencies(app, []v1beta1.RestoreMemberStatus{status(database, v1beta1.TargetRestoreFailed)})
+	if len(failed) != 1 || !TargetMatched(failed[0], database) {
+		t.Errorf("FailedDependencies() = %v, want [%s]", failed, database.Name)
+	}
+	if failed = inv.FailedDependencies(app, []v1beta1.RestoreMemberStatus{status(database, v1beta1.TargetRestoreRunning)}); len(failed) != 0 {
+		t.Errorf("FailedDependencies() = %v, want none while the dependency is running", failed)
+	}
+}
+
+func TestBackupBatchNextInOrderStopOnFailure(t *testing.T) {
+	ref := func(name string) v1beta1.TargetRef {
+		return v1beta1.TargetRef{APIVersion: "apps/v1", Kind: "StatefulSet", Name: name, Namespace: "demo"}
+	}
+	member := func(target v1beta1.TargetRef) v1beta1.BackupConfigurationTemplateSpec {
+		return v1beta1.BackupConfigurationTemplateSpec{Target: &v1beta1.BackupTarget{Ref: target}}
+	}
+	status := []v1beta1.BackupTargetStatus{{Ref: ref("db"), Phase: v1beta1.TargetBackupFailed}}
+
+	tests := []struct {
+		name   string
+		policy *v1beta1.FailurePolicy
+		want   bool
+	}{
+		{
+			name: "default policy",
+			want: true,
+		},
+		{
+			name:   "fail fast",
+			policy: &v1beta1.FailurePolicy{Type: v1beta1.FailFast},
+			want:   false,
+		},
+		{
+			name:   "continue on error",
+			policy: &v1beta1.FailurePolicy{Type: v1beta1.ContinueOnError},
+			want:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bb := &v1beta1.BackupBatch{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-backup", Namespace: "demo"},
+				Spec: v1beta1.BackupBatchSpec{
+					ExecutionOrder: v1beta1.Sequential,
+					Members:        []v1beta1.BackupConfigurationTemplateSpec{member(ref("db")), member(ref("cache"))},
+					FailurePolicy:  test.policy,
+				},
+			}
+			if got := NewBackupBatchInvoker(nil, bb).NextInOrder(ref("cache"), status); got != test.want {
+				t.Errorf("NextInOrder() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
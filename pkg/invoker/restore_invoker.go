@@ -55,11 +55,18 @@ type RestoreInvoker interface {
 type RestoreExecutionOrderHandler interface {
 	GetExecutionOrder() v1beta1.ExecutionOrder
 	NextInOrder(curTarget v1beta1.TargetRef, targetStatus []v1beta1.RestoreMemberStatus) bool
+	// FailedDependencies returns the members the target depends on that have failed or have been skipped.
+	// The target will never start. So, it should be skipped if there is any.
+	FailedDependencies(curTarget v1beta1.TargetRef, targetStatus []v1beta1.RestoreMemberStatus) []v1beta1.TargetRef
+	// GetFailurePolicy returns how to handle the failure of a member. It returns nil for a single target invoker.
+	GetFailurePolicy() *v1beta1.FailurePolicy
 }
 
 type RestoreTargetHandler interface {
 	GetTargetInfo() []RestoreTargetInfo
 	GetGlobalHooks() *v1beta1.RestoreHooks
+	// IsDryRun returns true if the restore should only be planned without restoring anything or executing any hooks
+	IsDryRun() bool
 }
 
 type RestoreStatusHandler interface {
@@ -73,6 +80,7 @@ type RestoreInvokerStatus struct {
 	SessionDeadline *metav1.Time
 	Conditions      []kmapi.Condition
 	TargetStatus    []v1beta1.RestoreMemberStatus
+	Plan            *v1beta1.RestorePlan
 }
 
 type RestoreTargetInfo struct {
@@ -82,6 +90,10 @@ type RestoreTargetInfo struct {
 	TempDir               v1beta1.EmptyDirSettings
 	InterimVolumeTemplate *ofst.PersistentVolumeClaim
 	Hooks                 *v1beta1.RestoreHooks
+	// TimeOut and RetryConfig are set only if the target has its own timeout and retry configuration
+	// (i.e. a member of a RestoreBatch). Otherwise, the timeout of the invoker applies.
+	TimeOut     *metav1.Duration
+	RetryConfig *v1beta1.RetryConfig
 }
 
 func NewRestoreInvoker(kubeClient kubernetes.Interface, stashClient cs.Interface, kind, name, namespace string) (RestoreInvoker, error) {
@@ -187,6 +199,7 @@ func getInvokerStatusFromRestoreBatch(restoreBatch *v1beta1.RestoreBatch) Restor
 		Conditions:      restoreBatch.Status.Conditions,
 		TargetStatus:    getMemberStatus(restoreBatch.DeepCopy()),
 		SessionDeadline: restoreBatch.Status.SessionDeadline,
+		Plan:            restoreBatch.Status.Plan,
 	}
 }
 
@@ -202,11 +215,12 @@ func getInvokerStatusFromRestoreSession(restoreSession *v1beta1.RestoreSession)
 		SessionDuration: restoreSession.Status.SessionDuration,
 		SessionDeadline: restoreSession.Status.SessionDeadline,
 		Conditions:      restoreSession.Status.Conditions,
+		Plan:            restoreSession.Status.Plan,
 	}
 	var targetStatus v1beta1.RestoreMemberStatus
 	if restoreSession.Spec.Target != nil {
 		targetStatus = v1beta1.RestoreMemberStatus{
-			Ref:        defaultTargetNamespace(restoreSession.Spec.Target.Ref, restoreSession.Namespace),
+			Ref:        v1beta1.ApplyRestoreMappings(getRestoreTarget(restoreSession.Spec.Target.DeepCopy(), restoreSession.Namespace), restoreSession.Spec.Mappings).Ref,
 			Conditions: restoreSession.Status.Conditions,
 			TotalHosts: restoreSession.Status.TotalHosts,
 			Stats:      restoreSession.Status.Stats,
@@ -215,7 +229,11 @@ func getInvokerStatusFromRestoreSession(restoreSession *v1beta1.RestoreSession)
 	}
 
 	invokerStatus.TargetStatus = append(invokerStatus.TargetStatus, targetStatus)
-	invokerStatus.Phase = calculateRestoreSessionPhase(targetStatus)
+	if restoreSession.Spec.DryRun {
+		invokerStatus.Phase = calculateDryRunPhase(invokerStatus.TargetStatus, 1)
+	} else {
+		invokerStatus.Phase = calculateRestoreSessionPhase(targetStatus)
+	}
 
 	return invokerStatus
 }
@@ -231,8 +249,27 @@ func TargetRestoreCompleted(ref v1beta1.TargetRef, targetStatus []v1beta1.Restor
 	for i := range targetStatus {
 		if TargetMatched(ref, targetStatus[i].Ref) {
 			return targetStatus[i].Phase == v1beta1.TargetRestoreSucceeded ||
-				targetStatus[i].Phase == v1beta1.TargetRestoreFailed ||
-				targetStatus[i].Phase == v1beta1.TargetRestorePhaseUnknown
+				(targetStatus[i].Phase == v1beta1.TargetRestoreFailed && !RestoreMemberRetryable(targetStatus[i])) ||
+				targetStatus[i].Phase == v1beta1.TargetRestorePhaseUnknown ||
+				targetStatus[i].Phase == v1beta1.TargetRestoreSkipped
+		}
+	}
+	return false
+}
+
+func targetRestoreSucceeded(ref v1beta1.TargetRef, targetStatus []v1beta1.RestoreMemberStatus) bool {
+	for i := range targetStatus {
+		if TargetMatched(ref, targetStatus[i].Ref) {
+			return targetStatus[i].Phase == v1beta1.TargetRestoreSucceeded
+		}
+	}
+	return false
+}
+
+func targetRestoreFailed(targetStatus []v1beta1.RestoreMemberStatus) bool {
+	for i := range targetStatus {
+		if targetStatus[i].Phase == v1beta1.TargetRestoreFailed && !RestoreMemberRetryable(targetStatus[i]) {
+			return true
 		}
 	}
 	return false
@@ -271,13 +308,26 @@ func upsertRestoreTargetStatus(cur, new v1beta1.RestoreMemberStatus) v1beta1.Res
 		cur.Stats = upsertRestoreHostStatus(cur.Stats, new.Stats)
 	}
 
+	// The deadline and the retry attempts are set together when the restore of the member starts.
+	// Afterwards, they are only changed by RetryMember. A member that has used all of its retry
+	// attempts keeps zero retry attempts. So, it is never initialized again.
+	if cur.Deadline == nil && cur.RetryLeft == nil {
+		cur.Deadline = new.Deadline
+		cur.RetryLeft = new.RetryLeft
+	}
+
 	cur.Phase = calculateRestoreTargetPhase(cur)
 	return cur
 }
 
 func calculateRestoreTargetPhase(status v1beta1.RestoreMemberStatus) v1beta1.RestoreTargetPhase {
+	if cutil.IsConditionTrue(status.Conditions, v1beta1.TargetSkipped) {
+		return v1beta1.TargetRestoreSkipped
+	}
+
 	if cutil.IsConditionFalse(status.Conditions, v1beta1.RestoreExecutorEnsured) ||
 		cutil.IsConditionFalse(status.Conditions, v1beta1.PreRestoreHookExecutionSucceeded) ||
+		cutil.IsConditionTrue(status.Conditions, v1beta1.DeadlineExceeded) ||
 		cutil.IsConditionFalse(status.Conditions, v1beta1.PostRestoreHookExecutionSucceeded) {
 		return v1beta1.TargetRestoreFailed
 	}
@@ -318,6 +368,7 @@ func calculateRestoreTargetPhase(status v1beta1.RestoreMemberStatus) v1beta1.Res
 
 func IsRestoreCompleted(phase v1beta1.RestorePhase) bool {
 	return phase == v1beta1.RestoreSucceeded ||
+		phase == v1beta1.RestorePartiallySucceeded ||
 		phase == v1beta1.RestoreFailed ||
 		phase == v1beta1.RestorePhaseUnknown
 }
@@ -0,0 +1,108 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package invoker
+
+import (
+	"stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cutil "kmodules.xyz/client-go/conditions"
+)
+
+// NewTargetRestorePlan generates the restore plan of a target from the hosts plan.
+// The availability of the target is determined from the RestoreTargetFound condition.
+func NewTargetRestorePlan(inv RestoreInvoker, ref v1beta1.TargetRef, hosts []v1beta1.HostRestorePlan) v1beta1.TargetRestorePlan {
+	plan := v1beta1.TargetRestorePlan{
+		Ref:   ref,
+		Hosts: hosts,
+	}
+	_, cond, err := inv.GetCondition(&ref, v1beta1.RestoreTargetFound)
+	switch {
+	case err != nil:
+		plan.Reason = err.Error()
+	case cond == nil:
+		plan.Reason = "Restore target has not been checked yet."
+	case cond.Status == metav1.ConditionTrue:
+		plan.Available = true
+	default:
+		plan.Reason = cond.Message
+	}
+	return plan
+}
+
+func upsertRestorePlan(cur, newPlan *v1beta1.RestorePlan) *v1beta1.RestorePlan {
+	if cur == nil {
+		return newPlan.DeepCopy()
+	}
+	for _, target := range newPlan.Targets {
+		found := false
+		for i := range cur.Targets {
+			if TargetMatched(cur.Targets[i].Ref, target.Ref) {
+				cur.Targets[i].Available = target.Available
+				cur.Targets[i].Reason = target.Reason
+				cur.Targets[i].Hosts = upsertHostRestorePlan(cur.Targets[i].Hosts, target.Hosts)
+				found = true
+				break
+			}
+		}
+		if !found {
+			cur.Targets = append(cur.Targets, *target.DeepCopy())
+		}
+	}
+	return cur
+}
+
+func upsertHostRestorePlan(cur, hosts []v1beta1.HostRestorePlan) []v1beta1.HostRestorePlan {
+	for _, host := range hosts {
+		found := false
+		for i := range cur {
+			if cur[i].Hostname == host.Hostname {
+				cur[i] = *host.DeepCopy()
+				found = true
+				break
+			}
+		}
+		if !found {
+			cur = append(cur, *host.DeepCopy())
+		}
+	}
+	return cur
+}
+
+// calculateDryRunPhase calculates the phase of a dry-run restore from the RestorePlanned condition of the targets.
+func calculateDryRunPhase(members []v1beta1.RestoreMemberStatus, totalTargets int) v1beta1.RestorePhase {
+	planned := 0
+	started := false
+	for _, m := range members {
+		if cutil.IsConditionFalse(m.Conditions, v1beta1.RestorePlanned) {
+			return v1beta1.RestoreFailed
+		}
+		if cutil.IsConditionTrue(m.Conditions, v1beta1.RestorePlanned) {
+			planned++
+		}
+		if len(m.Conditions) > 0 {
+			started = true
+		}
+	}
+	if planned >= totalTargets {
+		return v1beta1.RestoreSucceeded
+	}
+	if !started {
+		return v1beta1.RestorePending
+	}
+	return v1beta1.RestoreRunning
+}
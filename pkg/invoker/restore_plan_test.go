@@ -0,0 +1,126 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package invoker
+
+import (
+	"reflect"
+	"testing"
+
+	"stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kmapi "kmodules.xyz/client-go/api/v1"
+)
+
+func TestUpsertRestorePlan(t *testing.T) {
+	deployment := v1beta1.TargetRef{Kind: "Deployment", Name: "app", Namespace: "demo"}
+	statefulSet := v1beta1.TargetRef{Kind: "StatefulSet", Name: "db", Namespace: "demo"}
+
+	cur := &v1beta1.RestorePlan{
+		Targets: []v1beta1.TargetRestorePlan{
+			{
+				Ref: deployment,
+				Hosts: []v1beta1.HostRestorePlan{
+					{Hostname: "host-0", TotalSize: "1 KiB"},
+				},
+			},
+		},
+	}
+	newPlan := &v1beta1.RestorePlan{
+		Targets: []v1beta1.TargetRestorePlan{
+			{
+				Ref:       deployment,
+				Available: true,
+				Hosts: []v1beta1.HostRestorePlan{
+					{Hostname: "host-0", TotalSize: "2 KiB"},
+					{Hostname: "host-1", TotalSize: "3 KiB"},
+				},
+			},
+			{Ref: statefulSet, Reason: "not found"},
+		},
+	}
+	want := &v1beta1.RestorePlan{
+		Targets: []v1beta1.TargetRestorePlan{
+			{
+				Ref:       deployment,
+				Available: true,
+				Hosts: []v1beta1.HostRestorePlan{
+					{Hostname: "host-0", TotalSize: "2 KiB"},
+					{Hostname: "host-1", TotalSize: "3 KiB"},
+				},
+			},
+			{Ref: statefulSet, Reason: "not found"},
+		},
+	}
+
+	got := upsertRestorePlan(cur, newPlan)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("upsertRestorePlan() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCalculateDryRunPhase(t *testing.T) {
+	planned := func(status metav1.ConditionStatus) []kmapi.Condition {
+		return []kmapi.Condition{{Type: v1beta1.RestorePlanned, Status: status}}
+	}
+	found := []kmapi.Condition{{Type: v1beta1.RestoreTargetFound, Status: metav1.ConditionTrue}}
+
+	tests := []struct {
+		name    string
+		members []v1beta1.RestoreMemberStatus
+		total   int
+		want    v1beta1.RestorePhase
+	}{
+		{
+			name:    "no conditions",
+			members: []v1beta1.RestoreMemberStatus{{}},
+			total:   1,
+			want:    v1beta1.RestorePending,
+		},
+		{
+			name:    "plan not generated yet",
+			members: []v1beta1.RestoreMemberStatus{{Conditions: found}},
+			total:   1,
+			want:    v1beta1.RestoreRunning,
+		},
+		{
+			name:    "plan generated for all targets",
+			members: []v1beta1.RestoreMemberStatus{{Conditions: planned(metav1.ConditionTrue)}, {Conditions: planned(metav1.ConditionTrue)}},
+			total:   2,
+			want:    v1beta1.RestoreSucceeded,
+		},
+		{
+			name:    "plan generated for some targets",
+			members: []v1beta1.RestoreMemberStatus{{Conditions: planned(metav1.ConditionTrue)}, {Conditions: found}},
+			total:   2,
+			want:    v1beta1.RestoreRunning,
+		},
+		{
+			name:    "failed to generate plan",
+			members: []v1beta1.RestoreMemberStatus{{Conditions: planned(metav1.ConditionTrue)}, {Conditions: planned(metav1.ConditionFalse)}},
+			total:   2,
+			want:    v1beta1.RestoreFailed,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := calculateDryRunPhase(test.members, test.total); got != test.want {
+				t.Errorf("calculateDryRunPhase() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package invoker
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
@@ -155,11 +156,13 @@ func (inv *RestoreBatchInvoker) GetTargetInfo() []RestoreTargetInfo {
 	for _, member := range inv.restoreBatch.Spec.Members {
 		targetInfo = append(targetInfo, RestoreTargetInfo{
 			Task:                  member.Task,
-			Target:                getRestoreTarget(member.Target.DeepCopy(), inv.restoreBatch.Namespace),
+			Target:                v1beta1.ApplyRestoreMappings(getRestoreTarget(member.Target.DeepCopy(), inv.restoreBatch.Namespace), inv.restoreBatch.Spec.Mappings),
 			RuntimeSettings:       member.RuntimeSettings,
 			TempDir:               member.TempDir,
 			InterimVolumeTemplate: member.InterimVolumeTemplate,
 			Hooks:                 member.Hooks,
+			TimeOut:               member.TimeOut,
+			RetryConfig:           member.RetryConfig,
 		})
 	}
 	return targetInfo
@@ -203,12 +206,37 @@ func (inv *RestoreBatchInvoker) GetGlobalHooks() *v1beta1.RestoreHooks {
 	return inv.restoreBatch.Spec.Hooks
 }
 
+func (inv *RestoreBatchInvoker) IsDryRun() bool {
+	return inv.restoreBatch.Spec.DryRun
+}
+
 func (inv *RestoreBatchInvoker) GetExecutionOrder() v1beta1.ExecutionOrder {
+	// NextInOrder must be consulted before starting each member when the members depend on each other
+	if v1beta1.HasDependencies(inv.restoreBatch.MemberDependencies()) {
+		return v1beta1.Sequential
+	}
 	return inv.restoreBatch.Spec.ExecutionOrder
 }
 
+func (inv *RestoreBatchInvoker) GetFailurePolicy() *v1beta1.FailurePolicy {
+	return inv.restoreBatch.Spec.FailurePolicy
+}
+
 func (inv *RestoreBatchInvoker) NextInOrder(curTarget v1beta1.TargetRef, targetStatus []v1beta1.RestoreMemberStatus) bool {
-	for _, t := range inv.GetTargetInfo() {
+	if inv.GetFailurePolicy().StopOnFailure() && targetRestoreFailed(targetStatus) {
+		// Don't start any more members. The restore will be considered Failed once the running members complete.
+		return false
+	}
+	targetInfo := inv.GetTargetInfo()
+	if graph := inv.restoreBatch.MemberDependencies(); v1beta1.HasDependencies(graph) {
+		cur := slices.IndexFunc(targetInfo, func(t RestoreTargetInfo) bool {
+			return t.Target != nil && TargetMatched(t.Target.Ref, curTarget)
+		})
+		return dependenciesSucceeded(graph, cur, func(i int) bool {
+			return targetInfo[i].Target == nil || targetRestoreSucceeded(targetInfo[i].Target.Ref, targetStatus)
+		})
+	}
+	for _, t := range targetInfo {
 		if t.Target != nil {
 			if TargetMatched(t.Target.Ref, curTarget) {
 				return true
@@ -222,6 +250,22 @@ func (inv *RestoreBatchInvoker) NextInOrder(curTarget v1beta1.TargetRef, targetS
 	return true
 }
 
+func (inv *RestoreBatchInvoker) FailedDependencies(curTarget v1beta1.TargetRef, targetStatus []v1beta1.RestoreMemberStatus) []v1beta1.TargetRef {
+	targetInfo := inv.GetTargetInfo()
+	cur := slices.IndexFunc(targetInfo, func(t RestoreTargetInfo) bool {
+		return t.Target != nil && TargetMatched(t.Target.Ref, curTarget)
+	})
+	var refs []v1beta1.TargetRef
+	for _, i := range failedDependencies(inv.restoreBatch.MemberDependencies(), cur, func(i int) bool {
+		return targetInfo[i].Target != nil &&
+			TargetRestoreCompleted(targetInfo[i].Target.Ref, targetStatus) &&
+			!targetRestoreSucceeded(targetInfo[i].Target.Ref, targetStatus)
+	}) {
+		refs = append(refs, targetInfo[i].Target.Ref)
+	}
+	return refs
+}
+
 func (inv *RestoreBatchInvoker) GetHash() string {
 	return inv.restoreBatch.GetSpecHash()
 }
@@ -325,8 +369,19 @@ func (inv *RestoreBatchInvoker) UpdateStatus(status RestoreInvokerStatus) error
 					in.Members = upsertRestoreMemberStatus(in.Members, status.TargetStatus[i])
 				}
 			}
+			if inv.restoreBatch.Spec.FailurePolicy.StopOnFailure() && restoreStoppedOnFailure(in.Members) {
+				// the pending members will never start
+				in.Members = skipPendingRestoreMembers(in.Members)
+			}
+
+			if status.Plan != nil {
+				in.Plan = upsertRestorePlan(in.Plan, status.Plan)
+			}
 
-			in.Phase = calculateRestoreBatchPhase(in, totalTargets)
+			in.Phase = calculateRestoreBatchPhase(in, totalTargets, inv.restoreBatch.Spec.FailurePolicy)
+			if inv.IsDryRun() {
+				in.Phase = calculateDryRunPhase(in.Members, totalTargets)
+			}
 			if IsRestoreCompleted(in.Phase) && in.SessionDuration == "" {
 				duration := time.Since(startTime.Time)
 				in.SessionDuration = duration.Round(time.Second).String()
@@ -361,7 +416,49 @@ func upsertRestoreMemberStatus(cur []v1beta1.RestoreMemberStatus, new v1beta1.Re
 	return cur
 }
 
-func calculateRestoreBatchPhase(status *v1beta1.RestoreBatchStatus, totalTargets int) v1beta1.RestorePhase {
+// RestorePhaseAfterMetrics returns the phase the restore will have once its metrics have been pushed.
+// Pushing metrics is the last step of a restore, so it is used to decide the metrics of the restore itself.
+func RestorePhaseAfterMetrics(inv RestoreInvoker) v1beta1.RestorePhase {
+	rb, ok := inv.(*RestoreBatchInvoker)
+	if !ok {
+		return inv.GetStatus().Phase
+	}
+	status := rb.restoreBatch.Status.DeepCopy()
+	status.Conditions = cutil.SetCondition(status.Conditions, kmapi.Condition{
+		Type:   v1beta1.MetricsPushed,
+		Status: metav1.ConditionTrue,
+	})
+	return calculateRestoreBatchPhase(status, len(rb.GetTargetInfo()), rb.restoreBatch.Spec.FailurePolicy)
+}
+
+// restoreStoppedOnFailure returns true if a member has failed and no other member is running anymore
+func restoreStoppedOnFailure(members []v1beta1.RestoreMemberStatus) bool {
+	for _, m := range members {
+		if m.Phase == v1beta1.TargetRestoreRunning || RestoreMemberRetryable(m) {
+			return false
+		}
+	}
+	return targetRestoreFailed(members)
+}
+
+func skipPendingRestoreMembers(members []v1beta1.RestoreMemberStatus) []v1beta1.RestoreMemberStatus {
+	for i := range members {
+		if members[i].Phase != v1beta1.TargetRestorePending && members[i].Phase != "" {
+			continue
+		}
+		members[i].Conditions = cutil.SetCondition(members[i].Conditions, kmapi.Condition{
+			Type:               v1beta1.TargetSkipped,
+			Status:             metav1.ConditionTrue,
+			Reason:             v1beta1.SkippedDueToFailurePolicy,
+			Message:            "Skipped restoring as another member has failed.",
+			LastTransitionTime: metav1.Now(),
+		})
+		members[i].Phase = calculateRestoreTargetPhase(members[i])
+	}
+	return members
+}
+
+func calculateRestoreBatchPhase(status *v1beta1.RestoreBatchStatus, totalTargets int, policy *v1beta1.FailurePolicy) v1beta1.RestorePhase {
 	if cutil.IsConditionFalse(status.Conditions, v1beta1.MetricsPushed) {
 		return v1beta1.RestoreFailed
 	}
@@ -385,29 +482,41 @@ func calculateRestoreBatchPhase(status *v1beta1.RestoreBatchStatus, totalTargets
 	failedTargetCount := 0
 	unknownTargetCount := 0
 	successfulTargetCount := 0
+	runningTargetCount := 0
 
 	for _, m := range status.Members {
-		switch m.Phase {
-		case v1beta1.TargetRestoreFailed:
+		switch {
+		case RestoreMemberRetryable(m):
+			// the restore of the member will be retried
+			runningTargetCount++
+		case m.Phase == v1beta1.TargetRestoreFailed, m.Phase == v1beta1.TargetRestoreSkipped:
+			// a skipped member has not been restored. So, it is considered as failed.
 			failedTargetCount++
-		case v1beta1.TargetRestorePhaseUnknown:
+		case m.Phase == v1beta1.TargetRestorePhaseUnknown:
 			unknownTargetCount++
-		case v1beta1.TargetRestoreSucceeded:
+		case m.Phase == v1beta1.TargetRestoreSucceeded:
 			successfulTargetCount++
+		case m.Phase == v1beta1.TargetRestoreRunning:
+			runningTargetCount++
 		}
 	}
 	completedTargets := successfulTargetCount + failedTargetCount + unknownTargetCount
+	// the remaining members will never start once a member fails if the failure policy stops on failure
+	stoppedOnFailure := failedTargetCount > 0 && runningTargetCount == 0 && policy.StopOnFailure()
 
-	if completedTargets == totalTargets {
+	if completedTargets == totalTargets || stoppedOnFailure {
 		if unknownTargetCount > 0 {
 			return v1beta1.RestorePhaseUnknown
 		}
 
-		if failedTargetCount > 0 {
+		if failedTargetCount > 0 && (stoppedOnFailure || !policy.AllowsPartialSuccess(successfulTargetCount)) {
 			return v1beta1.RestoreFailed
 		}
 
 		if cutil.IsConditionTrue(status.Conditions, v1beta1.MetricsPushed) {
+			if failedTargetCount > 0 {
+				return v1beta1.RestorePartiallySucceeded
+			}
 			return v1beta1.RestoreSucceeded
 		}
 	}
@@ -439,9 +548,14 @@ func (inv *RestoreBatchInvoker) GetSummary(target v1beta1.TargetRef, session kma
 	}
 	summary.Status.Duration = time.Since(rb.CreationTimestamp.Time).Round(time.Second).String()
 
+	memberFailed := false
 	if target.Name != "" {
 		for _, m := range rb.Status.Members {
 			if TargetMatched(target, m.Ref) {
+				if m.RetryLeft != nil {
+					// the member has its own retry configuration
+					summary.RetryLeft = *m.RetryLeft
+				}
 				failureFound, reason := checkRestoreFailureInMemberStatus(m)
 				if failureFound {
 					summary.Status.Phase = string(v1beta1.RestoreFailed)
@@ -451,14 +565,24 @@ func (inv *RestoreBatchInvoker) GetSummary(target v1beta1.TargetRef, session kma
 			}
 		}
 	} else {
+		succeeded := 0
 		for _, m := range rb.Status.Members {
 			failureFound, reason := checkRestoreFailureInMemberStatus(m)
-			if failureFound {
-				summary.Status.Phase = string(v1beta1.RestoreFailed)
+			if !failureFound {
+				if m.Phase == v1beta1.TargetRestoreSucceeded {
+					succeeded++
+				}
+				continue
+			}
+			memberFailed = true
+			if summary.Status.Error == "" {
 				summary.Status.Error = reason
-				return summary
 			}
 		}
+		if memberFailed && !rb.Spec.FailurePolicy.AllowsPartialSuccess(succeeded) {
+			summary.Status.Phase = string(v1beta1.RestoreFailed)
+			return summary
+		}
 	}
 
 	failureFound, reason := checkFailureInConditions(rb.Status.Conditions)
@@ -468,6 +592,11 @@ func (inv *RestoreBatchInvoker) GetSummary(target v1beta1.TargetRef, session kma
 		return summary
 	}
 
+	if memberFailed {
+		// some members have failed but the failure policy allows partial success
+		summary.Status.Phase = string(v1beta1.RestorePartiallySucceeded)
+		return summary
+	}
 	summary.Status.Phase = string(v1beta1.RestoreSucceeded)
 	return summary
 }
@@ -140,7 +140,7 @@ func (inv *RestoreSessionInvoker) GetTargetInfo() []RestoreTargetInfo {
 	return []RestoreTargetInfo{
 		{
 			Task:                  inv.restoreSession.Spec.Task,
-			Target:                getRestoreTarget(inv.restoreSession.Spec.Target.DeepCopy(), inv.restoreSession.Namespace),
+			Target:                v1beta1.ApplyRestoreMappings(getRestoreTarget(inv.restoreSession.Spec.Target.DeepCopy(), inv.restoreSession.Namespace), inv.restoreSession.Spec.Mappings),
 			RuntimeSettings:       inv.restoreSession.Spec.RuntimeSettings,
 			TempDir:               inv.restoreSession.Spec.TempDir,
 			InterimVolumeTemplate: inv.restoreSession.Spec.InterimVolumeTemplate,
@@ -180,6 +180,10 @@ func (inv *RestoreSessionInvoker) GetGlobalHooks() *v1beta1.RestoreHooks {
 	return nil
 }
 
+func (inv *RestoreSessionInvoker) IsDryRun() bool {
+	return inv.restoreSession.Spec.DryRun
+}
+
 func (inv *RestoreSessionInvoker) GetExecutionOrder() v1beta1.ExecutionOrder {
 	return v1beta1.Sequential
 }
@@ -199,6 +203,15 @@ func (inv *RestoreSessionInvoker) NextInOrder(curTarget v1beta1.TargetRef, targe
 	return true
 }
 
+// FailedDependencies always returns nil as the target of a RestoreSession does not depend on anything
+func (inv *RestoreSessionInvoker) FailedDependencies(_ v1beta1.TargetRef, _ []v1beta1.RestoreMemberStatus) []v1beta1.TargetRef {
+	return nil
+}
+
+func (inv *RestoreSessionInvoker) GetFailurePolicy() *v1beta1.FailurePolicy {
+	return nil
+}
+
 func (inv *RestoreSessionInvoker) GetHash() string {
 	return inv.restoreSession.GetSpecHash()
 }
@@ -307,6 +320,9 @@ func (inv *RestoreSessionInvoker) UpdateStatus(status RestoreInvokerStatus) erro
 				in.Stats = updatedStatus.Stats
 				in.TotalHosts = updatedStatus.TotalHosts
 				in.Phase = calculateRestoreSessionPhase(updatedStatus)
+				if inv.IsDryRun() {
+					in.Phase = calculateDryRunPhase([]v1beta1.RestoreMemberStatus{updatedStatus}, 1)
+				}
 
 				if IsRestoreCompleted(in.Phase) && in.SessionDuration == "" {
 					in.SessionDuration = time.Since(startTime).Round(time.Second).String()
@@ -317,6 +333,10 @@ func (inv *RestoreSessionInvoker) UpdateStatus(status RestoreInvokerStatus) erro
 				in.SessionDeadline = status.SessionDeadline
 			}
 
+			if status.Plan != nil {
+				in.Plan = upsertRestorePlan(in.Plan, status.Plan)
+			}
+
 			return inv.restoreSession.UID, in
 		},
 		metav1.UpdateOptions{},
@@ -430,7 +450,11 @@ func calculateRestoreSessionPhase(status v1beta1.RestoreMemberStatus) v1beta1.Re
 
 func RestoreCompletedForAllTargets(status []v1beta1.RestoreMemberStatus, totalTargets int) bool {
 	for _, t := range status {
-		if t.Phase == v1beta1.TargetRestoreSucceeded || t.Phase == v1beta1.TargetRestoreFailed || t.Phase == v1beta1.TargetRestorePhaseUnknown {
+		if RestoreMemberRetryable(t) {
+			return false
+		}
+		if t.Phase == v1beta1.TargetRestoreSucceeded || t.Phase == v1beta1.TargetRestoreFailed || t.Phase == v1beta1.TargetRestorePhaseUnknown ||
+			t.Phase == v1beta1.TargetRestoreSkipped {
 			continue
 		}
 		if t.TotalHosts == nil || !restoreCompletedForAllHosts(t.Stats, *t.TotalHosts) {
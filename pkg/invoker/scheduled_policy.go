@@ -0,0 +1,114 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package invoker
+
+import (
+	"stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+	"stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+
+	kmapi "kmodules.xyz/client-go/api/v1"
+)
+
+// getSessionScheduledPolicy returns the scheduled policy that has triggered the session.
+// It returns nil if the session has been triggered by the default schedule or on demand.
+func getSessionScheduledPolicy(inv BackupInvoker, session *v1beta1.BackupSession) *v1beta1.ScheduledPolicy {
+	if session.Spec.Schedule == "" {
+		return nil
+	}
+	policies := inv.GetScheduledPolicies()
+	for i := range policies {
+		if policies[i].Name == session.Spec.Schedule {
+			return &policies[i]
+		}
+	}
+	return nil
+}
+
+// GetSessionRepoRef returns the Repository used by the session. The Repository of the scheduled policy
+// that has triggered the session overrides the Repository of the invoker.
+func GetSessionRepoRef(inv BackupInvoker, session *v1beta1.BackupSession) kmapi.ObjectReference {
+	policy := getSessionScheduledPolicy(inv, session)
+	if policy == nil || policy.Repository == nil {
+		return inv.GetRepoRef()
+	}
+	repo := *policy.Repository
+	if repo.Namespace == "" {
+		repo.Namespace = inv.GetObjectMeta().Namespace
+	}
+	return repo
+}
+
+// GetSessionRetentionPolicy returns the retention policy to apply after the session along with the tags
+// of the snapshots it should be applied on. The retention policy of a scheduled policy is applied only on
+// the snapshots taken by that schedule. If the invoker has any scheduled policy, the default retention policy
+// is applied only on the untagged snapshots, so that it does not remove the snapshots of the other schedules.
+// It returns nil if the session has asked to skip the retention policy.
+func GetSessionRetentionPolicy(inv BackupInvoker, session *v1beta1.BackupSession) (*v1alpha1.RetentionPolicy, []string) {
+	if session.Spec.SkipRetentionPolicy {
+		return nil, nil
+	}
+	retentionPolicy := inv.GetRetentionPolicy()
+	policy := getSessionScheduledPolicy(inv, session)
+	if policy == nil {
+		if len(inv.GetScheduledPolicies()) > 0 {
+			// restic selects the snapshots without any tag for an empty tag
+			return &retentionPolicy, []string{""}
+		}
+		return &retentionPolicy, nil
+	}
+	if policy.RetentionPolicy != nil {
+		return policy.RetentionPolicy, []string{policy.GetTag()}
+	}
+	return &retentionPolicy, []string{policy.GetTag()}
+}
+
+// ForSession returns the invoker scoped to the given BackupSession. GetTargetInfo of the returned invoker
+// returns only the targets selected by the session and their Task parameters are overridden by the session.
+// The members of a BackupBatch that are not selected by the session do not block the others in NextInOrder.
+func ForSession(inv BackupInvoker, session *v1beta1.BackupSession) BackupInvoker {
+	switch in := inv.(type) {
+	case *BackupBatchInvoker:
+		scoped := *in
+		scoped.session = session
+		return &scoped
+	case *BackupConfigurationInvoker:
+		scoped := *in
+		scoped.session = session
+		return &scoped
+	}
+	return inv
+}
+
+func sessionIncludesTarget(session *v1beta1.BackupSession, ref v1beta1.TargetRef) bool {
+	return session == nil || session.IncludesTarget(ref)
+}
+
+// sessionTargetInfo returns the targets selected by the session after applying the parameter overrides of the session
+func sessionTargetInfo(session *v1beta1.BackupSession, targetInfo []BackupTargetInfo) []BackupTargetInfo {
+	if session == nil {
+		return targetInfo
+	}
+	var result []BackupTargetInfo
+	for _, t := range targetInfo {
+		if t.Target != nil && !session.IncludesTarget(t.Target.Ref) {
+			continue
+		}
+		t.Task.Params = session.OverrideParams(t.Task.Params)
+		result = append(result, t)
+	}
+	return result
+}
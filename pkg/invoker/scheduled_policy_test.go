@@ -0,0 +1,170 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package invoker
+
+import (
+	"slices"
+	"testing"
+
+	"stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+	"stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kmapi "kmodules.xyz/client-go/api/v1"
+)
+
+func TestScheduledPolicySession(t *testing.T) {
+	bc := &v1beta1.BackupConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "sample-backup", Namespace: "demo"},
+		Spec: v1beta1.BackupConfigurationSpec{
+			BackupConfigurationTemplateSpec: v1beta1.BackupConfigurationTemplateSpec{
+				Target: &v1beta1.BackupTarget{
+					Ref: v1beta1.TargetRef{APIVersion: "apps/v1", Kind: "Deployment", Name: "app"},
+				},
+			},
+			Schedule:        "0 * * * *",
+			Repository:      kmapi.ObjectReference{Name: "local-repo"},
+			RetentionPolicy: v1alpha1.RetentionPolicy{Name: "keep-last-24", KeepLast: 24},
+			Schedules: []v1beta1.ScheduledPolicy{
+				{
+					Name:            "weekly-offsite",
+					Schedule:        "0 0 * * 0",
+					Tag:             "offsite",
+					Repository:      &kmapi.ObjectReference{Name: "offsite-repo"},
+					RetentionPolicy: &v1alpha1.RetentionPolicy{Name: "keep-weekly-4", KeepWeekly: 4},
+				},
+				{
+					Name:     "daily",
+					Schedule: "0 0 * * *",
+				},
+			},
+		},
+	}
+	inv := NewBackupConfigurationInvoker(nil, bc)
+
+	tests := []struct {
+		name          string
+		schedule      string
+		skipRetention bool
+		wantTags      []string
+		wantRepo      string
+		wantRetention string
+		wantFilter    []string
+	}{
+		{
+			name:          "default schedule",
+			wantRepo:      "local-repo",
+			wantRetention: "keep-last-24",
+			wantFilter:    []string{""},
+		},
+		{
+			name:          "schedule overriding repository and retention policy",
+			schedule:      "weekly-offsite",
+			wantTags:      []string{"offsite"},
+			wantRepo:      "offsite-repo",
+			wantRetention: "keep-weekly-4",
+			wantFilter:    []string{"offsite"},
+		},
+		{
+			name:          "schedule without any override",
+			schedule:      "daily",
+			wantTags:      []string{"daily"},
+			wantRepo:      "local-repo",
+			wantRetention: "keep-last-24",
+			wantFilter:    []string{"daily"},
+		},
+		{
+			name:          "session skipping retention policy",
+			schedule:      "weekly-offsite",
+			skipRetention: true,
+			wantTags:      []string{"offsite"},
+			wantRepo:      "offsite-repo",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			session := inv.NewScheduledSession(test.schedule)
+			session.Spec.SkipRetentionPolicy = test.skipRetention
+			if session.Spec.Schedule != test.schedule || !slices.Equal(session.Spec.Tags, test.wantTags) {
+				t.Errorf("unexpected session schedule %q with tags %v", session.Spec.Schedule, session.Spec.Tags)
+				return
+			}
+			if repo := GetSessionRepoRef(inv, session); repo.Name != test.wantRepo || repo.Namespace != "demo" {
+				t.Errorf("expected repository demo/%s, found %s/%s", test.wantRepo, repo.Namespace, repo.Name)
+			}
+			policy, tags := GetSessionRetentionPolicy(inv, session)
+			if test.skipRetention {
+				if policy != nil {
+					t.Errorf("expected no retention policy, found %s", policy.Name)
+				}
+				return
+			}
+			if policy == nil || policy.Name != test.wantRetention || !slices.Equal(tags, test.wantFilter) {
+				t.Errorf("expected retention policy %s for tags %q, found %v for tags %q", test.wantRetention, test.wantFilter, policy, tags)
+			}
+		})
+	}
+}
+
+func TestForSession(t *testing.T) {
+	ref := func(name string) v1beta1.TargetRef {
+		return v1beta1.TargetRef{APIVersion: "apps/v1", Kind: "StatefulSet", Name: name, Namespace: "demo"}
+	}
+	member := func(target v1beta1.TargetRef) v1beta1.BackupConfigurationTemplateSpec {
+		return v1beta1.BackupConfigurationTemplateSpec{
+			Task:   v1beta1.TaskRef{Params: []v1beta1.Param{{Name: "args", Value: "--all-databases"}}},
+			Target: &v1beta1.BackupTarget{Ref: target},
+		}
+	}
+	bb := &v1beta1.BackupBatch{
+		ObjectMeta: metav1.ObjectMeta{Name: "sample-backup", Namespace: "demo"},
+		Spec: v1beta1.BackupBatchSpec{
+			ExecutionOrder: v1beta1.Sequential,
+			Members:        []v1beta1.BackupConfigurationTemplateSpec{member(ref("db")), member(ref("cache")), member(ref("search"))},
+		},
+	}
+	inv := NewBackupBatchInvoker(nil, bb)
+	session := inv.NewSession()
+	session.Spec.Targets = []v1beta1.TargetRef{ref("cache"), ref("search")}
+	session.Spec.Params = []v1beta1.Param{{Name: "args", Value: "--databases=app"}}
+
+	scoped := ForSession(inv, session)
+	targetInfo := scoped.GetTargetInfo()
+	if len(targetInfo) != 2 || !TargetMatched(targetInfo[0].Target.Ref, ref("cache")) || !TargetMatched(targetInfo[1].Target.Ref, ref("search")) {
+		t.Errorf("expected only the selected targets, found %v", targetInfo)
+		return
+	}
+	for _, info := range targetInfo {
+		if len(info.Task.Params) != 1 || info.Task.Params[0].Value != "--databases=app" {
+			t.Errorf("expected overridden params for %s, found %v", info.Target.Ref.Name, info.Task.Params)
+		}
+	}
+	if params := bb.Spec.Members[0].Task.Params; params[0].Value != "--all-databases" {
+		t.Errorf("params of the BackupBatch must not be modified, found %v", params)
+	}
+	if len(inv.GetTargetInfo()) != 3 {
+		t.Errorf("the original invoker must not be scoped to the session")
+	}
+
+	// the excluded member "db" must not block the first selected member
+	if !scoped.NextInOrder(ref("cache"), nil) {
+		t.Errorf("excluded member blocked the selected member")
+	}
+	if scoped.NextInOrder(ref("search"), []v1beta1.BackupTargetStatus{{Ref: ref("cache"), Phase: v1beta1.TargetBackupRunning}}) {
+		t.Errorf("selected member started before the previous selected member has completed")
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package invoker
+
+import (
+	"context"
+	"time"
+
+	"stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	stash_util "stash.appscode.dev/apimachinery/client/clientset/versioned/typed/stash/v1beta1/util"
+
+	"gomodules.xyz/pointer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// NewBackupTargetStatus returns the initial status of a target when its backup starts. The deadline and the
+// retry attempts are set only if the target has its own timeout and retry configuration.
+func NewBackupTargetStatus(info BackupTargetInfo, startTime time.Time) v1beta1.BackupTargetStatus {
+	status := v1beta1.BackupTargetStatus{
+		Deadline: targetDeadline(info.TimeOut, startTime),
+	}
+	if info.Target != nil {
+		status.Ref = info.Target.Ref
+	}
+	if info.RetryConfig != nil {
+		status.RetryLeft = pointer.Int32P(info.RetryConfig.MaxRetry)
+	}
+	return status
+}
+
+// BackupTargetRetryable returns true if the backup of the target has failed but the target has retry attempts left.
+// The session waits for such a target instead of considering it failed.
+func BackupTargetRetryable(status v1beta1.BackupTargetStatus) bool {
+	return status.Phase == v1beta1.TargetBackupFailed && pointer.Int32(status.RetryLeft) > 0
+}
+
+// BackupTargetDeadlineExceeded returns true if the backup of the target has not completed within its deadline.
+func BackupTargetDeadlineExceeded(status v1beta1.BackupTargetStatus, now time.Time) bool {
+	return status.Deadline != nil &&
+		status.Phase != v1beta1.TargetBackupSucceeded &&
+		status.Phase != v1beta1.TargetBackupFailed &&
+		status.Phase != v1beta1.TargetBackupSkipped &&
+		now.After(status.Deadline.Time)
+}
+
+// RetryTarget resets the status of a failed target that has retry attempts left so that only the backup of
+// this target is run again. The status of the other targets of the session are kept as they are.
+func (h *BackupSessionHandler) RetryTarget(info BackupTargetInfo) error {
+	if info.Target == nil {
+		return nil
+	}
+	updatedBackupSession, err := stash_util.UpdateBackupSessionStatus(
+		context.TODO(),
+		h.stashClient.StashV1beta1(),
+		h.backupSession.ObjectMeta,
+		func(in *v1beta1.BackupSessionStatus) (types.UID, *v1beta1.BackupSessionStatus) {
+			for i := range in.Targets {
+				if TargetMatched(in.Targets[i].Ref, info.Target.Ref) && BackupTargetRetryable(in.Targets[i]) {
+					in.Targets[i] = resetBackupTargetStatus(in.Targets[i], info.TimeOut, time.Now())
+				}
+			}
+			in.Phase = calculateBackupSessionPhase(in, h.backupSession.Spec.FailurePolicy)
+			return h.backupSession.UID, in
+		},
+		metav1.UpdateOptions{},
+	)
+	if err != nil {
+		return err
+	}
+	h.backupSession = updatedBackupSession
+	return nil
+}
+
+func resetBackupTargetStatus(status v1beta1.BackupTargetStatus, timeOut *metav1.Duration, now time.Time) v1beta1.BackupTargetStatus {
+	return v1beta1.BackupTargetStatus{
+		Ref:       status.Ref,
+		Phase:     v1beta1.TargetBackupPending,
+		Deadline:  targetDeadline(timeOut, now),
+		RetryLeft: pointer.Int32P(pointer.Int32(status.RetryLeft) - 1),
+	}
+}
+
+// NewRestoreMemberStatus returns the initial status of a member when its restore starts. The deadline and the
+// retry attempts are set only if the member has its own timeout and retry configuration.
+func NewRestoreMemberStatus(info RestoreTargetInfo, startTime time.Time) v1beta1.RestoreMemberStatus {
+	status := v1beta1.RestoreMemberStatus{
+		Deadline: targetDeadline(info.TimeOut, startTime),
+	}
+	if info.Target != nil {
+		status.Ref = info.Target.Ref
+	}
+	if info.RetryConfig != nil {
+		status.RetryLeft = pointer.Int32P(info.RetryConfig.MaxRetry)
+	}
+	return status
+}
+
+// RestoreMemberRetryable returns true if the restore of the member has failed but the member has retry attempts left.
+// The RestoreBatch waits for such a member instead of considering it failed.
+func RestoreMemberRetryable(status v1beta1.RestoreMemberStatus) bool {
+	return status.Phase == v1beta1.TargetRestoreFailed && pointer.Int32(status.RetryLeft) > 0
+}
+
+// RestoreMemberDeadlineExceeded returns true if the restore of the member has not completed within its deadline.
+func RestoreMemberDeadlineExceeded(status v1beta1.RestoreMemberStatus, now time.Time) bool {
+	return status.Deadline != nil &&
+		status.Phase != v1beta1.TargetRestoreSucceeded &&
+		status.Phase != v1beta1.TargetRestoreFailed &&
+		status.Phase != v1beta1.TargetRestorePhaseUnknown &&
+		status.Phase != v1beta1.TargetRestoreSkipped &&
+		now.After(status.Deadline.Time)
+}
+
+// RetryMember resets the status of a failed member that has retry attempts left so that only the restore of
+// this member is run again. The status of the other members of the RestoreBatch are kept as they are.
+func (inv *RestoreBatchInvoker) RetryMember(info RestoreTargetInfo) error {
+	if info.Target == nil {
+		return nil
+	}
+	totalTargets := len(inv.GetTargetInfo())
+	updatedRestoreBatch, err := stash_util.UpdateRestoreBatchStatus(
+		context.TODO(),
+		inv.stashClient.StashV1beta1(),
+		inv.restoreBatch.ObjectMeta,
+		func(in *v1beta1.RestoreBatchStatus) (types.UID, *v1beta1.RestoreBatchStatus) {
+			for i := range in.Members {
+				if TargetMatched(in.Members[i].Ref, info.Target.Ref) && RestoreMemberRetryable(in.Members[i]) {
+					in.Members[i] = resetRestoreMemberStatus(in.Members[i], info.TimeOut, time.Now())
+				}
+			}
+			in.Phase = calculateRestoreBatchPhase(in, totalTargets, inv.restoreBatch.Spec.FailurePolicy)
+			return inv.restoreBatch.UID, in
+		},
+		metav1.UpdateOptions{},
+	)
+	if err != nil {
+		return err
+	}
+	inv.restoreBatch = updatedRestoreBatch
+	return nil
+}
+
+func resetRestoreMemberStatus(status v1beta1.RestoreMemberStatus, timeOut *metav1.Duration, now time.Time) v1beta1.RestoreMemberStatus {
+	return v1beta1.RestoreMemberStatus{
+		Ref:       status.Ref,
+		Phase:     v1beta1.TargetRestorePending,
+		Deadline:  targetDeadline(timeOut, now),
+		RetryLeft: pointer.Int32P(pointer.Int32(status.RetryLeft) - 1),
+	}
+}
+
+func targetDeadline(timeOut *metav1.Duration, startTime time.Time) *metav1.Time {
+	if timeOut == nil {
+		return nil
+	}
+	deadline := metav1.NewTime(startTime.Add(timeOut.Duration))
+	return &deadline
+}
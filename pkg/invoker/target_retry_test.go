@@ -0,0 +1,203 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package invoker
+
+import (
+	"testing"
+	"time"
+
+	"stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+
+	"gomodules.xyz/pointer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kmapi "kmodules.xyz/client-go/api/v1"
+)
+
+func TestBackupTargetRetry(t *testing.T) {
+	database := v1beta1.TargetRef{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "database", Namespace: "demo"}
+	configMap := v1beta1.TargetRef{APIVersion: "v1", Kind: "ConfigMap", Name: "config", Namespace: "demo"}
+	info := BackupTargetInfo{
+		Target:      &v1beta1.BackupTarget{Ref: database},
+		TimeOut:     &metav1.Duration{Duration: 30 * time.Minute},
+		RetryConfig: &v1beta1.RetryConfig{MaxRetry: 2},
+	}
+	startTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	db := NewBackupTargetStatus(info, startTime)
+	if pointer.Int32(db.RetryLeft) != 2 || db.Deadline == nil || !db.Deadline.Time.Equal(startTime.Add(30*time.Minute)) {
+		t.Errorf("NewBackupTargetStatus() = %+v, want retryLeft 2 and deadline after 30m", db)
+		return
+	}
+	if cs := NewBackupTargetStatus(BackupTargetInfo{Target: &v1beta1.BackupTarget{Ref: configMap}}, startTime); cs.RetryLeft != nil || cs.Deadline != nil {
+		t.Errorf("NewBackupTargetStatus() = %+v, want neither retryLeft nor deadline for a target without its own configuration", cs)
+		return
+	}
+
+	db.Phase = v1beta1.TargetBackupRunning
+	if !BackupTargetDeadlineExceeded(db, startTime.Add(time.Hour)) {
+		t.Errorf("BackupTargetDeadlineExceeded() = false, want true after the deadline")
+		return
+	}
+
+	db.Phase = v1beta1.TargetBackupFailed
+	db.Conditions = []kmapi.Condition{{Type: v1beta1.DeadlineExceeded, Status: metav1.ConditionTrue}}
+	status := &v1beta1.BackupSessionStatus{
+		Conditions: []kmapi.Condition{{Type: v1beta1.MetricsPushed, Status: metav1.ConditionTrue}},
+		Targets: []v1beta1.BackupTargetStatus{
+			db,
+			{Ref: configMap, Phase: v1beta1.TargetBackupSucceeded},
+		},
+	}
+	if TargetBackupCompleted(database, status.Targets) {
+		t.Errorf("TargetBackupCompleted() = true, want false for a target that will be retried")
+		return
+	}
+	if phase := calculateBackupSessionPhase(status, nil); phase != v1beta1.BackupSessionRunning {
+		t.Errorf("calculateBackupSessionPhase() = %v, want %v while a target will be retried", phase, v1beta1.BackupSessionRunning)
+		return
+	}
+
+	retryTime := startTime.Add(time.Hour)
+	db = resetBackupTargetStatus(db, info.TimeOut, retryTime)
+	if db.Phase != v1beta1.TargetBackupPending || pointer.Int32(db.RetryLeft) != 1 || len(db.Conditions) != 0 ||
+		!db.Deadline.Time.Equal(retryTime.Add(30*time.Minute)) {
+		t.Errorf("resetBackupTargetStatus() = %+v, want a pending target with 1 retry left and a new deadline", db)
+		return
+	}
+
+	// the target fails again after exhausting its retries
+	db.Phase = v1beta1.TargetBackupFailed
+	db.RetryLeft = pointer.Int32P(0)
+	status.Targets[0] = db
+	if phase := calculateBackupSessionPhase(status, nil); phase != v1beta1.BackupSessionFailed {
+		t.Errorf("calculateBackupSessionPhase() = %v, want %v after the retries are exhausted", phase, v1beta1.BackupSessionFailed)
+	}
+}
+
+func TestUpsertTargetStatusKeepsRetryState(t *testing.T) {
+	deadline := metav1.NewTime(time.Date(2026, 1, 1, 0, 30, 0, 0, time.UTC))
+	ref := v1beta1.TargetRef{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "database", Namespace: "demo"}
+
+	backup := upsertBackupTargetStatus(v1beta1.BackupTargetStatus{Ref: ref}, v1beta1.BackupTargetStatus{Ref: ref, Deadline: &deadline, RetryLeft: pointer.Int32P(2)})
+	if pointer.Int32(backup.RetryLeft) != 2 || backup.Deadline == nil {
+		t.Errorf("upsertBackupTargetStatus() = %+v, want retryLeft 2 and the deadline to be set", backup)
+		return
+	}
+	backup.RetryLeft = pointer.Int32P(1)
+	if backup = upsertBackupTargetStatus(backup, v1beta1.BackupTargetStatus{Ref: ref, RetryLeft: pointer.Int32P(2)}); pointer.Int32(backup.RetryLeft) != 1 {
+		t.Errorf("upsertBackupTargetStatus() retryLeft = %d, want 1 to be kept", pointer.Int32(backup.RetryLeft))
+	}
+
+	restore := upsertRestoreTargetStatus(v1beta1.RestoreMemberStatus{Ref: ref}, v1beta1.RestoreMemberStatus{Ref: ref, Deadline: &deadline, RetryLeft: pointer.Int32P(2)})
+	if pointer.Int32(restore.RetryLeft) != 2 || restore.Deadline == nil {
+		t.Errorf("upsertRestoreTargetStatus() = %+v, want retryLeft 2 and the deadline to be set", restore)
+		return
+	}
+	restore.RetryLeft = pointer.Int32P(1)
+	if restore = upsertRestoreTargetStatus(restore, v1beta1.RestoreMemberStatus{Ref: ref, RetryLeft: pointer.Int32P(2)}); pointer.Int32(restore.RetryLeft) != 1 {
+		t.Errorf("upsertRestoreTargetStatus() retryLeft = %d, want 1 to be kept", pointer.Int32(restore.RetryLeft))
+	}
+}
+
+func TestRetriesExhaustedWithoutTimeOut(t *testing.T) {
+	ref := v1beta1.TargetRef{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "database", Namespace: "demo"}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	backupInfo := BackupTargetInfo{
+		Target:      &v1beta1.BackupTarget{Ref: ref},
+		RetryConfig: &v1beta1.RetryConfig{MaxRetry: 1},
+	}
+	backup := upsertBackupTargetStatus(v1beta1.BackupTargetStatus{Ref: ref}, NewBackupTargetStatus(backupInfo, now))
+	backup.Phase = v1beta1.TargetBackupFailed
+	backup = resetBackupTargetStatus(backup, backupInfo.TimeOut, now)
+	// the backup of the target is started again after the last retry
+	backup = upsertBackupTargetStatus(backup, NewBackupTargetStatus(backupInfo, now))
+	backup.Phase = v1beta1.TargetBackupFailed
+	if BackupTargetRetryable(backup) {
+		t.Errorf("BackupTargetRetryable() = true, want false after the last retry, retryLeft = %d", pointer.Int32(backup.RetryLeft))
+	}
+
+	restoreInfo := RestoreTargetInfo{
+		Target:      &v1beta1.RestoreTarget{Ref: ref},
+		RetryConfig: &v1beta1.RetryConfig{MaxRetry: 1},
+	}
+	restore := upsertRestoreTargetStatus(v1beta1.RestoreMemberStatus{Ref: ref}, NewRestoreMemberStatus(restoreInfo, now))
+	restore.Phase = v1beta1.TargetRestoreFailed
+	restore = resetRestoreMemberStatus(restore, restoreInfo.TimeOut, now)
+	// the restore of the member is started again after the last retry
+	restore = upsertRestoreTargetStatus(restore, NewRestoreMemberStatus(restoreInfo, now))
+	restore.Phase = v1beta1.TargetRestoreFailed
+	if RestoreMemberRetryable(restore) {
+		t.Errorf("RestoreMemberRetryable() = true, want false after the last retry, retryLeft = %d", pointer.Int32(restore.RetryLeft))
+	}
+}
+
+func TestRestoreMemberRetry(t *testing.T) {
+	database := v1beta1.TargetRef{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "database", Namespace: "demo"}
+	configMap := v1beta1.TargetRef{APIVersion: "v1", Kind: "ConfigMap", Name: "config", Namespace: "demo"}
+	info := RestoreTargetInfo{
+		Target:      &v1beta1.RestoreTarget{Ref: database},
+		TimeOut:     &metav1.Duration{Duration: 30 * time.Minute},
+		RetryConfig: &v1beta1.RetryConfig{MaxRetry: 1},
+	}
+	startTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	db := NewRestoreMemberStatus(info, startTime)
+	if pointer.Int32(db.RetryLeft) != 1 || db.Deadline == nil || !db.Deadline.Time.Equal(startTime.Add(30*time.Minute)) {
+		t.Errorf("NewRestoreMemberStatus() = %+v, want retryLeft 1 and deadline after 30m", db)
+		return
+	}
+
+	db.Phase = v1beta1.TargetRestoreRunning
+	if !RestoreMemberDeadlineExceeded(db, startTime.Add(time.Hour)) {
+		t.Errorf("RestoreMemberDeadlineExceeded() = false, want true after the deadline")
+		return
+	}
+
+	db.Conditions = []kmapi.Condition{{Type: v1beta1.DeadlineExceeded, Status: metav1.ConditionTrue}}
+	db.Phase = calculateRestoreTargetPhase(db)
+	status := &v1beta1.RestoreBatchStatus{
+		Conditions: []kmapi.Condition{{Type: v1beta1.MetricsPushed, Status: metav1.ConditionTrue}},
+		Members: []v1beta1.RestoreMemberStatus{
+			db,
+			{Ref: configMap, Phase: v1beta1.TargetRestoreSucceeded},
+		},
+	}
+	if db.Phase != v1beta1.TargetRestoreFailed || TargetRestoreCompleted(database, status.Members) {
+		t.Errorf("member phase = %v, want a failed member that is not completed as it will be retried", db.Phase)
+		return
+	}
+	if phase := calculateRestoreBatchPhase(status, 2, nil); phase != v1beta1.RestoreRunning {
+		t.Errorf("calculateRestoreBatchPhase() = %v, want %v while a member will be retried", phase, v1beta1.RestoreRunning)
+		return
+	}
+
+	retryTime := startTime.Add(time.Hour)
+	db = resetRestoreMemberStatus(db, info.TimeOut, retryTime)
+	if db.Phase != v1beta1.TargetRestorePending || pointer.Int32(db.RetryLeft) != 0 || len(db.Conditions) != 0 ||
+		!db.Deadline.Time.Equal(retryTime.Add(30*time.Minute)) {
+		t.Errorf("resetRestoreMemberStatus() = %+v, want a pending member with no retry left and a new deadline", db)
+		return
+	}
+
+	// the member fails again after exhausting its retries
+	db.Phase = v1beta1.TargetRestoreFailed
+	status.Members[0] = db
+	if phase := calculateRestoreBatchPhase(status, 2, nil); phase != v1beta1.RestoreFailed {
+		t.Errorf("calculateRestoreBatchPhase() = %v, want %v after the retries are exhausted", phase, v1beta1.RestoreFailed)
+	}
+}
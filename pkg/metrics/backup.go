@@ -40,7 +40,8 @@ type BackupMetrics struct {
 
 // BackupSessionMetrics defines metrics for entire backup session
 type BackupSessionMetrics struct {
-	// SessionSuccess indicates whether the entire backup session was succeeded or not
+	// SessionSuccess indicates whether the entire backup session was succeeded or not.
+	// For a partially succeeded session, it indicates the fraction of the targets that succeeded.
 	SessionSuccess prometheus.Gauge
 	// SessionDuration indicates total time taken to complete the entire backup session
 	SessionDuration prometheus.Gauge
@@ -96,7 +97,7 @@ func legacyBackupSessionMetrics(labels prometheus.Labels) *BackupMetrics {
 					Namespace:   "stash",
 					Subsystem:   "backup",
 					Name:        "session_success",
-					Help:        "Indicates whether the entire backup session was succeeded or not",
+					Help:        "Indicates whether the entire backup session was succeeded or not. For a partially succeeded session, it indicates the fraction of the targets that succeeded",
 					ConstLabels: labels,
 				},
 			),
@@ -139,7 +140,7 @@ func newBackupSessionMetrics(labels prometheus.Labels) *BackupMetrics {
 					Namespace:   "stash_appscode_com",
 					Subsystem:   "backupsession",
 					Name:        "success",
-					Help:        "Indicates whether the entire backup session was succeeded or not",
+					Help:        "Indicates whether the entire backup session was succeeded or not. For a partially succeeded session, it indicates the fraction of the targets that succeeded",
 					ConstLabels: labels,
 				},
 			),
@@ -423,7 +424,20 @@ func newBackupHostMetrics(labels prometheus.Labels) *BackupMetrics {
 }
 
 // SendBackupSessionMetrics send backup session related metrics to the Pushgateway
+//
+// Deprecated: use SendBackupSessionMetricsOf so that the failure policy of the session is respected.
 func (metricOpt *MetricsOptions) SendBackupSessionMetrics(inv invoker.BackupInvoker, status api_v1beta1.BackupSessionStatus) error {
+	session := &api_v1beta1.BackupSession{
+		Spec: api_v1beta1.BackupSessionSpec{
+			FailurePolicy: inv.GetFailurePolicy(),
+		},
+		Status: status,
+	}
+	return metricOpt.SendBackupSessionMetricsOf(inv, session)
+}
+
+// SendBackupSessionMetricsOf send the metrics of a backup session to the Pushgateway
+func (metricOpt *MetricsOptions) SendBackupSessionMetricsOf(inv invoker.BackupInvoker, session *api_v1beta1.BackupSession) error {
 	// create metric registry
 	registry := prometheus.NewRegistry()
 
@@ -433,12 +447,12 @@ func (metricOpt *MetricsOptions) SendBackupSessionMetrics(inv invoker.BackupInvo
 		return err
 	}
 
-	err = exportBackupSessionMetrics(labels, status, registry)
+	err = exportBackupSessionMetrics(labels, session, registry)
 	if err != nil {
 		return err
 	}
 
-	err = exportBackupSessionLegacyMetrics(labels, status, registry)
+	err = exportBackupSessionLegacyMetrics(labels, session, registry)
 	if err != nil {
 		return err
 	}
@@ -447,14 +461,14 @@ func (metricOpt *MetricsOptions) SendBackupSessionMetrics(inv invoker.BackupInvo
 	return metricOpt.sendMetrics(registry, metricOpt.JobName)
 }
 
-func exportBackupSessionMetrics(labels prometheus.Labels, status api_v1beta1.BackupSessionStatus, registry *prometheus.Registry) error {
+func exportBackupSessionMetrics(labels prometheus.Labels, session *api_v1beta1.BackupSession, registry *prometheus.Registry) error {
 	metrics := newBackupSessionMetrics(labels)
-	return setBackupSessionMetrics(metrics, status, registry)
+	return setBackupSessionMetrics(metrics, session, registry)
 }
 
-func exportBackupSessionLegacyMetrics(labels prometheus.Labels, status api_v1beta1.BackupSessionStatus, registry *prometheus.Registry) error {
+func exportBackupSessionLegacyMetrics(labels prometheus.Labels, session *api_v1beta1.BackupSession, registry *prometheus.Registry) error {
 	metrics := legacyBackupSessionMetrics(labels)
-	return setBackupSessionMetrics(metrics, status, registry)
+	return setBackupSessionMetrics(metrics, session, registry)
 }
 
 func checkIfBackupSessionSucceeded(status api_v1beta1.BackupSessionStatus) bool {
@@ -466,8 +480,30 @@ func checkIfBackupSessionSucceeded(status api_v1beta1.BackupSessionStatus) bool
 	return true
 }
 
-func setBackupSessionMetrics(metrics *BackupMetrics, status api_v1beta1.BackupSessionStatus, registry *prometheus.Registry) error {
-	if checkIfBackupSessionSucceeded(status) {
+func countSucceededBackupTargets(status api_v1beta1.BackupSessionStatus) int {
+	succeeded := 0
+	for _, tr := range status.Targets {
+		if tr.Phase == api_v1beta1.TargetBackupSucceeded {
+			succeeded++
+		}
+	}
+	return succeeded
+}
+
+func setBackupSessionMetrics(metrics *BackupMetrics, session *api_v1beta1.BackupSession, registry *prometheus.Registry) error {
+	status := session.Status
+	if invoker.BackupSessionPhaseAfterMetrics(session) == api_v1beta1.BackupSessionPartiallySucceeded {
+		// mark the backup session as partially succeeded
+		metrics.BackupSessionMetrics.SessionSuccess.Set(float64(countSucceededBackupTargets(status)) / float64(len(status.Targets)))
+
+		// set total number of target that was backed up in this backup session
+		metrics.BackupSessionMetrics.TargetCount.Set(float64(len(status.Targets)))
+
+		registry.MustRegister(
+			metrics.BackupSessionMetrics.SessionSuccess,
+			metrics.BackupSessionMetrics.TargetCount,
+		)
+	} else if checkIfBackupSessionSucceeded(status) {
 		metrics.BackupSessionMetrics.SessionSuccess.Set(1)
 
 		// set total time taken to complete the entire backup session
@@ -40,7 +40,8 @@ type RestoreMetrics struct {
 
 // RestoreSessionMetrics defines metrics related to entire restore session
 type RestoreSessionMetrics struct {
-	// SessionSuccess indicates whether the restore session succeeded or not
+	// SessionSuccess indicates whether the restore session succeeded or not.
+	// For a partially succeeded session, it indicates the fraction of the targets that succeeded.
 	SessionSuccess prometheus.Gauge
 	// SessionDuration indicates the total time taken to complete the entire restore session
 	SessionDuration prometheus.Gauge
@@ -72,7 +73,7 @@ func newRestoreSessionMetrics(labels prometheus.Labels) *RestoreMetrics {
 					Namespace:   "stash_appscode_com",
 					Subsystem:   "restoresession",
 					Name:        "success",
-					Help:        "Indicates whether the entire restore session was succeeded or not",
+					Help:        "Indicates whether the entire restore session was succeeded or not. For a partially succeeded session, it indicates the fraction of the targets that succeeded",
 					ConstLabels: labels,
 				},
 			),
@@ -106,7 +107,7 @@ func legacyRestoreSessionMetrics(labels prometheus.Labels) *RestoreMetrics {
 					Namespace:   "stash",
 					Subsystem:   "restore",
 					Name:        "session_success",
-					Help:        "Indicates whether the entire restore session was succeeded or not",
+					Help:        "Indicates whether the entire restore session was succeeded or not. For a partially succeeded session, it indicates the fraction of the targets that succeeded",
 					ConstLabels: labels,
 				},
 			),
@@ -267,8 +268,30 @@ func exportRestoreSessionLegacyMetrics(labels prometheus.Labels, inv invoker.Res
 	return setRestoreSessionMetrics(metrics, registry, inv)
 }
 
+func countSucceededRestoreTargets(status []api_v1beta1.RestoreMemberStatus) int {
+	succeeded := 0
+	for _, m := range status {
+		if m.Phase == api_v1beta1.TargetRestoreSucceeded {
+			succeeded++
+		}
+	}
+	return succeeded
+}
+
 func setRestoreSessionMetrics(metrics *RestoreMetrics, registry *prometheus.Registry, inv invoker.RestoreInvoker) error {
-	if inv.GetStatus().Phase == api_v1beta1.RestoreSucceeded {
+	targetStatus := inv.GetStatus().TargetStatus
+	if invoker.RestorePhaseAfterMetrics(inv) == api_v1beta1.RestorePartiallySucceeded {
+		// mark the restore session as partially succeeded
+		metrics.RestoreSessionMetrics.SessionSuccess.Set(float64(countSucceededRestoreTargets(targetStatus)) / float64(len(targetStatus)))
+
+		// set total number of target that was restored in this restore session
+		metrics.RestoreSessionMetrics.TargetCount.Set(float64(len(targetStatus)))
+
+		registry.MustRegister(
+			metrics.RestoreSessionMetrics.SessionSuccess,
+			metrics.RestoreSessionMetrics.TargetCount,
+		)
+	} else if inv.GetStatus().Phase == api_v1beta1.RestoreSucceeded {
 		// mark the entire restore session as succeeded
 		metrics.RestoreSessionMetrics.SessionSuccess.Set(1)
 
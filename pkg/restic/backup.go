@@ -18,6 +18,8 @@ package restic
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -25,6 +27,7 @@ import (
 	api_v1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
 
 	"gomodules.xyz/pointer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/errors"
 )
 
@@ -144,6 +147,7 @@ func (w *ResticWrapper) runBackup(backupOption BackupOptions) (api_v1beta1.HostB
 		params := backupParams{
 			path:     path,
 			host:     backupOption.Host,
+			tags:     backupOption.Tags,
 			excludes: backupOption.Exclude,
 			args:     backupOption.Args,
 		}
@@ -197,9 +201,21 @@ func (w *ResticWrapper) InitializeRepository() error {
 }
 
 func (w *ResticWrapper) ApplyRetentionPolicies(retentionPolicy api_v1alpha1.RetentionPolicy) (*RepositoryStats, error) {
+	return w.ApplyRetentionPoliciesForTags(retentionPolicy, nil)
+}
+
+// ApplyRetentionPoliciesForTags applies the retention policy only on the snapshots that have any of the given tags.
+// An empty tag selects the snapshots without any tag. The policy is applied on all snapshots if no tag is given.
+func (w *ResticWrapper) ApplyRetentionPoliciesForTags(retentionPolicy api_v1alpha1.RetentionPolicy, tags []string) (*RepositoryStats, error) {
+	if err := retentionPolicy.IsValid(); err != nil {
+		return nil, err
+	}
+	if w.config.Immutability != nil {
+		return w.applyRetentionPoliciesWithLock(retentionPolicy, tags)
+	}
 	// Cleanup old snapshots according to retention policy
 	out, err := w.RunWithRetry(context.Background(), func() ([]byte, error) {
-		return w.cleanup(retentionPolicy, "")
+		return w.cleanup(retentionPolicy, "", tags)
 	})
 	if err != nil {
 		return nil, err
@@ -212,7 +228,64 @@ func (w *ResticWrapper) ApplyRetentionPolicies(retentionPolicy api_v1alpha1.Rete
 	return &RepositoryStats{SnapshotCount: kept, SnapshotsRemovedOnLastCleanup: removed}, nil
 }
 
+// applyRetentionPoliciesWithLock applies the retention policy on a repository whose backend locks the backed up data.
+// It evaluates the policy first without removing anything and refuses to proceed if the policy would remove any
+// snapshot that is still locked. The repository is pruned separately so that no locked data is repacked.
+func (w *ResticWrapper) applyRetentionPoliciesWithLock(retentionPolicy api_v1alpha1.RetentionPolicy, tags []string) (*RepositoryStats, error) {
+	dryRun := retentionPolicy
+	dryRun.DryRun = true
+	dryRun.Prune = false
+	out, err := w.RunWithRetry(context.Background(), func() ([]byte, error) {
+		return w.cleanup(dryRun, "", tags)
+	})
+	if err != nil {
+		return nil, err
+	}
+	fg, err := extractForgetGroups(out)
+	if err != nil {
+		return nil, err
+	}
+	if locked := lockedSnapshots(fg, *w.config.Immutability, time.Now()); len(locked) > 0 {
+		return nil, fmt.Errorf("refusing to apply retention policy %q: it would remove %d snapshot(s) locked for %s, i.e. %s",
+			retentionPolicy.Name, len(locked), w.config.Immutability.RetentionPeriod.Duration, strings.Join(locked, ", "))
+	}
+	if !retentionPolicy.DryRun {
+		forget := retentionPolicy
+		forget.Prune = false
+		out, err = w.RunWithRetry(context.Background(), func() ([]byte, error) {
+			return w.cleanup(forget, "", tags)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if retentionPolicy.Prune {
+			if _, err = w.Prune(PruneOptions{}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	kept, removed, err := extractCleanupInfo(out)
+	if err != nil {
+		return nil, err
+	}
+	return &RepositoryStats{SnapshotCount: kept, SnapshotsRemovedOnLastCleanup: removed}, nil
+}
+
+// lockedSnapshots returns the IDs of the snapshots that the forget groups would remove while they are still locked.
+func lockedSnapshots(groups []ForgetGroup, policy api_v1alpha1.ImmutabilityPolicy, now time.Time) []string {
+	var locked []string
+	for _, g := range groups {
+		for _, s := range g.Remove {
+			if policy.IsLocked(s.Time, now) {
+				locked = append(locked, s.ID)
+			}
+		}
+	}
+	return locked
+}
+
 func (w *ResticWrapper) VerifyRepositoryIntegrity() (*RepositoryStats, error) {
+	checkTime := metav1.Now()
 	// Check repository integrity
 	out, err := w.RunWithRetry(context.Background(), func() ([]byte, error) {
 		return w.check()
@@ -234,5 +307,37 @@ func (w *ResticWrapper) VerifyRepositoryIntegrity() (*RepositoryStats, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &RepositoryStats{Integrity: pointer.BoolP(integrity), Size: repoSize}, nil
+	compressionRatio, err := extractCompressionRatio(out)
+	if err != nil {
+		return nil, err
+	}
+	return &RepositoryStats{
+		Integrity:        pointer.BoolP(integrity),
+		Size:             repoSize,
+		CompressionRatio: compressionRatio,
+		LastCheckTime:    &checkTime,
+	}, nil
+}
+
+// GetHostStats returns the snapshot count, the latest snapshot and the size of each host of the repository.
+// It runs a "stats" command for each host, so it is kept separate from the integrity check.
+func (w *ResticWrapper) GetHostStats() ([]api_v1alpha1.RepositoryHostStatus, error) {
+	snapshots, err := w.ListSnapshots(nil)
+	if err != nil {
+		return nil, err
+	}
+	hosts := extractHostStats(snapshots)
+	for i := range hosts {
+		out, err := w.RunWithRetry(context.Background(), func() ([]byte, error) {
+			return w.hostStats(hosts[i].Hostname)
+		})
+		if err != nil {
+			return nil, err
+		}
+		hosts[i].Size, err = extractStatsInfo(out)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return hosts, nil
 }
@@ -50,6 +50,24 @@ type Snapshot struct {
 	UID      int       `json:"uid"`
 	Gid      int       `json:"gid"`
 	Tags     []string  `json:"tags"`
+	// Parent, ProgramVersion and Summary are available only for the snapshots created by newer versions of restic
+	Parent         string           `json:"parent,omitempty"`
+	ProgramVersion string           `json:"program_version,omitempty"`
+	Summary        *SnapshotSummary `json:"summary,omitempty"`
+}
+
+type SnapshotSummary struct {
+	BackupStart         time.Time `json:"backup_start"`
+	BackupEnd           time.Time `json:"backup_end"`
+	FilesNew            int64     `json:"files_new"`
+	FilesChanged        int64     `json:"files_changed"`
+	FilesUnmodified     int64     `json:"files_unmodified"`
+	DirsNew             int64     `json:"dirs_new"`
+	DirsChanged         int64     `json:"dirs_changed"`
+	DirsUnmodified      int64     `json:"dirs_unmodified"`
+	DataAdded           uint64    `json:"data_added"`
+	TotalFilesProcessed int64     `json:"total_files_processed"`
+	TotalBytesProcessed uint64    `json:"total_bytes_processed"`
 }
 
 type backupParams struct {
@@ -64,6 +82,7 @@ type restoreParams struct {
 	path        string
 	host        string
 	snapshotId  string
+	subfolder   string // restore only the content of this directory of the snapshot
 	destination string
 	excludes    []string
 	includes    []string
@@ -177,6 +196,10 @@ func (w *ResticWrapper) backupFromStdin(options BackupOptions) ([]byte, error) {
 		args = append(args, "--host")
 		args = append(args, options.Host)
 	}
+	for _, tag := range options.Tags {
+		args = append(args, "--tag")
+		args = append(args, tag)
+	}
 	args = w.appendCacheDirFlag(args)
 	args = w.appendCleanupCacheFlag(args)
 	args = w.appendCaCertFlag(args)
@@ -194,10 +217,10 @@ func (w *ResticWrapper) createLocalDir() error {
 	return nil
 }
 
-func (w *ResticWrapper) cleanup(retentionPolicy v1alpha1.RetentionPolicy, host string) ([]byte, error) {
+func (w *ResticWrapper) cleanup(retentionPolicy v1alpha1.RetentionPolicy, host string, tags []string) ([]byte, error) {
 	klog.Infoln("Cleaning old snapshots according to retention policy")
 
-	out, err := w.tryCleanup(retentionPolicy, host)
+	out, err := w.tryCleanup(retentionPolicy, host, tags)
 	if err == nil || !strings.Contains(err.Error(), "unlock") {
 		return out, err
 	}
@@ -206,72 +229,165 @@ func (w *ResticWrapper) cleanup(retentionPolicy v1alpha1.RetentionPolicy, host s
 	if o2, e2 := w.unlock(); e2 != nil {
 		return o2, e2
 	}
-	return w.tryCleanup(retentionPolicy, host)
+	return w.tryCleanup(retentionPolicy, host, tags)
 }
 
-func (w *ResticWrapper) tryCleanup(retentionPolicy v1alpha1.RetentionPolicy, host string) ([]byte, error) {
-	args := []any{"forget", "--quiet", "--json"}
+func (w *ResticWrapper) tryCleanup(retentionPolicy v1alpha1.RetentionPolicy, host string, tags []string) ([]byte, error) {
+	return w.forget(newForgetOptions(retentionPolicy, host, tags))
+}
 
+// newForgetOptions converts a retention policy into the options of "restic forget" command
+func newForgetOptions(retentionPolicy v1alpha1.RetentionPolicy, host string, tags []string) ForgetOptions {
+	opt := ForgetOptions{
+		KeepLast:    retentionPolicy.KeepLast,
+		KeepHourly:  retentionPolicy.KeepHourly,
+		KeepDaily:   retentionPolicy.KeepDaily,
+		KeepWeekly:  retentionPolicy.KeepWeekly,
+		KeepMonthly: retentionPolicy.KeepMonthly,
+		KeepYearly:  retentionPolicy.KeepYearly,
+		KeepTags:    retentionPolicy.KeepTags,
+		Prune:       retentionPolicy.Prune,
+		DryRun:      retentionPolicy.DryRun,
+	}
+	if retentionPolicy.KeepWithin != nil {
+		opt.KeepWithin = retentionPolicy.KeepWithin.Duration
+	}
+	if retentionPolicy.KeepWithinHourly != nil {
+		opt.KeepWithinHourly = retentionPolicy.KeepWithinHourly.Duration
+	}
+	if retentionPolicy.KeepWithinDaily != nil {
+		opt.KeepWithinDaily = retentionPolicy.KeepWithinDaily.Duration
+	}
+	if retentionPolicy.KeepWithinWeekly != nil {
+		opt.KeepWithinWeekly = retentionPolicy.KeepWithinWeekly.Duration
+	}
+	if retentionPolicy.KeepWithinMonthly != nil {
+		opt.KeepWithinMonthly = retentionPolicy.KeepWithinMonthly.Duration
+	}
+	if retentionPolicy.KeepWithinYearly != nil {
+		opt.KeepWithinYearly = retentionPolicy.KeepWithinYearly.Duration
+	}
 	if host != "" {
+		opt.Hosts = []string{host}
+	}
+	opt.Tags = tags
+	return opt
+}
+
+func (w *ResticWrapper) forget(opt ForgetOptions) ([]byte, error) {
+	args := forgetArgs(opt)
+	args = w.appendCacheDirFlag(args)
+	args = w.appendCaCertFlag(args)
+	args = w.appendInsecureTLSFlag(args)
+	args = w.appendMaxConnectionsFlag(args)
+
+	return w.run(Command{Name: ResticCMD, Args: args})
+}
+
+// forgetArgs generates the arguments of "restic forget" command from the options
+func forgetArgs(opt ForgetOptions) []any {
+	args := []any{"forget", "--quiet", "--json"}
+
+	for _, host := range opt.Hosts {
 		args = append(args, "--host")
 		args = append(args, host)
 	}
+	for _, path := range opt.Paths {
+		args = append(args, "--path")
+		args = append(args, path)
+	}
+	for _, tag := range opt.Tags {
+		args = append(args, "--tag")
+		args = append(args, tag)
+	}
+	if opt.GroupBy != "" {
+		args = append(args, "--group-by")
+		args = append(args, opt.GroupBy)
+	}
 
-	if retentionPolicy.KeepLast > 0 {
+	if opt.KeepLast > 0 {
 		args = append(args, string(v1alpha1.KeepLast))
-		args = append(args, strconv.FormatInt(retentionPolicy.KeepLast, 10))
+		args = append(args, strconv.FormatInt(opt.KeepLast, 10))
 	}
-	if retentionPolicy.KeepHourly > 0 {
+	if opt.KeepHourly > 0 {
 		args = append(args, string(v1alpha1.KeepHourly))
-		args = append(args, strconv.FormatInt(retentionPolicy.KeepHourly, 10))
+		args = append(args, strconv.FormatInt(opt.KeepHourly, 10))
 	}
-	if retentionPolicy.KeepDaily > 0 {
+	if opt.KeepDaily > 0 {
 		args = append(args, string(v1alpha1.KeepDaily))
-		args = append(args, strconv.FormatInt(retentionPolicy.KeepDaily, 10))
+		args = append(args, strconv.FormatInt(opt.KeepDaily, 10))
 	}
-	if retentionPolicy.KeepWeekly > 0 {
+	if opt.KeepWeekly > 0 {
 		args = append(args, string(v1alpha1.KeepWeekly))
-		args = append(args, strconv.FormatInt(retentionPolicy.KeepWeekly, 10))
+		args = append(args, strconv.FormatInt(opt.KeepWeekly, 10))
 	}
-	if retentionPolicy.KeepMonthly > 0 {
+	if opt.KeepMonthly > 0 {
 		args = append(args, string(v1alpha1.KeepMonthly))
-		args = append(args, strconv.FormatInt(retentionPolicy.KeepMonthly, 10))
+		args = append(args, strconv.FormatInt(opt.KeepMonthly, 10))
 	}
-	if retentionPolicy.KeepYearly > 0 {
+	if opt.KeepYearly > 0 {
 		args = append(args, string(v1alpha1.KeepYearly))
-		args = append(args, strconv.FormatInt(retentionPolicy.KeepYearly, 10))
+		args = append(args, strconv.FormatInt(opt.KeepYearly, 10))
+	}
+	if opt.KeepWithin > 0 {
+		args = append(args, string(v1alpha1.KeepWithin))
+		args = append(args, formatResticDuration(opt.KeepWithin))
+	}
+	if opt.KeepWithinHourly > 0 {
+		args = append(args, string(v1alpha1.KeepWithinHourly))
+		args = append(args, formatResticDuration(opt.KeepWithinHourly))
+	}
+	if opt.KeepWithinDaily > 0 {
+		args = append(args, string(v1alpha1.KeepWithinDaily))
+		args = append(args, formatResticDuration(opt.KeepWithinDaily))
+	}
+	if opt.KeepWithinWeekly > 0 {
+		args = append(args, string(v1alpha1.KeepWithinWeekly))
+		args = append(args, formatResticDuration(opt.KeepWithinWeekly))
 	}
-	for _, tag := range retentionPolicy.KeepTags {
+	if opt.KeepWithinMonthly > 0 {
+		args = append(args, string(v1alpha1.KeepWithinMonthly))
+		args = append(args, formatResticDuration(opt.KeepWithinMonthly))
+	}
+	if opt.KeepWithinYearly > 0 {
+		args = append(args, string(v1alpha1.KeepWithinYearly))
+		args = append(args, formatResticDuration(opt.KeepWithinYearly))
+	}
+	for _, tag := range opt.KeepTags {
 		args = append(args, string(v1alpha1.KeepTag))
 		args = append(args, tag)
 	}
-	if retentionPolicy.Prune {
+	if opt.Prune {
 		args = append(args, "--prune")
 	}
-	if retentionPolicy.DryRun {
+	if opt.DryRun {
 		args = append(args, "--dry-run")
 	}
+	return args
+}
 
-	if len(args) > 1 {
-		args = w.appendCacheDirFlag(args)
-		args = w.appendCaCertFlag(args)
-		args = w.appendInsecureTLSFlag(args)
-		args = w.appendMaxConnectionsFlag(args)
-
-		return w.run(Command{Name: ResticCMD, Args: args})
+// formatResticDuration converts a duration into the format accepted by restic (i.e. "36h").
+// The duration is rounded up to the hour as it is the smallest unit supported by restic.
+func formatResticDuration(d time.Duration) string {
+	hours := int64(d / time.Hour)
+	if d%time.Hour != 0 {
+		hours++
 	}
-	return nil, nil
+	return strconv.FormatInt(hours, 10) + "h"
 }
 
 func (w *ResticWrapper) restore(params restoreParams) ([]byte, error) {
 	klog.Infoln("Restoring backed up data")
 
 	args := []any{"restore"}
+	snapshot := "latest"
 	if params.snapshotId != "" {
-		args = append(args, params.snapshotId)
-	} else {
-		args = append(args, "latest")
+		snapshot = params.snapshotId
 	}
+	if params.subfolder != "" {
+		snapshot += ":" + params.subfolder
+	}
+	args = append(args, snapshot)
 	if params.path != "" {
 		args = append(args, "--path")
 		args = append(args, params.path) // source-path specified in restic fileGroup
@@ -313,6 +429,14 @@ func (w *ResticWrapper) DumpOnce(dumpOptions DumpOptions) ([]byte, error) {
 	klog.Infoln("Dumping backed up data")
 
 	args := []any{"dump", "--quiet"}
+	if dumpOptions.Snapshot == "" && !dumpOptions.SnapshotFilter.IsEmpty() {
+		snapshot, err := w.FindSnapshot(dumpOptions.SourceHost, dumpOptions.Path, dumpOptions.SnapshotFilter)
+		if err != nil {
+			return nil, err
+		}
+		klog.Infoln("Dumping snapshot", snapshot.ID, "taken at", snapshot.Time)
+		dumpOptions.Snapshot = snapshot.ID
+	}
 	if dumpOptions.Snapshot != "" {
 		args = append(args, dumpOptions.Snapshot)
 	} else {
@@ -356,12 +480,29 @@ func (w *ResticWrapper) check() ([]byte, error) {
 }
 
 func (w *ResticWrapper) stats(snapshotID string) ([]byte, error) {
+	return w.statsWithMode(snapshotID, "raw-data")
+}
+
+// statsWithMode runs "restic stats" in the given counting mode (i.e. "raw-data", "restore-size")
+func (w *ResticWrapper) statsWithMode(snapshotID, mode string) ([]byte, error) {
 	klog.Infoln("Reading repository status")
 	args := w.appendCacheDirFlag([]any{"stats"})
 	if snapshotID != "" {
 		args = append(args, snapshotID)
 	}
 	args = w.appendMaxConnectionsFlag(args)
+	args = append(args, "--quiet", "--json", "--mode", mode, "--no-lock")
+	args = w.appendCaCertFlag(args)
+	args = w.appendInsecureTLSFlag(args)
+
+	return w.run(Command{Name: ResticCMD, Args: args})
+}
+
+// hostStats returns the size of the data of all the snapshots of a host stored in the repository
+func (w *ResticWrapper) hostStats(host string) ([]byte, error) {
+	klog.Infoln("Reading repository status of host", host)
+	args := w.appendCacheDirFlag([]any{"stats", "--host", host})
+	args = w.appendMaxConnectionsFlag(args)
 	args = append(args, "--quiet", "--json", "--mode", "raw-data", "--no-lock")
 	args = w.appendCaCertFlag(args)
 	args = w.appendInsecureTLSFlag(args)
@@ -369,6 +510,16 @@ func (w *ResticWrapper) stats(snapshotID string) ([]byte, error) {
 	return w.run(Command{Name: ResticCMD, Args: args})
 }
 
+func (w *ResticWrapper) listFiles(snapshotID string) ([]byte, error) {
+	klog.Infoln("Listing files of snapshot", snapshotID)
+	args := w.appendCacheDirFlag([]any{"ls", snapshotID, "--json", "--quiet", "--no-lock"})
+	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendCaCertFlag(args)
+	args = w.appendInsecureTLSFlag(args)
+
+	return w.run(Command{Name: ResticCMD, Args: args})
+}
+
 func (w *ResticWrapper) unlock() ([]byte, error) {
 	klog.Infoln("Unlocking restic repository")
 	args := w.appendCacheDirFlag([]any{"unlock", "--remove-all"})
@@ -379,6 +530,17 @@ func (w *ResticWrapper) unlock() ([]byte, error) {
 	return w.run(Command{Name: ResticCMD, Args: args})
 }
 
+// unlockStale removes only the locks that restic considers stale
+func (w *ResticWrapper) unlockStale() ([]byte, error) {
+	klog.Infoln("Removing stale locks from restic repository")
+	args := w.appendCacheDirFlag([]any{"unlock"})
+	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendCaCertFlag(args)
+	args = w.appendInsecureTLSFlag(args)
+
+	return w.run(Command{Name: ResticCMD, Args: args})
+}
+
 func (w *ResticWrapper) migrateToV2() ([]byte, error) {
 	klog.Infoln("Migrating repository to v2")
 	args := w.appendCacheDirFlag([]any{"migrate", "upgrade_repo_v2"})
@@ -582,10 +744,13 @@ func (w *ResticWrapper) addKey(params keyParams) ([]byte, error) {
 	return w.run(Command{Name: ResticCMD, Args: args})
 }
 
-func (w *ResticWrapper) listKey() ([]byte, error) {
+func (w *ResticWrapper) listKey(jsonOutput bool) ([]byte, error) {
 	klog.Infoln("Listing restic keys")
 
 	args := []any{"key", "list", "--no-lock"}
+	if jsonOutput {
+		args = append(args, "--json", "--quiet")
+	}
 
 	args = w.appendCacheDirFlag(args)
 	args = w.appendMaxConnectionsFlag(args)
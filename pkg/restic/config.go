@@ -21,8 +21,10 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"time"
 
 	"stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+	"stash.appscode.dev/apimachinery/apis/stash/v1beta1"
 
 	shell "gomodules.xyz/go-sh"
 	core "k8s.io/api/core/v1"
@@ -56,6 +58,7 @@ type BackupOptions struct {
 	RetentionPolicy   v1alpha1.RetentionPolicy
 	Exclude           []string
 	Args              []string
+	Tags              []string // additional tags to add to the snapshots
 }
 
 // RestoreOptions specifies restore information
@@ -68,6 +71,10 @@ type RestoreOptions struct {
 	Exclude      []string
 	Include      []string
 	Args         []string
+	// SnapshotFilter selects the snapshot to restore for each of the RestorePaths when Snapshots are not specified
+	SnapshotFilter
+	// PathRewrites restores the RestorePaths into different locations
+	PathRewrites []v1beta1.PathRewrite
 }
 
 type DumpOptions struct {
@@ -77,6 +84,17 @@ type DumpOptions struct {
 	Path               string
 	FileName           string // default "stdin"
 	StdoutPipeCommands []Command
+	// SnapshotFilter selects the snapshot to dump when Snapshot is not specified
+	SnapshotFilter
+}
+
+// SnapshotFilter selects the latest snapshot that satisfies all the specified conditions.
+// The zero value selects the latest snapshot.
+type SnapshotFilter struct {
+	RestoreTime time.Time // select the snapshots taken at or before this time
+	Before      time.Time // select the snapshots taken before this time
+	After       time.Time // select the snapshots taken after this time
+	Tags        []string  // select the snapshots that have all of these tags
 }
 
 type PruneOptions struct {
@@ -88,6 +106,39 @@ type PruneOptions struct {
 	RepackCacheableOnly bool
 }
 
+// ForgetOptions specifies which snapshots should be removed by "restic forget" command.
+// The Keep* rules are applied to each group of snapshots individually.
+type ForgetOptions struct {
+	// GroupBy is a comma separated list of the snapshot fields used to group the snapshots.
+	// Allowed fields are "host", "paths" and "tags". Restic groups by "host,paths" if it is empty.
+	GroupBy string
+	// Hosts, Paths and Tags restrict the snapshots considered for removal.
+	// A snapshot is considered if it matches any of the given hosts, any of the given paths and any of the given tags.
+	Hosts []string
+	Paths []string
+	Tags  []string
+
+	KeepLast    int64
+	KeepHourly  int64
+	KeepDaily   int64
+	KeepWeekly  int64
+	KeepMonthly int64
+	KeepYearly  int64
+	// KeepWithin* keep the snapshots (of the respective interval) taken within the given duration of the latest snapshot.
+	// The durations are rounded up to the hour as restic does not support a smaller unit.
+	KeepWithin        time.Duration
+	KeepWithinHourly  time.Duration
+	KeepWithinDaily   time.Duration
+	KeepWithinWeekly  time.Duration
+	KeepWithinMonthly time.Duration
+	KeepWithinYearly  time.Duration
+	KeepTags          []string
+
+	Prune bool
+	// DryRun only reports which snapshots would be kept and removed
+	DryRun bool
+}
+
 type SetupOptions struct {
 	Provider       string
 	Bucket         string
@@ -102,6 +153,18 @@ type SetupOptions struct {
 	StorageSecret  *core.Secret
 	Nice           *ofst.NiceSettings
 	IONice         *ofst.IONiceSettings
+	// Immutability specifies the object lock settings of the backend.
+	// The snapshots that are younger than the lock period are never removed when it is set.
+	Immutability *v1alpha1.ImmutabilityPolicy
+}
+
+// SetImmutability sets the object lock settings of the backend from the given Repository.
+// It clears the settings if the Repository is not immutable.
+func (opt *SetupOptions) SetImmutability(repo *v1alpha1.Repository) {
+	opt.Immutability = nil
+	if repo.IsImmutable() {
+		opt.Immutability = repo.Spec.Immutability.DeepCopy()
+	}
 }
 
 type KeyOptions struct {
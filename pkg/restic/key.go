@@ -16,7 +16,20 @@ limitations under the License.
 
 package restic
 
-import "os"
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	core_util "kmodules.xyz/client-go/core/v1"
+)
 
 func (w *ResticWrapper) AddKey(opt KeyOptions) error {
 	params := keyParams{
@@ -29,7 +42,7 @@ func (w *ResticWrapper) AddKey(opt KeyOptions) error {
 }
 
 func (w *ResticWrapper) ListKey() error {
-	out, err := w.listKey()
+	out, err := w.listKey(false)
 	if err != nil {
 		return err
 	}
@@ -39,6 +52,15 @@ func (w *ResticWrapper) ListKey() error {
 	return err
 }
 
+// ListKeys returns the keys of the repository. The key used to open the repository is marked as current.
+func (w *ResticWrapper) ListKeys() ([]Key, error) {
+	out, err := w.listKey(true)
+	if err != nil {
+		return nil, err
+	}
+	return extractKeys(out)
+}
+
 func (w *ResticWrapper) UpdateKey(opt KeyOptions) error {
 	params := keyParams{
 		file: opt.File,
@@ -54,3 +76,144 @@ func (w *ResticWrapper) RemoveKey(opt KeyOptions) error {
 	_, err := w.removeKey(params)
 	return err
 }
+
+// RotatePassword replaces the current repository password with the RESTIC_PASSWORD of newSecret.
+// It adds a new key with the new password, verifies that the new key can open the repository,
+// updates the storage Secret and finally removes the old key. If any of the steps fails, the changes
+// made by the previous steps are rolled back so that the repository remains accessible with the old password.
+// On success, the wrapper starts using the new password.
+func (w *ResticWrapper) RotatePassword(kubeClient kubernetes.Interface, newSecret *core.Secret) error {
+	oldSecret := w.config.StorageSecret
+	if newSecret == nil {
+		return errors.New("missing new storage Secret")
+	}
+	newPassword, ok := newSecret.Data[RESTIC_PASSWORD]
+	if !ok || len(newPassword) == 0 {
+		return fmt.Errorf("new storage Secret missing %s key", RESTIC_PASSWORD)
+	}
+	if bytes.Equal(newPassword, oldSecret.Data[RESTIC_PASSWORD]) {
+		return errors.New("new password is same as the current password")
+	}
+
+	keys, err := w.ListKeys()
+	if err != nil {
+		return err
+	}
+	oldKey := currentKey(keys)
+	if oldKey == nil {
+		return errors.New("no key found that matches the current password")
+	}
+
+	// add the new password as a new key
+	passwordFile, err := os.CreateTemp(w.config.ScratchDir, "new-password-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.Remove(passwordFile.Name()); err != nil {
+			klog.Errorln("failed to remove new password file:", err)
+		}
+	}()
+	_, err = passwordFile.Write(newPassword)
+	if cerr := passwordFile.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if err := w.AddKey(KeyOptions{File: passwordFile.Name()}); err != nil {
+		return fmt.Errorf("failed to add new key: %w", err)
+	}
+
+	// verify that the new key can open the repository
+	nw := w.Copy()
+	nw.config.StorageSecret = newSecret
+	nw.SetEnv(RESTIC_PASSWORD, string(newPassword))
+	newKeys, err := nw.ListKeys()
+	if err != nil {
+		return w.rollbackKeyRotation(fmt.Errorf("failed to open repository with new key: %w", err), nil, w.addedKeyID(keys))
+	}
+	newKey := currentKey(newKeys)
+	if newKey == nil || newKey.ID == oldKey.ID {
+		return w.rollbackKeyRotation(errors.New("new key was not found in the repository"), nil, w.addedKeyID(keys))
+	}
+
+	// update the storage Secret with the new password
+	klog.Infoln("Updating storage Secret", oldSecret.Namespace+"/"+oldSecret.Name, "with the new password")
+	updatedSecret, _, err := core_util.PatchSecret(context.TODO(), kubeClient, oldSecret, func(in *core.Secret) *core.Secret {
+		return setSecretPassword(in, newPassword)
+	}, metav1.PatchOptions{})
+	if err != nil {
+		return w.rollbackKeyRotation(fmt.Errorf("failed to update storage Secret: %w", err), nil, newKey.ID)
+	}
+
+	// remove the old key using the new key as restic does not allow removing the key currently in use
+	if err := nw.RemoveKey(KeyOptions{ID: oldKey.ID}); err != nil {
+		return w.rollbackKeyRotation(fmt.Errorf("failed to remove old key: %w", err), func() error {
+			_, _, err := core_util.PatchSecret(context.TODO(), kubeClient, updatedSecret, func(in *core.Secret) *core.Secret {
+				return setSecretPassword(in, oldSecret.Data[RESTIC_PASSWORD])
+			}, metav1.PatchOptions{})
+			return err
+		}, newKey.ID)
+	}
+
+	// start using the new password
+	w.config.StorageSecret = updatedSecret
+	w.SetEnv(RESTIC_PASSWORD, string(newPassword))
+	return nil
+}
+
+// setSecretPassword updates only the RESTIC_PASSWORD of the storage Secret so that the backend credentials are kept intact
+func setSecretPassword(in *core.Secret, password []byte) *core.Secret {
+	if in.Data == nil {
+		in.Data = map[string][]byte{}
+	}
+	in.Data[RESTIC_PASSWORD] = password
+	return in
+}
+
+// currentKey returns the key that has been used to open the repository
+func currentKey(keys []Key) *Key {
+	for i := range keys {
+		if keys[i].Current {
+			return &keys[i]
+		}
+	}
+	return nil
+}
+
+// addedKeyID returns the ID of the key that exists in the repository now but was not in the old key list
+func (w *ResticWrapper) addedKeyID(oldKeys []Key) string {
+	keys, err := w.ListKeys()
+	if err != nil {
+		klog.Errorln("failed to list keys:", err)
+		return ""
+	}
+	existing := make(map[string]bool, len(oldKeys))
+	for _, k := range oldKeys {
+		existing[k.ID] = true
+	}
+	for _, k := range keys {
+		if !existing[k.ID] {
+			return k.ID
+		}
+	}
+	return ""
+}
+
+// rollbackKeyRotation reverts the storage Secret using revertSecret (if provided) and removes the newly added key.
+func (w *ResticWrapper) rollbackKeyRotation(cause error, revertSecret func() error, newKeyID string) error {
+	klog.Warningln("Rolling back password rotation. Reason:", cause)
+	errs := []error{cause}
+	if revertSecret != nil {
+		if err := revertSecret(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to revert storage Secret: %w", err))
+		}
+	}
+	if newKeyID == "" {
+		errs = append(errs, errors.New("failed to identify the new key, remove it manually"))
+	} else if err := w.RemoveKey(KeyOptions{ID: newKeyID}); err != nil {
+		errs = append(errs, fmt.Errorf("failed to remove new key %s: %w", newKeyID, err))
+	}
+	return utilerrors.NewAggregate(errs)
+}
@@ -16,7 +16,18 @@ limitations under the License.
 
 package restic
 
+import "fmt"
+
+// Prune removes the unreferenced data from the repository. If the backend locks the backed up data,
+// only the completely unused pack files are removed as repacking would rewrite the locked data.
 func (w *ResticWrapper) Prune(pruneOpts PruneOptions) ([]byte, error) {
+	if w.config.Immutability != nil {
+		if pruneOpts.RepackSmall || pruneOpts.RepackUncompressed {
+			return nil, fmt.Errorf("repacking is not allowed for a repository with immutability policy")
+		}
+		pruneOpts.MaxUnusedLimit = "unlimited"
+		pruneOpts.MaxRepackSize = ""
+	}
 	return w.prune(pruneOpts)
 }
 
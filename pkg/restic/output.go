@@ -25,10 +25,15 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	api_v1alpha1 "stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
 	api_v1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const FileModeRWXAll = 0o777
@@ -47,6 +52,43 @@ type RepositoryStats struct {
 	SnapshotCount int64 `json:"snapshotCount,omitempty"`
 	// SnapshotsRemovedOnLastCleanup shows number of old snapshots cleaned up according to retention policy on last backup session
 	SnapshotsRemovedOnLastCleanup int64 `json:"snapshotsRemovedOnLastCleanup,omitempty"`
+	// CompressionRatio shows the ratio of the uncompressed data size to the size of the data stored in the repository
+	CompressionRatio string `json:"compressionRatio,omitempty"`
+	// LastCheckTime indicates the timestamp when the repository integrity was checked
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+	// Hosts shows the statistics of the individual hosts of the repository. It is collected by GetHostStats.
+	// The targets of the hosts are not known to restic, so they are left for the caller to set.
+	Hosts []api_v1alpha1.RepositoryHostStatus `json:"hosts,omitempty"`
+}
+
+// EvaluateRepositoryQuota evaluates the quota and the growth alert of a Repository against the statistics collected in a
+// backup session and sets the respective conditions of the Repository. The size and the snapshot count of the Repository
+// status are used as the previous usage and also for the statistics that were not collected in the session.
+// It returns true if the quota has been exceeded.
+func EvaluateRepositoryQuota(repo *api_v1alpha1.Repository, stats RepositoryStats) (bool, error) {
+	var previousSize uint64
+	if repo.Status.TotalSize != "" {
+		size, err := parseBytes(repo.Status.TotalSize)
+		if err != nil {
+			return false, err
+		}
+		previousSize = size
+	}
+	usage := api_v1alpha1.RepositoryUsage{
+		Size:          int64(previousSize),
+		SnapshotCount: repo.Status.SnapshotCount,
+	}
+	if stats.Size != "" {
+		size, err := parseBytes(stats.Size)
+		if err != nil {
+			return false, err
+		}
+		usage.Size = int64(size)
+	}
+	if stats.SnapshotCount != 0 {
+		usage.SnapshotCount = stats.SnapshotCount
+	}
+	return repo.EvaluateQuota(usage, int64(previousSize)), nil
 }
 
 type RestoreOutput struct {
@@ -191,13 +233,7 @@ func extractCheckInfo(out []byte) bool {
 // ExtractCleanupInfo extract information from output of "restic forget" command and
 // save valuable information into backupOutput
 func extractCleanupInfo(out []byte) (int64, int64, error) {
-	var fg []ForgetGroup
-	// The output can have some warning message along with a array of json. Here, we are going to extract the json part.
-	// The json part start with "[{" and ends with "}]". We are going to use use regular expression to take the first section
-	// that start with "[{" and end with "}]".
-	regex := regexp.MustCompile(`\[{.*}]`)
-	jsonPart := regex.Find(out)
-	err := json.Unmarshal(jsonPart, &fg)
+	fg, err := extractForgetGroups(out)
 	if err != nil {
 		return 0, 0, err
 	}
@@ -212,6 +248,25 @@ func extractCleanupInfo(out []byte) (int64, int64, error) {
 	return keep, removed, nil
 }
 
+// extractForgetGroups extract the snapshot groups from the output of "restic forget --json" command
+func extractForgetGroups(out []byte) ([]ForgetGroup, error) {
+	var fg []ForgetGroup
+	// The output can have some warning message along with a array of json. Here, we are going to extract the json part.
+	// The json part start with "[{" and ends with "}]". We are going to use use regular expression to take the first section
+	// that start with "[{" and end with "}]". If there is no such section (i.e. the output is empty or "[]"),
+	// there is no snapshot group to report.
+	regex := regexp.MustCompile(`\[{.*}]`)
+	jsonPart := regex.Find(out)
+	if jsonPart == nil {
+		return nil, nil
+	}
+	err := json.Unmarshal(jsonPart, &fg)
+	if err != nil {
+		return nil, err
+	}
+	return fg, nil
+}
+
 // ExtractStatsInfo extract information from output of "restic stats" command and
 // save valuable information into backupOutput
 func extractStatsInfo(out []byte) (string, error) {
@@ -223,6 +278,45 @@ func extractStatsInfo(out []byte) (string, error) {
 	return formatBytes(stat.TotalSize), nil
 }
 
+// extractCompressionRatio extract the compression ratio from output of "restic stats --mode raw-data" command.
+// The ratio is empty for the repositories that does not support compression.
+func extractCompressionRatio(out []byte) (string, error) {
+	var stat StatsContainer
+	err := json.Unmarshal(out, &stat)
+	if err != nil {
+		return "", err
+	}
+	if stat.CompressionRatio <= 0 {
+		return "", nil
+	}
+	return strconv.FormatFloat(stat.CompressionRatio, 'f', 2, 64), nil
+}
+
+// extractHostStats groups the snapshots by their host and returns the statistics of each host sorted by hostname.
+// The last snapshot of a host is considered as its last successful backup.
+func extractHostStats(snapshots []Snapshot) []api_v1alpha1.RepositoryHostStatus {
+	var hosts []api_v1alpha1.RepositoryHostStatus
+	index := make(map[string]int)
+	for _, snapshot := range snapshots {
+		i, ok := index[snapshot.Hostname]
+		if !ok {
+			hosts = append(hosts, api_v1alpha1.RepositoryHostStatus{Hostname: snapshot.Hostname})
+			i = len(hosts) - 1
+			index[snapshot.Hostname] = i
+		}
+		hosts[i].SnapshotCount++
+		if hosts[i].LastSnapshotTime == nil || snapshot.Time.After(hosts[i].LastSnapshotTime.Time) {
+			hosts[i].LastSnapshot = snapshot.ID
+			hosts[i].LastSnapshotTime = &metav1.Time{Time: snapshot.Time}
+			hosts[i].LastSuccessTime = hosts[i].LastSnapshotTime
+		}
+	}
+	sort.Slice(hosts, func(i, j int) bool {
+		return hosts[i].Hostname < hosts[j].Hostname
+	})
+	return hosts
+}
+
 type BackupSummary struct {
 	MessageType         string  `json:"message_type"` // "summary"
 	FilesNew            *int64  `json:"files_new"`
@@ -235,16 +329,48 @@ type BackupSummary struct {
 	SnapshotID          string  `json:"snapshot_id"`
 }
 
+// ForgetGroup represents a group of snapshots in the output of "restic forget --json" command
 type ForgetGroup struct {
-	Keep   []json.RawMessage `json:"keep"`
-	Remove []json.RawMessage `json:"remove"`
+	Tags    []string     `json:"tags"`
+	Host    string       `json:"host"`
+	Paths   []string     `json:"paths"`
+	Keep    []Snapshot   `json:"keep"`
+	Remove  []Snapshot   `json:"remove"`
+	Reasons []KeepReason `json:"reasons"`
+}
+
+// KeepReason shows why a snapshot has been kept
+type KeepReason struct {
+	Snapshot Snapshot `json:"snapshot"`
+	// Matches lists the retention rules that matched the snapshot i.e. "last snapshot", "daily snapshot", "within 1d" etc.
+	Matches []string `json:"matches"`
+}
+
+// KeepReasons returns the retention rules that kept the given snapshot of this group
+func (g ForgetGroup) KeepReasons(snapshotID string) []string {
+	for _, r := range g.Reasons {
+		if r.Snapshot.ID == snapshotID {
+			return r.Matches
+		}
+	}
+	return nil
 }
 
 type StatsContainer struct {
-	TotalSize uint64 `json:"total_size"`
+	TotalSize        uint64  `json:"total_size"`
+	CompressionRatio float64 `json:"compression_ratio,omitempty"`
+}
+
+type Key struct {
+	ID       string `json:"id"`
+	Current  bool   `json:"current"` // true if the key has been used to open the repository
+	UserName string `json:"userName"`
+	HostName string `json:"hostName"`
+	Created  string `json:"created"`
 }
 
 type LockStats struct {
+	ID        string    `json:"id,omitempty"` // ID of the lock file, not part of the "restic cat lock" output
 	Time      time.Time `json:"time"`
 	Exclusive bool      `json:"exclusive"` // true if the lock is exclusive, false if it is non-exclusive
 	Hostname  string    `json:"hostname"`  // Hostname of the machine where the lock was created, our case PodName
@@ -254,6 +380,77 @@ type LockStats struct {
 	GID       int       `json:"gid"`
 }
 
+// SnapshotNode represents a single entry of the "restic ls --json" output
+type SnapshotNode struct {
+	StructType string      `json:"struct_type"` // "node"
+	Name       string      `json:"name"`
+	Type       string      `json:"type"` // "dir", "file", "symlink" etc.
+	Path       string      `json:"path"`
+	UID        int         `json:"uid"`
+	GID        int         `json:"gid"`
+	Size       uint64      `json:"size"`
+	Mode       os.FileMode `json:"mode"`
+	ModTime    time.Time   `json:"mtime"`
+	AccessTime time.Time   `json:"atime"`
+	ChangeTime time.Time   `json:"ctime"`
+}
+
+// extractSnapshotFiles extract the snapshot information and the file nodes from the output of "restic ls --json" command.
+// The first json object of the output describes the snapshot and the rest of them describes the files.
+func extractSnapshotFiles(out []byte) (*Snapshot, []SnapshotNode, error) {
+	var (
+		snapshot *Snapshot
+		nodes    []SnapshotNode
+	)
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for {
+		var raw json.RawMessage
+		err := dec.Decode(&raw)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var kind struct {
+			StructType  string `json:"struct_type"`
+			MessageType string `json:"message_type"`
+		}
+		if err := json.Unmarshal(raw, &kind); err != nil {
+			return nil, nil, err
+		}
+		if kind.StructType == "snapshot" || kind.MessageType == "snapshot" {
+			snapshot = &Snapshot{}
+			if err := json.Unmarshal(raw, snapshot); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		var node SnapshotNode
+		if err := json.Unmarshal(raw, &node); err != nil {
+			return nil, nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	if snapshot == nil {
+		return nil, nil, fmt.Errorf("snapshot information not found in the output")
+	}
+	return snapshot, nodes, nil
+}
+
+// extractKeys extract the key list from the output of "restic key list --json" command
+func extractKeys(out []byte) ([]Key, error) {
+	var keys []Key
+	// The output can have some warning message along with the json array. So, take only the json array.
+	regex := regexp.MustCompile(`(?s)\[.*]`)
+	if err := json.Unmarshal(regex.Find(out), &keys); err != nil {
+		return nil, fmt.Errorf("cannot decode key list JSON: %w", err)
+	}
+	return keys, nil
+}
+
 func extractLockStats(raw []byte) (*LockStats, error) {
 	var stats LockStats
 	if err := json.Unmarshal(raw, &stats); err != nil {
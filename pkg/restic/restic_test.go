@@ -17,10 +17,15 @@ limitations under the License.
 package restic
 
 import (
+	"context"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	api_v1alpha1 "stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
 	api_v1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
@@ -28,6 +33,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"gomodules.xyz/pointer"
 	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/klog/v2"
 	storage "kmodules.xyz/objectstore-api/api/v1"
 	ofst "kmodules.xyz/offshoot-api/api/v1"
@@ -844,6 +851,69 @@ func TestApplyRetentionPolicy(t *testing.T) {
 	assert.Equal(t, int64(1), repoStats.SnapshotsRemovedOnLastCleanup)
 }
 
+func TestApplyRetentionPolicyWithImmutability(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "stash-unit-test-")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	w, err := setupTest(tempDir)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer cleanup(tempDir)
+	// simulate a backend that locks the backed up data for a day
+	w.config.Immutability = &api_v1alpha1.ImmutabilityPolicy{
+		Mode:            api_v1alpha1.ImmutabilityModeCompliance,
+		RetentionPeriod: metav1.Duration{Duration: 24 * time.Hour},
+	}
+
+	// Initialize Repository
+	err = w.InitializeRepository()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	backupOpt := BackupOptions{
+		BackupPaths: []string{targetPath},
+	}
+	// take two backup
+	_, err = w.RunBackup(backupOpt, testTargetRef)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_, err = w.RunBackup(backupOpt, testTargetRef)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	// the policy would remove a locked snapshot
+	_, err = w.ApplyRetentionPolicies(api_v1alpha1.RetentionPolicy{
+		Name:     "keep-last-1",
+		KeepLast: 1,
+		Prune:    true,
+	})
+	assert.Error(t, err)
+
+	// the policy keeps every locked snapshot
+	repoStats, err := w.ApplyRetentionPolicies(api_v1alpha1.RetentionPolicy{
+		Name:       "keep-last-1-within-1d",
+		KeepLast:   1,
+		KeepWithin: &metav1.Duration{Duration: 24 * time.Hour},
+		Prune:      true,
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, int64(2), repoStats.SnapshotCount)
+	assert.Equal(t, int64(0), repoStats.SnapshotsRemovedOnLastCleanup)
+}
+
 func TestVerifyRepositoryIntegrity(t *testing.T) {
 	tempDir, err := os.MkdirTemp("", "stash-unit-test-")
 	if err != nil {
@@ -894,6 +964,530 @@ func TestVerifyRepositoryIntegrity(t *testing.T) {
 	assert.Equal(t, true, *repoStats.Integrity)
 }
 
+func TestSnapshotFS(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "stash-unit-test-")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	w, err := setupTest(tempDir)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer cleanup(tempDir)
+
+	// Initialize Repository
+	err = w.InitializeRepository()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	backupOpt := BackupOptions{
+		BackupPaths: []string{targetPath},
+	}
+	backupOut, err := w.RunBackup(backupOpt, testTargetRef)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	snapshotID := backupOut.BackupTargetStatus.Stats[0].Snapshots[0].Name
+
+	sfs, err := w.SnapshotFS(snapshotID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, snapshotID, sfs.Snapshot().ID)
+
+	filePath := strings.TrimPrefix(filepath.Join(targetPath, fileName), "/")
+	if err = fstest.TestFS(sfs, filePath); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var files []string
+	err = fs.WalkDir(sfs, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, []string{filePath}, files)
+
+	data, err := fs.ReadFile(sfs, filePath)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, fileContent, string(data))
+}
+
+func TestRotatePassword(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "stash-unit-test-")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	w, err := setupTest(tempDir)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer cleanup(tempDir)
+
+	// Initialize Repository
+	err = w.InitializeRepository()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	oldKeys, err := w.ListKeys()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// use a named copy of the storage Secret so that the shared fixture is not modified
+	secret := storageSecret.DeepCopy()
+	secret.Name = "storage-secret"
+	secret.Namespace = "demo"
+	w.config.StorageSecret = secret
+	kubeClient := fake.NewClientset(secret)
+	newSecret := secret.DeepCopy()
+	newSecret.Data = map[string][]byte{
+		RESTIC_PASSWORD: []byte("new-password"),
+	}
+	err = w.RotatePassword(kubeClient, newSecret)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// the storage Secret should have the new password
+	secret, err = kubeClient.CoreV1().Secrets(secret.Namespace).Get(context.TODO(), secret.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, "new-password", string(secret.Data[RESTIC_PASSWORD]))
+
+	// the old key should be removed and the wrapper should use the new key
+	keys, err := w.ListKeys()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, 1, len(keys))
+	assert.NotEqual(t, oldKeys[0].ID, keys[0].ID)
+	assert.True(t, keys[0].Current)
+}
+
+func TestSetSecretPassword(t *testing.T) {
+	secret := &core.Secret{
+		Data: map[string][]byte{
+			RESTIC_PASSWORD:         []byte("old-password"),
+			"AWS_ACCESS_KEY_ID":     []byte("access-key"),
+			"AWS_SECRET_ACCESS_KEY": []byte("secret-key"),
+		},
+	}
+	secret = setSecretPassword(secret, []byte("new-password"))
+	assert.Equal(t, "new-password", string(secret.Data[RESTIC_PASSWORD]))
+	assert.Equal(t, "access-key", string(secret.Data["AWS_ACCESS_KEY_ID"]))
+	assert.Equal(t, "secret-key", string(secret.Data["AWS_SECRET_ACCESS_KEY"]))
+
+	secret = setSecretPassword(&core.Secret{}, []byte("new-password"))
+	assert.Equal(t, "new-password", string(secret.Data[RESTIC_PASSWORD]))
+}
+
+func TestIsStaleLock(t *testing.T) {
+	kubeClient := fake.NewClientset(
+		&core.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "running-pod", Namespace: "demo"},
+			Spec:       core.PodSpec{NodeName: "node-1"},
+			Status:     core.PodStatus{Phase: core.PodRunning},
+		},
+		&core.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "completed-pod", Namespace: "demo"},
+			Spec:       core.PodSpec{NodeName: "node-1"},
+			Status:     core.PodStatus{Phase: core.PodSucceeded},
+		},
+		&core.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "orphan-pod", Namespace: "demo"},
+			Spec:       core.PodSpec{NodeName: "deleted-node"},
+			Status:     core.PodStatus{Phase: core.PodRunning},
+		},
+		&core.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		},
+	)
+
+	testCases := []struct {
+		name     string
+		lock     LockStats
+		policy   StaleLockPolicy
+		expected bool
+	}{
+		{
+			name:     "lock held by running pod",
+			lock:     LockStats{Hostname: "running-pod", Time: time.Now()},
+			policy:   StaleLockPolicy{PodGone: true, NodeGone: true},
+			expected: false,
+		},
+		{
+			name:     "lock held by deleted pod",
+			lock:     LockStats{Hostname: "deleted-pod", Time: time.Now()},
+			policy:   StaleLockPolicy{PodGone: true},
+			expected: true,
+		},
+		{
+			name:     "lock held by completed pod",
+			lock:     LockStats{Hostname: "completed-pod", Time: time.Now()},
+			policy:   StaleLockPolicy{PodGone: true},
+			expected: true,
+		},
+		{
+			name:     "lock held by pod of deleted node",
+			lock:     LockStats{Hostname: "orphan-pod", Time: time.Now()},
+			policy:   StaleLockPolicy{NodeGone: true},
+			expected: true,
+		},
+		{
+			name:     "lock held by pod of deleted node without node check",
+			lock:     LockStats{Hostname: "orphan-pod", Time: time.Now()},
+			policy:   StaleLockPolicy{PodGone: true},
+			expected: false,
+		},
+		{
+			name:     "old lock held by running pod",
+			lock:     LockStats{Hostname: "running-pod", Time: time.Now().Add(-time.Hour)},
+			policy:   StaleLockPolicy{MaxAge: 30 * time.Minute},
+			expected: true,
+		},
+		{
+			name:     "all checks disabled",
+			lock:     LockStats{Hostname: "deleted-pod", Time: time.Now().Add(-time.Hour)},
+			policy:   StaleLockPolicy{},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			staleness, err := isStaleLock(kubeClient, "demo", tc.lock, tc.policy)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			assert.Equal(t, tc.expected, staleness.stale)
+		})
+	}
+}
+
+func TestRemoveLocksFromRemoteBackend(t *testing.T) {
+	w := &ResticWrapper{config: SetupOptions{Provider: storage.ProviderS3}}
+
+	err := w.validateStaleLockPolicy(StaleLockPolicy{MaxAge: 10 * time.Minute})
+	assert.Error(t, err)
+	assert.NoError(t, w.validateStaleLockPolicy(StaleLockPolicy{MaxAge: time.Hour}))
+	assert.NoError(t, w.validateStaleLockPolicy(StaleLockPolicy{PodGone: true}))
+
+	// the young locks must be reported instead of removing all the locks
+	err = w.removeLocks([]LockStats{
+		{ID: "young-lock", Time: time.Now()},
+		{ID: "old-lock", Time: time.Now().Add(-time.Hour)},
+	})
+	assert.ErrorContains(t, err, "young-lock")
+	assert.NotContains(t, err.Error(), "old-lock")
+
+	local := &ResticWrapper{config: SetupOptions{Provider: storage.ProviderLocal}}
+	assert.NoError(t, local.validateStaleLockPolicy(StaleLockPolicy{MaxAge: 10 * time.Minute}))
+	assert.True(t, local.lockRemovable(LockStats{ID: "young-lock", Time: time.Now()}))
+}
+
+func TestExtractForgetGroups(t *testing.T) {
+	out := []byte(`repository 1b2d3f4e opened (version 2, compression level auto)
+[{"tags":null,"host":"host-0","paths":["/source/data"],"keep":[{"time":"2024-01-02T00:00:00Z","tree":"t2","paths":["/source/data"],"hostname":"host-0","id":"s2"}],"remove":[{"time":"2024-01-01T00:00:00Z","tree":"t1","paths":["/source/data"],"hostname":"host-0","id":"s1"}],"reasons":[{"snapshot":{"time":"2024-01-02T00:00:00Z","tree":"t2","paths":["/source/data"],"hostname":"host-0","id":"s2"},"matches":["last snapshot","within 1d"],"counters":{"last":0}}]}]`)
+
+	groups, err := extractForgetGroups(out)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, 1, len(groups))
+	assert.Equal(t, "host-0", groups[0].Host)
+	assert.Equal(t, "s2", groups[0].Keep[0].ID)
+	assert.Equal(t, "s1", groups[0].Remove[0].ID)
+	assert.Equal(t, []string{"last snapshot", "within 1d"}, groups[0].KeepReasons("s2"))
+	assert.Nil(t, groups[0].KeepReasons("s1"))
+
+	kept, removed, err := extractCleanupInfo(out)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, int64(1), kept)
+	assert.Equal(t, int64(1), removed)
+}
+
+func TestExtractForgetGroupsEmpty(t *testing.T) {
+	testCases := []struct {
+		name string
+		out  []byte
+	}{
+		{name: "empty output", out: nil},
+		{name: "empty array", out: []byte(`[]`)},
+		{name: "empty array with warning", out: []byte("repository 1b2d3f4e opened (version 2, compression level auto)\n[]\n")},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			groups, err := extractForgetGroups(tc.out)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			assert.Empty(t, groups)
+
+			kept, removed, err := extractCleanupInfo(tc.out)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			assert.Equal(t, int64(0), kept)
+			assert.Equal(t, int64(0), removed)
+		})
+	}
+}
+
+func TestForgetArgsForKeepWithin(t *testing.T) {
+	duration := func(d time.Duration) *metav1.Duration {
+		return &metav1.Duration{Duration: d}
+	}
+
+	testCases := []struct {
+		name     string
+		policy   api_v1alpha1.RetentionPolicy
+		expected []any
+	}{
+		{
+			name:     "no keep-within rule",
+			policy:   api_v1alpha1.RetentionPolicy{KeepLast: 5},
+			expected: nil,
+		},
+		{
+			name:     "keep-within",
+			policy:   api_v1alpha1.RetentionPolicy{KeepWithin: duration(36 * time.Hour)},
+			expected: []any{"--keep-within", "36h"},
+		},
+		{
+			name: "all keep-within rules",
+			policy: api_v1alpha1.RetentionPolicy{
+				KeepWithin:        duration(2 * time.Hour),
+				KeepWithinHourly:  duration(24 * time.Hour),
+				KeepWithinDaily:   duration(7 * 24 * time.Hour),
+				KeepWithinWeekly:  duration(30 * 24 * time.Hour),
+				KeepWithinMonthly: duration(365 * 24 * time.Hour),
+				KeepWithinYearly:  duration(2 * 365 * 24 * time.Hour),
+			},
+			expected: []any{
+				"--keep-within", "2h",
+				"--keep-within-hourly", "24h",
+				"--keep-within-daily", "168h",
+				"--keep-within-weekly", "720h",
+				"--keep-within-monthly", "8760h",
+				"--keep-within-yearly", "17520h",
+			},
+		},
+		{
+			name:     "keep-within rounded up to the hour",
+			policy:   api_v1alpha1.RetentionPolicy{KeepWithinDaily: duration(90 * time.Minute)},
+			expected: []any{"--keep-within-daily", "2h"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := forgetArgs(newForgetOptions(tc.policy, "", nil))
+			var keepWithin []any
+			for i := 0; i < len(args); i++ {
+				if flag, ok := args[i].(string); ok && strings.HasPrefix(flag, "--keep-within") {
+					keepWithin = append(keepWithin, flag, args[i+1])
+					i++
+				}
+			}
+			assert.Equal(t, tc.expected, keepWithin)
+		})
+	}
+}
+
+func TestFormatResticDuration(t *testing.T) {
+	assert.Equal(t, "1h", formatResticDuration(time.Hour))
+	assert.Equal(t, "2h", formatResticDuration(90*time.Minute))
+	assert.Equal(t, "720h", formatResticDuration(30*24*time.Hour))
+}
+
+func TestSelectSnapshot(t *testing.T) {
+	at := func(day int) time.Time {
+		return time.Date(2024, time.March, day, 0, 0, 0, 0, time.UTC)
+	}
+	snapshots := []Snapshot{
+		{ID: "s1", Time: at(1), Hostname: "host-0", Paths: []string{targetPath}, Tags: []string{"daily"}},
+		{ID: "s2", Time: at(2), Hostname: "host-0", Paths: []string{targetPath}, Tags: []string{"daily", "pre-upgrade"}},
+		{ID: "s3", Time: at(3), Hostname: "host-0", Paths: []string{targetPath}, Tags: []string{"daily"}},
+		{ID: "s4", Time: at(4), Hostname: "host-1", Paths: []string{targetPath}, Tags: []string{"daily"}},
+	}
+
+	assert.Equal(t, "s3", selectSnapshot(snapshots, "host-0", targetPath, SnapshotFilter{}).ID)
+	assert.Equal(t, "s2", selectSnapshot(snapshots, "host-0", targetPath, SnapshotFilter{RestoreTime: at(2)}).ID)
+	assert.Equal(t, "s1", selectSnapshot(snapshots, "host-0", targetPath, SnapshotFilter{Before: at(2)}).ID)
+	assert.Equal(t, "s2", selectSnapshot(snapshots, "host-0", "", SnapshotFilter{Tags: []string{"pre-upgrade"}}).ID)
+	assert.Equal(t, "s4", selectSnapshot(snapshots, "", targetPath, SnapshotFilter{After: at(2)}).ID)
+	assert.Nil(t, selectSnapshot(snapshots, "host-0", targetPath, SnapshotFilter{After: at(3)}))
+}
+
+func TestSelectSnapshotsOfHost(t *testing.T) {
+	at := func(day int) time.Time {
+		return time.Date(2024, time.March, day, 0, 0, 0, 0, time.UTC)
+	}
+	snapshots := []Snapshot{
+		{ID: "data-1", Time: at(1), Hostname: "host-0", Paths: []string{"/data"}, Tags: []string{"daily"}},
+		{ID: "logs-1", Time: at(1), Hostname: "host-0", Paths: []string{"/logs"}, Tags: []string{"daily"}},
+		{ID: "data-2", Time: at(2), Hostname: "host-0", Paths: []string{"/data"}, Tags: []string{"daily"}},
+		{ID: "logs-2", Time: at(2), Hostname: "host-0", Paths: []string{"/logs"}},
+		{ID: "data-3", Time: at(3), Hostname: "host-1", Paths: []string{"/data"}, Tags: []string{"daily"}},
+	}
+
+	ids := func(snapshots []Snapshot) []string {
+		var result []string
+		for _, s := range snapshots {
+			result = append(result, s.ID)
+		}
+		return result
+	}
+	assert.Equal(t, []string{"data-2", "logs-2"}, ids(selectSnapshotsOfHost(snapshots, "host-0", SnapshotFilter{})))
+	assert.Equal(t, []string{"data-2", "logs-1"}, ids(selectSnapshotsOfHost(snapshots, "host-0", SnapshotFilter{Tags: []string{"daily"}})))
+	assert.Equal(t, []string{"data-1", "logs-1"}, ids(selectSnapshotsOfHost(snapshots, "host-0", SnapshotFilter{Before: at(2)})))
+	assert.Empty(t, selectSnapshotsOfHost(snapshots, "host-0", SnapshotFilter{After: at(2)}))
+}
+
+func TestSnapshotFilesOf(t *testing.T) {
+	out := []byte(`{"time":"2024-03-01T00:00:00Z","paths":["/data"],"hostname":"host-0","id":"s1","struct_type":"snapshot"}
+{"name":"data","type":"dir","path":"/data","uid":0,"gid":0,"mode":2147484141,"mtime":"2024-03-01T00:00:00Z","struct_type":"node"}
+{"name":"some-file","type":"file","path":"/data/some-file","uid":1000,"gid":1000,"size":11,"mode":420,"mtime":"2024-03-01T00:00:00Z","struct_type":"node"}
+{"name":"logs","type":"dir","path":"/data/logs","uid":1000,"gid":1000,"mode":2147484141,"mtime":"2024-03-01T00:00:00Z","struct_type":"node"}
+{"name":"app.log","type":"file","path":"/data/logs/app.log","uid":1000,"gid":1000,"size":42,"mode":420,"mtime":"2024-03-01T00:00:00Z","struct_type":"node"}
+`)
+	_, nodes, err := extractSnapshotFiles(out)
+	if !assert.NoError(t, err) {
+		return
+	}
+	files := snapshotFilesOf(nodes, "/data")
+	if assert.Len(t, files, 2) {
+		assert.Equal(t, "/data/some-file", files[0].Path)
+		assert.Equal(t, uint64(11), files[0].Size)
+		assert.Equal(t, "-rw-r--r--", files[0].Mode)
+		assert.Equal(t, "/data/logs", files[1].Path)
+		assert.EqualValues(t, "dir", files[1].Type)
+	}
+}
+
+func TestNewSnapshotStatus(t *testing.T) {
+	start := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	status := NewSnapshotStatus(Snapshot{
+		ID:       "s1",
+		Time:     start,
+		Hostname: "host-0",
+		Parent:   "s0",
+		Summary: &SnapshotSummary{
+			BackupStart:         start,
+			BackupEnd:           start.Add(time.Minute),
+			FilesNew:            2,
+			TotalBytesProcessed: 1536,
+		},
+	}, "local-repo", "sample-backup-1709251200")
+	assert.Equal(t, "local-repo", status.Repository)
+	assert.Equal(t, "sample-backup-1709251200", status.BackupSession)
+	assert.Equal(t, "s0", status.Parent)
+	assert.Equal(t, start, status.Time.Time)
+	assert.Equal(t, formatBytes(1536), status.TotalSize)
+	if assert.NotNil(t, status.Summary) {
+		assert.Equal(t, int64(2), status.Summary.FilesNew)
+		assert.Equal(t, start.Add(time.Minute), status.Summary.BackupEnd.Time)
+	}
+}
+
+func TestExtractHostStats(t *testing.T) {
+	at := func(day int) time.Time {
+		return time.Date(2024, time.March, day, 0, 0, 0, 0, time.UTC)
+	}
+	hosts := extractHostStats([]Snapshot{
+		{ID: "s1", Time: at(1), Hostname: "host-1"},
+		{ID: "s2", Time: at(3), Hostname: "host-0"},
+		{ID: "s3", Time: at(2), Hostname: "host-0"},
+	})
+	if assert.Len(t, hosts, 2) {
+		assert.Equal(t, "host-0", hosts[0].Hostname)
+		assert.Equal(t, int64(2), hosts[0].SnapshotCount)
+		assert.Equal(t, "s2", hosts[0].LastSnapshot)
+		assert.Equal(t, at(3), hosts[0].LastSuccessTime.Time)
+		assert.Equal(t, "host-1", hosts[1].Hostname)
+		assert.Equal(t, int64(1), hosts[1].SnapshotCount)
+	}
+
+	ratio, err := extractCompressionRatio([]byte(`{"total_size":1024,"total_uncompressed_size":2560,"compression_ratio":2.5}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "2.50", ratio)
+}
+
+func TestParseBytes(t *testing.T) {
+	for _, size := range []uint64{0, 512, 1536, 5 << 20, 3 << 30, 2 << 40} {
+		got, err := parseBytes(formatBytes(size))
+		if assert.NoError(t, err) {
+			assert.Equal(t, size, got)
+		}
+	}
+	_, err := parseBytes("12 PB")
+	assert.Error(t, err)
+}
+
+func TestLockedSnapshots(t *testing.T) {
+	now := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+	groups := []ForgetGroup{
+		{
+			Host:   "host-0",
+			Keep:   []Snapshot{{ID: "s4", Time: now.Add(-time.Hour)}},
+			Remove: []Snapshot{{ID: "s1", Time: now.Add(-72 * time.Hour)}, {ID: "s3", Time: now.Add(-12 * time.Hour)}},
+		},
+		{
+			Host:   "host-1",
+			Remove: []Snapshot{{ID: "s2", Time: now.Add(-47 * time.Hour)}},
+		},
+	}
+	policy := api_v1alpha1.ImmutabilityPolicy{RetentionPeriod: metav1.Duration{Duration: 48 * time.Hour}}
+	assert.Equal(t, []string{"s3", "s2"}, lockedSnapshots(groups, policy, now))
+
+	policy.RetentionPeriod.Duration = time.Hour
+	assert.Empty(t, lockedSnapshots(groups, policy, now))
+}
+
+func TestPruneWithImmutability(t *testing.T) {
+	w := &ResticWrapper{config: SetupOptions{
+		Immutability: &api_v1alpha1.ImmutabilityPolicy{RetentionPeriod: metav1.Duration{Duration: time.Hour}},
+	}}
+	_, err := w.Prune(PruneOptions{RepackSmall: true})
+	assert.Error(t, err)
+}
+
 func newParallelBackupOptions() []BackupOptions {
 	return []BackupOptions{
 		{
@@ -981,3 +1575,21 @@ func newParallelDumpOptions() []DumpOptions {
 		},
 	}
 }
+
+func TestSetImmutability(t *testing.T) {
+	repo := &api_v1alpha1.Repository{
+		Spec: api_v1alpha1.RepositorySpec{
+			Immutability: &api_v1alpha1.ImmutabilityPolicy{RetentionPeriod: metav1.Duration{Duration: time.Hour}},
+		},
+	}
+	var opt SetupOptions
+	opt.SetImmutability(repo)
+	if assert.NotNil(t, opt.Immutability) {
+		assert.Equal(t, time.Hour, opt.Immutability.RetentionPeriod.Duration)
+		assert.NotSame(t, repo.Spec.Immutability, opt.Immutability)
+	}
+
+	repo.Spec.Immutability.RetentionPeriod.Duration = 0
+	opt.SetImmutability(repo)
+	assert.Nil(t, opt.Immutability)
+}
@@ -17,12 +17,16 @@ limitations under the License.
 package restic
 
 import (
+	"fmt"
+	"path/filepath"
 	"sync"
 	"time"
 
 	api_v1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
 )
 
 // RunRestore run restore process for a single host.
@@ -237,6 +241,26 @@ func (w *ResticWrapper) runRestore(restoreOptions RestoreOptions) error {
 				return err
 			}
 		}
+	} else if !restoreOptions.SnapshotFilter.IsEmpty() {
+		// resolve the snapshot of each path using the filter as restic can only restore the latest snapshot by itself
+		resolved, err := w.resolveSnapshots(restoreOptions)
+		if err != nil {
+			return err
+		}
+		for _, r := range resolved {
+			klog.Infoln("Restoring snapshot", r.snapshot.ID, "taken at", r.snapshot.Time, "for paths", r.snapshot.Paths)
+			params := restoreParams{
+				destination: restoreOptions.Destination,
+				snapshotId:  r.snapshot.ID,
+				excludes:    restoreOptions.Exclude,
+				includes:    restoreOptions.Include,
+				args:        restoreOptions.Args,
+			}
+			rewritePath(&params, r.path, restoreOptions.PathRewrites)
+			if _, err := w.restore(params); err != nil {
+				return err
+			}
+		}
 	} else if len(restoreOptions.RestorePaths) != 0 {
 		for _, path := range restoreOptions.RestorePaths {
 			params := restoreParams{
@@ -247,6 +271,7 @@ func (w *ResticWrapper) runRestore(restoreOptions RestoreOptions) error {
 				includes:    restoreOptions.Include,
 				args:        restoreOptions.Args,
 			}
+			rewritePath(&params, path, restoreOptions.PathRewrites)
 			if _, err := w.restore(params); err != nil {
 				return err
 			}
@@ -255,6 +280,112 @@ func (w *ResticWrapper) runRestore(restoreOptions RestoreOptions) error {
 	return nil
 }
 
+// PlanRestore resolves the snapshots that would be restored for a host without restoring anything.
+// The snapshots are resolved in the same way as RunRestore does. The size of a snapshot is its total restore size.
+// So, it can be larger than the actually restored data when include or exclude patterns are used.
+func (w *ResticWrapper) PlanRestore(restoreOptions RestoreOptions) (*api_v1beta1.HostRestorePlan, error) {
+	plan := &api_v1beta1.HostRestorePlan{
+		Hostname:   restoreOptions.Host,
+		SourceHost: restoreOptions.SourceHost,
+	}
+
+	var planned []resolvedSnapshot
+	if len(restoreOptions.Snapshots) != 0 {
+		snapshots, err := w.ListSnapshots(restoreOptions.Snapshots)
+		if err != nil {
+			return nil, err
+		}
+		if len(snapshots) != len(restoreOptions.Snapshots) {
+			return nil, fmt.Errorf("found %d snapshots out of %d specified snapshots", len(snapshots), len(restoreOptions.Snapshots))
+		}
+		for _, snapshot := range snapshots {
+			planned = append(planned, resolvedSnapshot{snapshot: snapshot})
+		}
+	} else if !restoreOptions.SnapshotFilter.IsEmpty() || len(restoreOptions.RestorePaths) != 0 {
+		var err error
+		if planned, err = w.resolveSnapshots(restoreOptions); err != nil {
+			return nil, err
+		}
+	}
+
+	var totalSize uint64
+	for _, p := range planned {
+		size, err := w.GetSnapshotRestoreSize(p.snapshot.ID)
+		if err != nil {
+			return nil, err
+		}
+		totalSize += size
+
+		params := restoreParams{destination: restoreOptions.Destination}
+		rewritePath(&params, p.path, restoreOptions.PathRewrites)
+		if params.destination == "" {
+			params.destination = "/"
+		}
+		paths := p.snapshot.Paths
+		if p.path != "" {
+			paths = []string{p.path}
+		}
+		plan.Snapshots = append(plan.Snapshots, api_v1beta1.SnapshotRestorePlan{
+			ID:          p.snapshot.ID,
+			Time:        &metav1.Time{Time: p.snapshot.Time},
+			Paths:       paths,
+			Destination: params.destination,
+			Size:        formatBytes(size),
+		})
+	}
+	plan.TotalSize = formatBytes(totalSize)
+	return plan, nil
+}
+
+// rewritePath restores the content of the path into its rewritten location instead of the original one
+// resolvedSnapshot is a snapshot resolved for a restore along with the path to restore from it.
+// The path is empty when the whole snapshot is restored.
+type resolvedSnapshot struct {
+	snapshot Snapshot
+	path     string
+}
+
+// resolveSnapshots resolves the latest snapshot that satisfies the filter for each of the restore paths.
+// When no path is specified, it resolves a snapshot for each distinct set of paths backed up from the source host.
+// Otherwise, the snapshots of a host that backs up multiple paths separately could not be restored together.
+func (w *ResticWrapper) resolveSnapshots(restoreOptions RestoreOptions) ([]resolvedSnapshot, error) {
+	var resolved []resolvedSnapshot
+	if len(restoreOptions.RestorePaths) == 0 {
+		snapshots, err := w.FindSnapshotsOfHost(restoreOptions.SourceHost, restoreOptions.SnapshotFilter)
+		if err != nil {
+			return nil, err
+		}
+		for _, snapshot := range snapshots {
+			resolved = append(resolved, resolvedSnapshot{snapshot: snapshot})
+		}
+		return resolved, nil
+	}
+	for _, path := range restoreOptions.RestorePaths {
+		snapshot, err := w.FindSnapshot(restoreOptions.SourceHost, path, restoreOptions.SnapshotFilter)
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, resolvedSnapshot{snapshot: *snapshot, path: path})
+	}
+	return resolved, nil
+}
+
+func rewritePath(params *restoreParams, path string, rewrites []api_v1beta1.PathRewrite) {
+	if path == "" {
+		return
+	}
+	newPath, ok := api_v1beta1.RewritePath(path, rewrites)
+	if !ok {
+		return
+	}
+	destination := params.destination
+	if destination == "" {
+		destination = "/"
+	}
+	params.subfolder = path
+	params.destination = filepath.Join(destination, newPath)
+}
+
 func (restoreOutput *RestoreOutput) upsertHostRestoreStats(hostStats api_v1beta1.HostRestoreStats) {
 	// check if a entry already exist for this host in restoreOutput. If exist then update it.
 	for i, v := range restoreOutput.RestoreTargetStatus.Stats {
@@ -16,16 +16,281 @@ limitations under the License.
 
 package restic
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	repo_v1alpha1 "stash.appscode.dev/apimachinery/apis/repositories/v1alpha1"
+	api_v1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 func (w *ResticWrapper) ListSnapshots(snapshotIDs []string) ([]Snapshot, error) {
 	return w.listSnapshots(snapshotIDs)
 }
 
+// FindSnapshot returns the latest snapshot of the given host and path that satisfies the filter.
+// Empty host or path matches any host or path respectively.
+func (w *ResticWrapper) FindSnapshot(host, path string, filter SnapshotFilter) (*Snapshot, error) {
+	snapshots, err := w.ListSnapshots(nil)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := selectSnapshot(snapshots, host, path, filter)
+	if snapshot == nil {
+		return nil, fmt.Errorf("no snapshot found for host %q and path %q that matches %s", host, path, filter)
+	}
+	return snapshot, nil
+}
+
+// FindSnapshotsOfHost returns the latest snapshot that satisfies the filter for each distinct set of paths
+// backed up from the host. Empty host matches any host.
+func (w *ResticWrapper) FindSnapshotsOfHost(host string, filter SnapshotFilter) ([]Snapshot, error) {
+	snapshots, err := w.ListSnapshots(nil)
+	if err != nil {
+		return nil, err
+	}
+	selected := selectSnapshotsOfHost(snapshots, host, filter)
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no snapshot found for host %q that matches %s", host, filter)
+	}
+	return selected, nil
+}
+
+// NewSnapshotFilter returns the SnapshotFilter specified by the time and tag selectors of a restore rule
+func NewSnapshotFilter(rule api_v1beta1.Rule) SnapshotFilter {
+	filter := SnapshotFilter{
+		Tags: rule.Tags,
+	}
+	if rule.RestoreTime != nil {
+		filter.RestoreTime = rule.RestoreTime.Time
+	}
+	if rule.Before != nil {
+		filter.Before = rule.Before.Time
+	}
+	if rule.After != nil {
+		filter.After = rule.After.Time
+	}
+	return filter
+}
+
+// IsEmpty checks whether the filter selects the latest snapshot
+func (f SnapshotFilter) IsEmpty() bool {
+	return f.RestoreTime.IsZero() && f.Before.IsZero() && f.After.IsZero() && len(f.Tags) == 0
+}
+
+// Matches checks whether the snapshot satisfies all the conditions of the filter
+func (f SnapshotFilter) Matches(snapshot Snapshot) bool {
+	if !f.RestoreTime.IsZero() && snapshot.Time.After(f.RestoreTime) {
+		return false
+	}
+	if !f.Before.IsZero() && !snapshot.Time.Before(f.Before) {
+		return false
+	}
+	if !f.After.IsZero() && !snapshot.Time.After(f.After) {
+		return false
+	}
+	for _, tag := range f.Tags {
+		if !slices.Contains(snapshot.Tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f SnapshotFilter) String() string {
+	var conditions []string
+	if !f.RestoreTime.IsZero() {
+		conditions = append(conditions, "restoreTime="+f.RestoreTime.UTC().Format(time.RFC3339))
+	}
+	if !f.Before.IsZero() {
+		conditions = append(conditions, "before="+f.Before.UTC().Format(time.RFC3339))
+	}
+	if !f.After.IsZero() {
+		conditions = append(conditions, "after="+f.After.UTC().Format(time.RFC3339))
+	}
+	if len(f.Tags) > 0 {
+		conditions = append(conditions, "tags="+strings.Join(f.Tags, ","))
+	}
+	return "{" + strings.Join(conditions, " ") + "}"
+}
+
+// selectSnapshot returns the latest snapshot of the given host and path that satisfies the filter
+func selectSnapshot(snapshots []Snapshot, host, path string, filter SnapshotFilter) *Snapshot {
+	var latest *Snapshot
+	for i := range snapshots {
+		s := &snapshots[i]
+		if host != "" && s.Hostname != host {
+			continue
+		}
+		if path != "" && !slices.Contains(s.Paths, path) {
+			continue
+		}
+		if !filter.Matches(*s) {
+			continue
+		}
+		if latest == nil || s.Time.After(latest.Time) {
+			latest = s
+		}
+	}
+	return latest
+}
+
+// selectSnapshotsOfHost groups the snapshots of the given host by their paths and returns the latest snapshot
+// of each group that satisfies the filter. The result is sorted by the paths of the snapshots.
+func selectSnapshotsOfHost(snapshots []Snapshot, host string, filter SnapshotFilter) []Snapshot {
+	latest := map[string]*Snapshot{}
+	for i := range snapshots {
+		s := &snapshots[i]
+		if host != "" && s.Hostname != host {
+			continue
+		}
+		if !filter.Matches(*s) {
+			continue
+		}
+		paths := slices.Clone(s.Paths)
+		slices.Sort(paths)
+		key := strings.Join(paths, "\x00")
+		if cur, ok := latest[key]; !ok || s.Time.After(cur.Time) {
+			latest[key] = s
+		}
+	}
+
+	keys := make([]string, 0, len(latest))
+	for key := range latest {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	selected := make([]Snapshot, 0, len(keys))
+	for _, key := range keys {
+		selected = append(selected, *latest[key])
+	}
+	return selected
+}
+
+// NewSnapshotStatus converts a restic snapshot into the status of the Snapshot api object.
+// The backupSession is the name of the BackupSession that has produced the snapshot. It is empty if unknown.
+// The total size and the summary are set only when restic has recorded the summary of the backup.
+func NewSnapshotStatus(snapshot Snapshot, repository, backupSession string) repo_v1alpha1.SnapshotStatus {
+	status := repo_v1alpha1.SnapshotStatus{
+		Tree:           snapshot.Tree,
+		Paths:          snapshot.Paths,
+		Hostname:       snapshot.Hostname,
+		Username:       snapshot.Username,
+		UID:            int32(snapshot.UID),
+		Gid:            int32(snapshot.Gid),
+		Tags:           snapshot.Tags,
+		Repository:     repository,
+		BackupSession:  backupSession,
+		Parent:         snapshot.Parent,
+		ProgramVersion: snapshot.ProgramVersion,
+	}
+	if !snapshot.Time.IsZero() {
+		status.Time = &metav1.Time{Time: snapshot.Time}
+	}
+	if snapshot.Summary != nil {
+		summary := snapshot.Summary
+		status.TotalSize = formatBytes(summary.TotalBytesProcessed)
+		status.Summary = &repo_v1alpha1.SnapshotSummary{
+			BackupStart:         &metav1.Time{Time: summary.BackupStart},
+			BackupEnd:           &metav1.Time{Time: summary.BackupEnd},
+			FilesNew:            summary.FilesNew,
+			FilesChanged:        summary.FilesChanged,
+			FilesUnmodified:     summary.FilesUnmodified,
+			DirsNew:             summary.DirsNew,
+			DirsChanged:         summary.DirsChanged,
+			DirsUnmodified:      summary.DirsUnmodified,
+			TotalFilesProcessed: summary.TotalFilesProcessed,
+			DataAdded:           formatBytes(summary.DataAdded),
+			TotalBytesProcessed: formatBytes(summary.TotalBytesProcessed),
+		}
+	}
+	return status
+}
+
+// ListSnapshotFiles lists the files and directories of a directory of a snapshot.
+// The root directory of the snapshot is listed when dir is empty.
+func (w *ResticWrapper) ListSnapshotFiles(snapshotID, dir string) (*repo_v1alpha1.SnapshotFiles, error) {
+	out, err := w.listFiles(snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	_, nodes, err := extractSnapshotFiles(out)
+	if err != nil {
+		return nil, err
+	}
+	dir = path.Clean("/" + dir)
+	return &repo_v1alpha1.SnapshotFiles{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: repo_v1alpha1.SchemeGroupVersion.String(),
+			Kind:       repo_v1alpha1.ResourceKindSnapshotFiles,
+		},
+		Path:  dir,
+		Items: snapshotFilesOf(nodes, dir),
+	}, nil
+}
+
+// snapshotFilesOf returns the direct children of dir from the file nodes of a snapshot
+func snapshotFilesOf(nodes []SnapshotNode, dir string) []repo_v1alpha1.SnapshotFile {
+	var items []repo_v1alpha1.SnapshotFile
+	for _, node := range nodes {
+		if node.Path == dir || path.Dir(node.Path) != dir {
+			continue
+		}
+		item := repo_v1alpha1.SnapshotFile{
+			Name: node.Name,
+			Path: node.Path,
+			Type: repo_v1alpha1.SnapshotFileType(node.Type),
+			Size: node.Size,
+			Mode: node.Mode.String(),
+			UID:  int32(node.UID),
+			Gid:  int32(node.GID),
+		}
+		if !node.ModTime.IsZero() {
+			item.ModTime = &metav1.Time{Time: node.ModTime}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
 func (w *ResticWrapper) DeleteSnapshots(snapshotIDs []string) ([]byte, error) {
 	return w.deleteSnapshots(snapshotIDs)
 }
 
+// Forget removes the snapshots that do not match the retention rules of the given options.
+// It returns the snapshot groups showing which snapshots have been kept (along with the reasons) and which have been removed.
+// In dry-run mode, nothing is removed. So, the groups can be used to preview the effect of a retention rule.
+func (w *ResticWrapper) Forget(opt ForgetOptions) ([]ForgetGroup, error) {
+	if err := validateGroupBy(opt.GroupBy); err != nil {
+		return nil, err
+	}
+	out, err := w.forget(opt)
+	if err != nil {
+		return nil, err
+	}
+	return extractForgetGroups(out)
+}
+
+func validateGroupBy(groupBy string) error {
+	if groupBy == "" {
+		return nil
+	}
+	for _, field := range strings.Split(groupBy, ",") {
+		switch field {
+		case "host", "paths", "tags":
+		default:
+			return fmt.Errorf("invalid group-by field %q. allowed fields are host, paths and tags", field)
+		}
+	}
+	return nil
+}
+
 // GetSnapshotSize returns size of a snapshot in bytes
 func (w *ResticWrapper) GetSnapshotSize(snapshotID string) (uint64, error) {
 	out, err := w.stats(snapshotID)
@@ -41,6 +306,21 @@ func (w *ResticWrapper) GetSnapshotSize(snapshotID string) (uint64, error) {
 	return stat.TotalSize, nil
 }
 
+// GetSnapshotRestoreSize returns the size of the data in bytes that would be restored from a snapshot
+func (w *ResticWrapper) GetSnapshotRestoreSize(snapshotID string) (uint64, error) {
+	out, err := w.statsWithMode(snapshotID, "restore-size")
+	if err != nil {
+		return 0, err
+	}
+
+	var stat StatsContainer
+	err = json.Unmarshal(out, &stat)
+	if err != nil {
+		return 0, err
+	}
+	return stat.TotalSize, nil
+}
+
 func (w *ResticWrapper) DownloadSnapshot(snapshot string, destination string) ([]byte, error) {
 	params := restoreParams{
 		snapshotId:  snapshot,
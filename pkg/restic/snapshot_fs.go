@@ -0,0 +1,294 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restic
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SnapshotFS is a read-only fs.FS backed by a restic snapshot.
+// The directory tree is read once using "restic ls" and the file contents are fetched on demand using "restic dump".
+// So, the snapshot can be inspected using fs.WalkDir, fs.ReadFile etc. without restoring it into the disk.
+//
+// The root of the file system represents the root ("/") of the snapshot. Hence, a file that was backed up
+// from "/source/data/file.txt" can be accessed as "source/data/file.txt".
+type SnapshotFS struct {
+	w        *ResticWrapper
+	snapshot Snapshot
+	nodes    map[string]*SnapshotNode
+	children map[string][]string
+	// mu serializes the restic commands as the underlying shell session can not be used concurrently
+	mu sync.Mutex
+}
+
+var (
+	_ fs.FS         = &SnapshotFS{}
+	_ fs.StatFS     = &SnapshotFS{}
+	_ fs.ReadDirFS  = &SnapshotFS{}
+	_ fs.ReadFileFS = &SnapshotFS{}
+)
+
+// SnapshotFS returns a read-only fs.FS for the given snapshot. Use "latest" to get the latest snapshot of the repository.
+func (w *ResticWrapper) SnapshotFS(snapshotID string) (*SnapshotFS, error) {
+	// use a separate shell session so that the caller can keep using the wrapper concurrently
+	nw := w.Copy()
+	out, err := nw.listFiles(snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, nodes, err := extractSnapshotFiles(out)
+	if err != nil {
+		return nil, err
+	}
+
+	sfs := &SnapshotFS{
+		w:        nw,
+		snapshot: *snapshot,
+		nodes:    make(map[string]*SnapshotNode),
+		children: make(map[string][]string),
+	}
+	sfs.nodes["."] = &SnapshotNode{
+		Name:    ".",
+		Type:    "dir",
+		Path:    "/",
+		Mode:    fs.ModeDir | 0o755,
+		ModTime: snapshot.Time,
+	}
+	for i := range nodes {
+		name := toFSPath(nodes[i].Path)
+		if name == "." {
+			continue
+		}
+		sfs.nodes[name] = &nodes[i]
+		parent := path.Dir(name)
+		sfs.children[parent] = append(sfs.children[parent], name)
+	}
+	for dir := range sfs.children {
+		sort.Strings(sfs.children[dir])
+	}
+	return sfs, nil
+}
+
+// Snapshot returns the snapshot this file system has been created from
+func (sfs *SnapshotFS) Snapshot() Snapshot {
+	return sfs.snapshot
+}
+
+// Open opens the named file or directory. The content of a file is fetched from the repository on the first read.
+func (sfs *SnapshotFS) Open(name string) (fs.File, error) {
+	node, err := sfs.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+	if node.Type == "dir" {
+		return &snapshotDir{fs: sfs, name: name, node: node}, nil
+	}
+	return &snapshotFile{fs: sfs, name: name, node: node}, nil
+}
+
+// Stat returns the FileInfo of the named file without running any restic command.
+func (sfs *SnapshotFS) Stat(name string) (fs.FileInfo, error) {
+	node, err := sfs.lookup("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return snapshotFileInfo{node: node}, nil
+}
+
+// ReadDir reads the named directory and returns its entries sorted by filename.
+func (sfs *SnapshotFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	node, err := sfs.lookup("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	if node.Type != "dir" {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	return sfs.dirEntries(name), nil
+}
+
+// ReadFile reads the named file from the repository and returns its content.
+func (sfs *SnapshotFS) ReadFile(name string) ([]byte, error) {
+	node, err := sfs.lookup("read", name)
+	if err != nil {
+		return nil, err
+	}
+	return sfs.dump(name, node)
+}
+
+func (sfs *SnapshotFS) lookup(op, name string) (*SnapshotNode, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	node, ok := sfs.nodes[name]
+	if !ok {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	return node, nil
+}
+
+func (sfs *SnapshotFS) dirEntries(name string) []fs.DirEntry {
+	entries := make([]fs.DirEntry, 0, len(sfs.children[name]))
+	for _, child := range sfs.children[name] {
+		entries = append(entries, fs.FileInfoToDirEntry(snapshotFileInfo{node: sfs.nodes[child]}))
+	}
+	return entries
+}
+
+// dump reads the content of a regular file from the repository using "restic dump" command
+func (sfs *SnapshotFS) dump(name string, node *SnapshotNode) ([]byte, error) {
+	if !(snapshotFileInfo{node: node}).Mode().IsRegular() {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrInvalid}
+	}
+
+	sfs.mu.Lock()
+	defer sfs.mu.Unlock()
+
+	sfs.w.HideCMD()
+	out, err := sfs.w.DumpOnce(DumpOptions{
+		Snapshot: sfs.snapshot.ID,
+		FileName: node.Path,
+	})
+	if err != nil {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: err}
+	}
+	return out, nil
+}
+
+// toFSPath converts an absolute path of the snapshot into a path valid for fs.FS
+func toFSPath(p string) string {
+	p = strings.TrimPrefix(path.Clean("/"+p), "/")
+	if p == "" {
+		return "."
+	}
+	return p
+}
+
+// snapshotFileInfo implements fs.FileInfo for a SnapshotNode
+type snapshotFileInfo struct {
+	node *SnapshotNode
+}
+
+func (fi snapshotFileInfo) Name() string {
+	if fi.node.Path == "/" {
+		return "."
+	}
+	return fi.node.Name
+}
+
+func (fi snapshotFileInfo) Size() int64 {
+	return int64(fi.node.Size)
+}
+
+func (fi snapshotFileInfo) Mode() fs.FileMode {
+	mode := fi.node.Mode
+	switch fi.node.Type {
+	case "dir":
+		mode |= fs.ModeDir
+	case "symlink":
+		mode |= fs.ModeSymlink
+	}
+	return mode
+}
+
+func (fi snapshotFileInfo) ModTime() time.Time {
+	return fi.node.ModTime
+}
+
+func (fi snapshotFileInfo) IsDir() bool {
+	return fi.node.Type == "dir"
+}
+
+func (fi snapshotFileInfo) Sys() any {
+	return fi.node
+}
+
+// snapshotDir is an opened directory of a SnapshotFS
+type snapshotDir struct {
+	fs      *SnapshotFS
+	name    string
+	node    *SnapshotNode
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *snapshotDir) Stat() (fs.FileInfo, error) {
+	return snapshotFileInfo{node: d.node}, nil
+}
+
+func (d *snapshotDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: fs.ErrInvalid}
+}
+
+func (d *snapshotDir) Close() error {
+	return nil
+}
+
+func (d *snapshotDir) ReadDir(count int) ([]fs.DirEntry, error) {
+	if d.entries == nil {
+		d.entries = d.fs.dirEntries(d.name)
+	}
+	remaining := len(d.entries) - d.offset
+	if count <= 0 {
+		entries := d.entries[d.offset:]
+		d.offset = len(d.entries)
+		return entries, nil
+	}
+	if remaining == 0 {
+		return nil, io.EOF
+	}
+	if count > remaining {
+		count = remaining
+	}
+	entries := d.entries[d.offset : d.offset+count]
+	d.offset += count
+	return entries, nil
+}
+
+// snapshotFile is an opened file of a SnapshotFS. The content is fetched from the repository on the first read.
+type snapshotFile struct {
+	fs     *SnapshotFS
+	name   string
+	node   *SnapshotNode
+	reader *bytes.Reader
+}
+
+func (f *snapshotFile) Stat() (fs.FileInfo, error) {
+	return snapshotFileInfo{node: f.node}, nil
+}
+
+func (f *snapshotFile) Read(b []byte) (int, error) {
+	if f.reader == nil {
+		data, err := f.fs.dump(f.name, f.node)
+		if err != nil {
+			return 0, err
+		}
+		f.reader = bytes.NewReader(data)
+	}
+	return f.reader.Read(b)
+}
+
+func (f *snapshotFile) Close() error {
+	return nil
+}
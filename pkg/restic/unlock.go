@@ -20,6 +20,8 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -29,13 +31,100 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 	kutil "kmodules.xyz/client-go"
+	storage "kmodules.xyz/objectstore-api/api/v1"
 )
 
+// StaleLockPolicy specifies when a lock should be considered stale.
+// A lock is stale if any of the enabled conditions is satisfied.
+type StaleLockPolicy struct {
+	// MaxAge marks a lock as stale if it has not been refreshed within this duration.
+	// Restic refreshes the locks of a running process every 5 minutes. Zero disables this check.
+	// For the backends other than local, it must be at least 30 minutes as restic does not remove a younger lock.
+	MaxAge time.Duration
+	// PodGone marks a lock as stale if the Pod holding the lock does not exist anymore or has completed.
+	PodGone bool
+	// NodeGone marks a lock as stale if the node where the lock holding Pod was running does not exist anymore.
+	NodeGone bool
+	// WaitTimeout specifies how long EnsureNoExclusiveLock should wait for an exclusive lock to be released.
+	// Default value is kutil.ReadinessTimeout.
+	WaitTimeout time.Duration
+}
+
+// resticStaleLockAge is the age after which restic itself considers a lock stale.
+// Restic refreshes the locks of a running process every 5 minutes, so such a lock can not belong to a live process.
+const resticStaleLockAge = 30 * time.Minute
+
+// DefaultStaleLockPolicy considers a lock stale only when the Pod holding the lock is gone
+func DefaultStaleLockPolicy() StaleLockPolicy {
+	return StaleLockPolicy{
+		PodGone:     true,
+		WaitTimeout: kutil.ReadinessTimeout,
+	}
+}
+
 func (w *ResticWrapper) UnlockRepository() error {
 	_, err := w.unlock()
 	return err
 }
 
+// ListLocks returns every lock currently held in the repository.
+func (w *ResticWrapper) ListLocks() ([]LockStats, error) {
+	ids, err := w.getLockIDs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list locks: %w", err)
+	}
+	locks := make([]LockStats, 0, len(ids))
+	for _, id := range ids {
+		st, err := w.getLockStats(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect lock %s: %w", id, err)
+		}
+		locks = append(locks, *st)
+	}
+	return locks, nil
+}
+
+// FindStaleLocks returns the locks that are stale according to the given policy.
+// The hostname of a lock is the name of the Pod that holds the lock and the Pod is looked up in the given namespace.
+func (w *ResticWrapper) FindStaleLocks(k8sClient kubernetes.Interface, namespace string, policy StaleLockPolicy) ([]LockStats, error) {
+	locks, err := w.ListLocks()
+	if err != nil {
+		return nil, err
+	}
+	var stale []LockStats
+	for _, lock := range locks {
+		staleness, err := isStaleLock(k8sClient, namespace, lock, policy)
+		if err != nil {
+			return nil, err
+		}
+		if staleness.stale {
+			klog.Infoln("Lock", lock.ID, "held by", lock.Hostname, "is stale. Reason:", staleness.reason)
+			stale = append(stale, lock)
+		}
+	}
+	return stale, nil
+}
+
+// RemoveStaleLocks removes only the locks that are stale according to the given policy and returns their IDs.
+// Unlike UnlockRepository, it does not remove the live locks held by the concurrent backup or restore processes.
+func (w *ResticWrapper) RemoveStaleLocks(k8sClient kubernetes.Interface, namespace string, policy StaleLockPolicy) ([]string, error) {
+	if err := w.validateStaleLockPolicy(policy); err != nil {
+		return nil, err
+	}
+	stale, err := w.FindStaleLocks(k8sClient, namespace, policy)
+	if err != nil {
+		return nil, err
+	}
+	if len(stale) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, 0, len(stale))
+	for _, lock := range stale {
+		ids = append(ids, lock.ID)
+	}
+	return ids, w.removeLocks(stale)
+}
+
 // getLockIDs lists every lock ID currently held in the repository.
 func (w *ResticWrapper) getLockIDs() ([]string, error) {
 	w.sh.ShowCMD = true
@@ -53,65 +142,207 @@ func (w *ResticWrapper) getLockStats(lockID string) (*LockStats, error) {
 	if err != nil {
 		return nil, err
 	}
-	return extractLockStats(out)
+	stats, err := extractLockStats(out)
+	if err != nil {
+		return nil, err
+	}
+	stats.ID = lockID
+	return stats, nil
 }
 
-// getPodNameIfAnyExclusiveLock scans every lock and returns the hostname aka (Pod name) of the first exclusive lock it finds, or "" if none exist.
-func (w *ResticWrapper) getPodNameIfAnyExclusiveLock() (string, error) {
+// getExclusiveLock scans every lock and returns the first exclusive lock it finds, or nil if none exist.
+func (w *ResticWrapper) getExclusiveLock() (*LockStats, error) {
 	klog.Infoln("Checking for exclusive locks in the repository...")
 	ids, err := w.getLockIDs()
 	if err != nil {
-		return "", fmt.Errorf("failed to list locks: %w", err)
+		return nil, fmt.Errorf("failed to list locks: %w", err)
 	}
 	for _, id := range ids {
 		st, err := w.getLockStats(id)
 		if err != nil {
-			return "", fmt.Errorf("failed to inspect lock %s: %w", id, err)
+			return nil, fmt.Errorf("failed to inspect lock %s: %w", id, err)
 		}
 		if st.Exclusive { // There's no chances to get multiple exclusive locks, so we can return the first one we find.
-			return st.Hostname, nil
+			return st, nil
 		}
 	}
-	return "", nil
+	return nil, nil
 }
 
 // EnsureNoExclusiveLock blocks until any exclusive lock is released.
-// If a lock is held by a Running Pod, it waits; otherwise it unlocks.
+// If a lock is held by a Running Pod, it waits; otherwise it removes that lock.
 func (w *ResticWrapper) EnsureNoExclusiveLock(k8sClient kubernetes.Interface, namespace string) error {
+	return w.EnsureNoExclusiveLockWithPolicy(k8sClient, namespace, DefaultStaleLockPolicy())
+}
+
+// EnsureNoExclusiveLockWithPolicy blocks until the exclusive lock (if any) is released or becomes stale according to the given policy.
+// Only the stale exclusive lock is removed. The non-exclusive locks of the concurrent processes are kept as they are.
+func (w *ResticWrapper) EnsureNoExclusiveLockWithPolicy(k8sClient kubernetes.Interface, namespace string, policy StaleLockPolicy) error {
 	klog.Infoln("Ensuring no exclusive lock is held in the repository...")
-	podName, err := w.getPodNameIfAnyExclusiveLock()
+	if err := w.validateStaleLockPolicy(policy); err != nil {
+		return err
+	}
+	lock, err := w.getExclusiveLock()
 	if err != nil {
 		return fmt.Errorf("failed to query exclusive lock: %w", err)
 	}
-	if podName == "" {
+	if lock == nil {
 		klog.Infoln("No exclusive lock found, nothing to do.")
 		return nil // nothing to do
 	}
 
-	return wait.PollUntilContextTimeout(
+	timeout := policy.WaitTimeout
+	if timeout <= 0 {
+		timeout = kutil.ReadinessTimeout
+	}
+	err = wait.PollUntilContextTimeout(
 		context.Background(),
 		5*time.Second,
-		kutil.ReadinessTimeout,
+		timeout,
 		true,
 		func(ctx context.Context) (bool, error) {
-			klog.Infoln("Getting Pod:", podName, "to check if it's finished...")
-			pod, err := k8sClient.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
-			switch {
-			case errors.IsNotFound(err): // Pod gone → unlock
-				klog.Infoln("Pod:", podName, "not found, unlocking repository...")
-				_, err := w.unlock()
-				return true, err
-			case err != nil: // API error → stop
+			klog.Infoln("Checking whether the exclusive lock held by", lock.Hostname, "is stale...")
+			staleness, err := isStaleLock(k8sClient, namespace, *lock, policy)
+			if err != nil { // API error → stop
 				return false, err
-			case pod.Status.Phase == corev1.PodSucceeded ||
-				pod.Status.Phase == corev1.PodFailed: // Pod finished → unlock
-				klog.Infoln("Pod:", podName, "finished with phase", pod.Status.Phase, ", unlocking repository...")
-				_, err := w.unlock()
-				return true, err
-			default: // Not finished yet → keep waiting
-				klog.Infoln("Pod:", podName, "is in phase", pod.Status.Phase, ", waiting for it to finish...")
+			}
+			if !staleness.stale { // Not finished yet → keep waiting
+				klog.Infoln("Lock", lock.ID, "is still in use, waiting for it to be released...")
 				return false, nil
 			}
+			if !w.lockRemovable(*lock) {
+				klog.Infoln("Lock", lock.ID, "is stale. Reason:", staleness.reason, ". Waiting for restic to consider it stale too...")
+				return false, nil
+			}
+			klog.Infoln("Lock", lock.ID, "is stale. Reason:", staleness.reason, ". Removing the lock...")
+			return true, w.removeLocks([]LockStats{*lock})
 		},
 	)
+	if wait.Interrupted(err) {
+		return fmt.Errorf("exclusive lock %s held by %s has not been released within %s", lock.ID, lock.Hostname, timeout)
+	}
+	return err
+}
+
+// lockStaleness describes whether a lock is stale and why
+type lockStaleness struct {
+	stale  bool
+	reason string
+}
+
+// isStaleLock checks whether a lock is stale according to the policy. It also returns the reason why the lock is stale.
+func isStaleLock(k8sClient kubernetes.Interface, namespace string, lock LockStats, policy StaleLockPolicy) (lockStaleness, error) {
+	if policy.MaxAge > 0 && time.Since(lock.Time) > policy.MaxAge {
+		// the liveness of the Pod holding the lock is unknown
+		return lockStaleness{stale: true, reason: fmt.Sprintf("lock is older than %s", policy.MaxAge)}, nil
+	}
+	if !policy.PodGone && !policy.NodeGone {
+		return lockStaleness{}, nil
+	}
+
+	pod, err := k8sClient.CoreV1().Pods(namespace).Get(context.TODO(), lock.Hostname, metav1.GetOptions{})
+	switch {
+	case errors.IsNotFound(err):
+		if policy.PodGone {
+			return lockStaleness{stale: true, reason: fmt.Sprintf("pod %s not found", lock.Hostname)}, nil
+		}
+		return lockStaleness{}, nil
+	case err != nil:
+		return lockStaleness{}, err
+	}
+
+	if policy.PodGone && (pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed) {
+		return lockStaleness{stale: true, reason: fmt.Sprintf("pod %s finished with phase %s", pod.Name, pod.Status.Phase)}, nil
+	}
+	if policy.NodeGone && pod.Spec.NodeName != "" {
+		_, err := k8sClient.CoreV1().Nodes().Get(context.TODO(), pod.Spec.NodeName, metav1.GetOptions{})
+		switch {
+		case errors.IsNotFound(err):
+			return lockStaleness{stale: true, reason: fmt.Sprintf("node %s of pod %s not found", pod.Spec.NodeName, pod.Name)}, nil
+		case err != nil:
+			return lockStaleness{}, err
+		}
+	}
+	return lockStaleness{}, nil
+}
+
+// validateStaleLockPolicy ensures that the stale locks found by the policy can be removed from the backend.
+func (w *ResticWrapper) validateStaleLockPolicy(policy StaleLockPolicy) error {
+	if w.config.Provider != storage.ProviderLocal && policy.MaxAge > 0 && policy.MaxAge < resticStaleLockAge {
+		return fmt.Errorf("maxAge of the stale lock policy must be at least %s for the %s backend", resticStaleLockAge, w.config.Provider)
+	}
+	return nil
+}
+
+// lockRemovable returns true if the lock can be removed from the backend without affecting the other locks
+func (w *ResticWrapper) lockRemovable(lock LockStats) bool {
+	return w.config.Provider == storage.ProviderLocal || time.Since(lock.Time) > resticStaleLockAge
+}
+
+// removeLocks removes exactly the given locks from the repository.
+// Restic does not provide any command to remove a particular lock. So, for the local backend, the lock files are
+// removed directly. For the other backends, it runs "restic unlock" which removes only the locks that restic also
+// considers stale, so the live locks of the concurrent processes are never removed. The locks that restic does not
+// consider stale yet are not removed and reported in the returned error.
+func (w *ResticWrapper) removeLocks(locks []LockStats) error {
+	if w.config.Provider == storage.ProviderLocal {
+		for _, lock := range locks {
+			klog.Infoln("Removing lock", lock.ID)
+			err := os.Remove(filepath.Join(w.config.Bucket, "locks", lock.ID))
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var notRemovable []string
+	for _, lock := range locks {
+		if !w.lockRemovable(lock) {
+			notRemovable = append(notRemovable, lock.ID)
+		}
+	}
+	if len(notRemovable) > 0 {
+		return fmt.Errorf("failed to remove locks %v as they can not be removed from the %s backend before they are %s old",
+			notRemovable, w.config.Provider, resticStaleLockAge)
+	}
+
+	remaining, err := w.remainingLocks(locks)
+	if err != nil {
+		return err
+	}
+	if len(remaining) == 0 {
+		// the locks have already been released
+		return nil
+	}
+	if _, err := w.unlockStale(); err != nil {
+		return err
+	}
+	remaining, err = w.remainingLocks(locks)
+	if err != nil {
+		return err
+	}
+	if len(remaining) > 0 {
+		return fmt.Errorf("failed to remove locks %v as restic does not consider them stale", remaining)
+	}
+	return nil
+}
+
+// remainingLocks returns the IDs of the given locks that still exist in the repository
+func (w *ResticWrapper) remainingLocks(locks []LockStats) ([]string, error) {
+	ids, err := w.getLockIDs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list locks: %w", err)
+	}
+	existing := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		existing[id] = true
+	}
+	var remaining []string
+	for _, lock := range locks {
+		if existing[lock.ID] {
+			remaining = append(remaining, lock.ID)
+		}
+	}
+	return remaining, nil
 }
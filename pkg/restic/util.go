@@ -18,6 +18,8 @@ package restic
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 func formatBytes(c uint64) string {
@@ -37,6 +39,33 @@ func formatBytes(c uint64) string {
 	}
 }
 
+// parseBytes parses the size formatted by formatBytes back into bytes.
+// The result is approximate as formatBytes rounds the size into three decimal places.
+func parseBytes(size string) (uint64, error) {
+	value, unit, found := strings.Cut(strings.TrimSpace(size), " ")
+	if !found {
+		return 0, fmt.Errorf("invalid size %q", size)
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q: %w", size, err)
+	}
+	switch unit {
+	case "TiB":
+		v *= 1 << 40
+	case "GiB":
+		v *= 1 << 30
+	case "MiB":
+		v *= 1 << 20
+	case "KiB":
+		v *= 1 << 10
+	case "B":
+	default:
+		return 0, fmt.Errorf("invalid size %q: unknown unit %q", size, unit)
+	}
+	return uint64(v), nil
+}
+
 func formatSeconds(sec uint64) string {
 	hours := sec / 3600
 	sec -= hours * 3600